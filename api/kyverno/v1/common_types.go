@@ -2,14 +2,19 @@ package v1
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
 	"github.com/sigstore/k8s-manifest-sigstore/pkg/k8smanifest"
 	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/pod-security-admission/api"
 )
 
@@ -63,6 +68,14 @@ type ContextEntry struct {
 	// ConfigMap is the ConfigMap reference.
 	ConfigMap *ConfigMapReference `json:"configMap,omitempty" yaml:"configMap,omitempty"`
 
+	// Secret is a reference to a key of a Secret. The Secret data is redacted from
+	// rule messages, logs and policy reports.
+	Secret *SecretKeyReference `json:"secret,omitempty" yaml:"secret,omitempty"`
+
+	// Resource looks up Kubernetes resources by name or label selector. A single resource is
+	// returned when a name is given, the list of matching resources otherwise.
+	Resource *ResourceLookup `json:"resource,omitempty" yaml:"resource,omitempty"`
+
 	// APICall is an HTTP request to the Kubernetes API server, or other JSON web service.
 	// The data returned is stored in the context with the name for the context entry.
 	APICall *APICall `json:"apiCall,omitempty" yaml:"apiCall,omitempty"`
@@ -73,6 +86,58 @@ type ContextEntry struct {
 
 	// Variable defines an arbitrary JMESPath context variable that can be defined inline.
 	Variable *Variable `json:"variable,omitempty" yaml:"variable,omitempty"`
+
+	// GlobalReference is a reference to a cached global context entry.
+	GlobalReference *GlobalContextEntryReference `json:"globalReference,omitempty" yaml:"globalReference,omitempty"`
+
+	// OnError defines how a failure to load the context entry is handled.
+	// Defaults to failing the rule.
+	// +optional
+	OnError *OnError `json:"onError,omitempty" yaml:"onError,omitempty"`
+}
+
+// OnErrorAction specifies how a context entry load failure is handled.
+// +kubebuilder:validation:Enum=Fail;Ignore;UseDefault
+type OnErrorAction string
+
+const (
+	// OnErrorFail fails the rule when the context entry can't be loaded.
+	OnErrorFail OnErrorAction = "Fail"
+	// OnErrorIgnore leaves the context variable unset when the context entry can't be loaded.
+	OnErrorIgnore OnErrorAction = "Ignore"
+	// OnErrorUseDefault sets the context variable to a default value when the context entry can't be loaded.
+	OnErrorUseDefault OnErrorAction = "UseDefault"
+)
+
+// OnError defines how a failure to load a context entry is handled.
+type OnError struct {
+	// Action is the action taken when the context entry can't be loaded.
+	// Defaults to Fail.
+	// +optional
+	Action OnErrorAction `json:"action,omitempty" yaml:"action,omitempty"`
+
+	// Default is the value given to the context variable when the action is UseDefault.
+	// +optional
+	Default *apiextv1.JSON `json:"default,omitempty" yaml:"default,omitempty"`
+}
+
+// GetAction returns the action taken when the context entry can't be loaded, defaults to Fail.
+func (e *OnError) GetAction() OnErrorAction {
+	if e == nil || e.Action == "" {
+		return OnErrorFail
+	}
+	return e.Action
+}
+
+// GlobalContextEntryReference references a GlobalContextEntry.
+type GlobalContextEntryReference struct {
+	// Name is the name of the GlobalContextEntry resource.
+	Name string `json:"name" yaml:"name"`
+
+	// JMESPath is an optional JSON Match Expression that can be used to
+	// transform the cached data.
+	// +optional
+	JMESPath string `json:"jmesPath,omitempty" yaml:"jmesPath,omitempty"`
 }
 
 // Variable defines an arbitrary JMESPath context variable that can be defined inline.
@@ -115,6 +180,46 @@ type ConfigMapReference struct {
 	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
 }
 
+// ResourceLookup identifies Kubernetes resources by name or label selector.
+// Resources are fetched from the API server, unless their group version resource is listed in the
+// --resourceContextInformers flag of the controllers in which case they are served from an informer cache.
+type ResourceLookup struct {
+	// APIVersion is the API version of the resources (e.g. "apps/v1").
+	APIVersion string `json:"apiVersion" yaml:"apiVersion"`
+
+	// Kind is the kind of the resources (e.g. "Deployment").
+	Kind string `json:"kind" yaml:"kind"`
+
+	// Namespace is the namespace of the resources, leave it empty for cluster scoped resources
+	// or to look up resources in all namespaces. Namespaced policies can only look up resources
+	// in their own namespace, which is the default.
+	// +optional
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+
+	// Name is the name of the resource to look up. Name and Selector are mutually exclusive.
+	// +optional
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// Selector is the label selector of the resources to look up.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty" yaml:"selector,omitempty"`
+}
+
+// SecretKeyReference refers to a key of a Secret.
+type SecretKeyReference struct {
+	// Name is the Secret name.
+	Name string `json:"name" yaml:"name"`
+
+	// Namespace is the Secret namespace, defaults to the policy namespace.
+	// Secrets can only be loaded from the policy namespace or from the namespaces
+	// allowed in the Kyverno configuration.
+	// +optional
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+
+	// Key is the key of the Secret data to load.
+	Key string `json:"key" yaml:"key"`
+}
+
 type APICall struct {
 	// URLPath is the URL path to be used in the HTTP GET request to the
 	// Kubernetes API server (e.g. "/api/v1/namespaces" or  "/apis/apps/v1/deployments").
@@ -133,6 +238,11 @@ type APICall struct {
 	// of deployments across all namespaces.
 	// +kubebuilder:validation:Optional
 	JMESPath string `json:"jmesPath,omitempty" yaml:"jmesPath,omitempty"`
+
+	// CacheTTL enables caching of the API call response for the given duration.
+	// Identical calls (same URL, method and body) made within the TTL reuse the cached response.
+	// +kubebuilder:validation:Optional
+	CacheTTL *metav1.Duration `json:"cacheTTL,omitempty" yaml:"cacheTTL,omitempty"`
 }
 
 type ServiceCall struct {
@@ -145,19 +255,139 @@ type ServiceCall struct {
 	// +kubebuilder:validation:Optional
 	CABundle string `json:"caBundle" yaml:"caBundle"`
 
-	// Method is the HTTP request type (GET or POST).
+	// Method is the HTTP request type (GET, POST, PUT or PATCH).
 	// +kubebuilder:default=GET
 	Method Method `json:"requestType" yaml:"requestType"`
 
 	// Data specifies the POST data sent to the server.
 	// +kubebuilder:validation:Optional
 	Data []RequestData `json:"data" yaml:"data"`
+
+	// Headers are additional HTTP headers sent to the server.
+	// Header values can contain variables.
+	// +kubebuilder:validation:Optional
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+
+	// Credentials references a Secret holding the credentials used to authenticate to the server.
+	// +kubebuilder:validation:Optional
+	Credentials *ServiceCallCredentials `json:"credentials,omitempty" yaml:"credentials,omitempty"`
+
+	// Timeout is the maximum duration of a single request, defaults to no timeout.
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// Retry configures how failed requests are retried.
+	// +kubebuilder:validation:Optional
+	Retry *ServiceCallRetry `json:"retry,omitempty" yaml:"retry,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (s *ServiceCall) Validate(path *field.Path) (errs field.ErrorList) {
+	for name := range s.Headers {
+		if name == "" || strings.ContainsAny(name, " \t\r\n:") {
+			errs = append(errs, field.Invalid(path.Child("headers"), name, "invalid header name"))
+		}
+	}
+	if s.Credentials != nil {
+		errs = append(errs, s.Credentials.Validate(path.Child("credentials"))...)
+	}
+	if s.Timeout != nil && s.Timeout.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("timeout"), s.Timeout.Duration.String(), "the timeout must be greater than zero"))
+	}
+	if s.Retry != nil {
+		errs = append(errs, s.Retry.Validate(path.Child("retry"))...)
+	}
+	return errs
 }
 
 // Method is a HTTP request type.
-// +kubebuilder:validation:Enum=GET;POST
+// +kubebuilder:validation:Enum=GET;POST;PUT;PATCH
 type Method string
 
+// CredentialsType is the type of credentials used to call a service.
+// +kubebuilder:validation:Enum=BearerToken;BasicAuth;ClientCertificate
+type CredentialsType string
+
+const (
+	// BearerToken reads a token from the "token" key of the Secret and sends it in the Authorization header.
+	BearerToken CredentialsType = "BearerToken"
+	// BasicAuth reads the "username" and "password" keys of the Secret.
+	BasicAuth CredentialsType = "BasicAuth"
+	// ClientCertificate reads a client certificate and its key from the "tls.crt" and "tls.key" keys of the Secret.
+	ClientCertificate CredentialsType = "ClientCertificate"
+)
+
+// ServiceCallCredentials references a Secret holding service credentials.
+type ServiceCallCredentials struct {
+	// Type is the type of credentials stored in the Secret (BearerToken, BasicAuth or ClientCertificate).
+	Type CredentialsType `json:"type" yaml:"type"`
+
+	// SecretRef references the Secret holding the credentials.
+	SecretRef corev1.SecretReference `json:"secretRef" yaml:"secretRef"`
+}
+
+// Validate implements programmatic validation
+func (c *ServiceCallCredentials) Validate(path *field.Path) (errs field.ErrorList) {
+	switch c.Type {
+	case BearerToken, BasicAuth, ClientCertificate:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("type"), c.Type, []string{string(BearerToken), string(BasicAuth), string(ClientCertificate)}))
+	}
+	if c.SecretRef.Name == "" {
+		errs = append(errs, field.Required(path.Child("secretRef", "name"), "a secret name is required"))
+	}
+	if c.SecretRef.Namespace == "" {
+		errs = append(errs, field.Required(path.Child("secretRef", "namespace"), "a secret namespace is required"))
+	}
+	return errs
+}
+
+// MaxServiceCallRetryAttempts is the maximum number of retries of a failed service call
+const MaxServiceCallRetryAttempts = 5
+
+// ServiceCallRetry configures retries of failed service calls.
+// Only idempotent GET and PUT requests are retried, on connection errors and on 429 or 5xx responses.
+type ServiceCallRetry struct {
+	// Attempts is the maximum number of retries after the first failed request, at most 5.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=5
+	Attempts int `json:"attempts" yaml:"attempts"`
+
+	// Backoff is the delay before the first retry, doubled after each retry. Defaults to 1s.
+	// +kubebuilder:validation:Optional
+	Backoff *metav1.Duration `json:"backoff,omitempty" yaml:"backoff,omitempty"`
+}
+
+// GetAttempts returns the number of retries, capped to MaxServiceCallRetryAttempts
+func (r *ServiceCallRetry) GetAttempts() int {
+	if r.Attempts > MaxServiceCallRetryAttempts {
+		return MaxServiceCallRetryAttempts
+	}
+	return r.Attempts
+}
+
+// GetBackoff returns the initial retry delay, falling back to the default one
+func (r *ServiceCallRetry) GetBackoff() time.Duration {
+	if r.Backoff == nil {
+		return time.Second
+	}
+	return r.Backoff.Duration
+}
+
+// Validate implements programmatic validation
+func (r *ServiceCallRetry) Validate(path *field.Path) (errs field.ErrorList) {
+	if r.Attempts < 0 {
+		errs = append(errs, field.Invalid(path.Child("attempts"), r.Attempts, "the number of attempts must not be negative"))
+	}
+	if r.Attempts > MaxServiceCallRetryAttempts {
+		errs = append(errs, field.Invalid(path.Child("attempts"), r.Attempts, fmt.Sprintf("the number of attempts must not be greater than %d", MaxServiceCallRetryAttempts)))
+	}
+	if r.Backoff != nil && r.Backoff.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("backoff"), r.Backoff.Duration.String(), "the backoff must be greater than zero"))
+	}
+	return errs
+}
+
 // RequestData contains the HTTP POST data
 type RequestData struct {
 	// Key is a unique identifier for the data value
@@ -175,7 +405,8 @@ type Condition struct {
 	// Operator is the conditional operation to perform. Valid operators are:
 	// Equals, NotEquals, In, AnyIn, AllIn, NotIn, AnyNotIn, AllNotIn, GreaterThanOrEquals,
 	// GreaterThan, LessThanOrEquals, LessThan, DurationGreaterThanOrEquals, DurationGreaterThan,
-	// DurationLessThanOrEquals, DurationLessThan
+	// DurationLessThanOrEquals, DurationLessThan, Matches, NotMatches, AnyMatches, AllMatches,
+	// SemverSatisfies
 	Operator ConditionOperator `json:"operator,omitempty" yaml:"operator,omitempty"`
 
 	// Value is the conditional value, or set of values. The values can be fixed set
@@ -201,7 +432,7 @@ func (c *Condition) SetValue(in apiextensions.JSON) {
 }
 
 // ConditionOperator is the operation performed on condition key and value.
-// +kubebuilder:validation:Enum=Equals;NotEquals;In;AnyIn;AllIn;NotIn;AnyNotIn;AllNotIn;GreaterThanOrEquals;GreaterThan;LessThanOrEquals;LessThan;DurationGreaterThanOrEquals;DurationGreaterThan;DurationLessThanOrEquals;DurationLessThan
+// +kubebuilder:validation:Enum=Equals;NotEquals;In;AnyIn;AllIn;NotIn;AnyNotIn;AllNotIn;GreaterThanOrEquals;GreaterThan;LessThanOrEquals;LessThan;DurationGreaterThanOrEquals;DurationGreaterThan;DurationLessThanOrEquals;DurationLessThan;Matches;NotMatches;AnyMatches;AllMatches;SemverSatisfies
 type ConditionOperator string
 
 // ConditionOperators stores all the valid ConditionOperator types as key-value pairs.
@@ -224,6 +455,11 @@ type ConditionOperator string
 // "DurationGreaterThan" evaluates if the key (duration) is greater than the value (duration)
 // "DurationLessThanOrEquals" evaluates if the key (duration) is less than or equal to the value (duration)
 // "DurationLessThan" evaluates if the key (duration) is greater than the value (duration)
+// "Matches" evaluates if the key matches the value (regular expression or list of regular expressions).
+// "NotMatches" evaluates if the key doesn't match the value (regular expression or list of regular expressions).
+// "AnyMatches" evaluates if any of the keys match the value (regular expression or list of regular expressions).
+// "AllMatches" evaluates if all the keys match the value (regular expression or list of regular expressions).
+// "SemverSatisfies" evaluates if the key (semantic version) satisfies the value (semver range, e.g. ">=1.2 <2.0").
 var ConditionOperators = map[string]ConditionOperator{
 	"Equal":                       ConditionOperator("Equal"),
 	"Equals":                      ConditionOperator("Equals"),
@@ -243,6 +479,11 @@ var ConditionOperators = map[string]ConditionOperator{
 	"DurationGreaterThan":         ConditionOperator("DurationGreaterThan"),
 	"DurationLessThanOrEquals":    ConditionOperator("DurationLessThanOrEquals"),
 	"DurationLessThan":            ConditionOperator("DurationLessThan"),
+	"Matches":                     ConditionOperator("Matches"),
+	"NotMatches":                  ConditionOperator("NotMatches"),
+	"AnyMatches":                  ConditionOperator("AnyMatches"),
+	"AllMatches":                  ConditionOperator("AllMatches"),
+	"SemverSatisfies":             ConditionOperator("SemverSatisfies"),
 }
 
 // ResourceFilters is a slice of ResourceFilter
@@ -274,6 +515,11 @@ type Mutation struct {
 	// +optional
 	RawPatchStrategicMerge *apiextv1.JSON `json:"patchStrategicMerge,omitempty" yaml:"patchStrategicMerge,omitempty"`
 
+	// PatchMerge is a JSON merge patch used to modify resources, maps are merged recursively,
+	// null values remove keys and lists are replaced. See https://tools.ietf.org/html/rfc7386.
+	// +optional
+	RawPatchMerge *apiextv1.JSON `json:"patchMerge,omitempty" yaml:"patchMerge,omitempty"`
+
 	// PatchesJSON6902 is a list of RFC 6902 JSON Patch declarations used to modify resources.
 	// See https://tools.ietf.org/html/rfc6902 and https://kubectl.docs.kubernetes.io/references/kustomize/patchesjson6902/.
 	// +optional
@@ -292,6 +538,14 @@ func (m *Mutation) SetPatchStrategicMerge(in apiextensions.JSON) {
 	m.RawPatchStrategicMerge = ToJSON(in)
 }
 
+func (m *Mutation) GetPatchMerge() apiextensions.JSON {
+	return FromJSON(m.RawPatchMerge)
+}
+
+func (m *Mutation) SetPatchMerge(in apiextensions.JSON) {
+	m.RawPatchMerge = ToJSON(in)
+}
+
 // ForEachMutation applies mutation rules to a list of sub-elements by creating a context for each entry in the list and looping over it to apply the specified logic.
 type ForEachMutation struct {
 	// List specifies a JMESPath expression that results in one or more elements
@@ -315,6 +569,11 @@ type ForEachMutation struct {
 	// +optional
 	RawPatchStrategicMerge *apiextv1.JSON `json:"patchStrategicMerge,omitempty" yaml:"patchStrategicMerge,omitempty"`
 
+	// PatchMerge is a JSON merge patch used to modify resources, maps are merged recursively,
+	// null values remove keys and lists are replaced. See https://tools.ietf.org/html/rfc7386.
+	// +optional
+	RawPatchMerge *apiextv1.JSON `json:"patchMerge,omitempty" yaml:"patchMerge,omitempty"`
+
 	// PatchesJSON6902 is a list of RFC 6902 JSON Patch declarations used to modify resources.
 	// See https://tools.ietf.org/html/rfc6902 and https://kubectl.docs.kubernetes.io/references/kustomize/patchesjson6902/.
 	// +optional
@@ -333,12 +592,34 @@ func (m *ForEachMutation) SetPatchStrategicMerge(in apiextensions.JSON) {
 	m.RawPatchStrategicMerge = ToJSON(in)
 }
 
+func (m *ForEachMutation) GetPatchMerge() apiextensions.JSON {
+	return FromJSON(m.RawPatchMerge)
+}
+
+func (m *ForEachMutation) SetPatchMerge(in apiextensions.JSON) {
+	m.RawPatchMerge = ToJSON(in)
+}
+
 // Validation defines checks to be performed on matching resources.
 type Validation struct {
 	// Message specifies a custom message to be displayed on failure.
 	// +optional
 	Message string `json:"message,omitempty" yaml:"message,omitempty"`
 
+	// ValidationFailureAction defines if a violation of this rule should block
+	// the admission review request (enforce), or allow (audit) the admission review request
+	// and report an error in a policy report. Warn allows the admission review request and
+	// returns the violation as an admission warning. When set, it takes precedence over the policy
+	// level ValidationFailureAction and ValidationFailureActionOverrides.
+	// +optional
+	// +kubebuilder:validation:Enum=audit;enforce;Audit;Enforce;Warn
+	ValidationFailureAction *ValidationFailureAction `json:"validationFailureAction,omitempty" yaml:"validationFailureAction,omitempty"`
+
+	// ValidationFailureActionOverrides specifies ValidationFailureAction namespace-wise for this rule.
+	// It takes precedence over the rule and policy level ValidationFailureAction and is only supported with ClusterPolicy.
+	// +optional
+	ValidationFailureActionOverrides []ValidationFailureActionOverride `json:"validationFailureActionOverrides,omitempty" yaml:"validationFailureActionOverrides,omitempty"`
+
 	// Manifest specifies conditions for manifest verification
 	// +optional
 	Manifests *Manifests `json:"manifests,omitempty" yaml:"manifests,omitempty"`
@@ -364,6 +645,42 @@ type Validation struct {
 	// by specifying exclusions for Pod Security Standards controls.
 	// +optional
 	PodSecurity *PodSecurity `json:"podSecurity,omitempty" yaml:"podSecurity,omitempty"`
+
+	// CEL allows validation checks using the Common Expression Language (https://kubernetes.io/docs/reference/using-api/cel/).
+	// +optional
+	CEL *CEL `json:"cel,omitempty" yaml:"cel,omitempty"`
+}
+
+// CEL allows validation checks using the Common Expression Language (https://kubernetes.io/docs/reference/using-api/cel/).
+// Expressions can access the `object`, `oldObject`, `request` and `params` variables
+// as well as the rule context entries.
+type CEL struct {
+	// Expressions is a list of CELExpression types.
+	Expressions []CELExpression `json:"expressions,omitempty" yaml:"expressions,omitempty"`
+
+	// Params is an arbitrary JSON object made available to expressions through the `params` variable.
+	// +optional
+	RawParams *apiextv1.JSON `json:"params,omitempty" yaml:"params,omitempty"`
+}
+
+func (c *CEL) GetParams() apiextensions.JSON {
+	return FromJSON(c.RawParams)
+}
+
+func (c *CEL) SetParams(in apiextensions.JSON) {
+	c.RawParams = ToJSON(in)
+}
+
+// CELExpression defines a CEL expression used to validate resources.
+type CELExpression struct {
+	// Expression represents the expression which will be evaluated by CEL.
+	// It must evaluate to a boolean, the validation fails when it evaluates to false.
+	Expression string `json:"expression" yaml:"expression"`
+
+	// Message represents the message displayed when the expression evaluates to false.
+	// Variables can be used in the message.
+	// +optional
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
 }
 
 // PodSecurity applies exemptions for Kubernetes Pod Security admission
@@ -8,6 +8,9 @@ const (
 	AnnotationPolicyCategory = "policies.kyverno.io/category"
 	AnnotationPolicySeverity = "policies.kyverno.io/severity"
 	AnnotationPolicyScored   = "policies.kyverno.io/scored"
+	// AnnotationGenerateValidatingAdmissionPolicy defines the annotation key used to opt in
+	// ValidatingAdmissionPolicy generation
+	AnnotationGenerateValidatingAdmissionPolicy = "kyverno.io/generate-validating-admission-policy"
 	// ValueKyvernoApp defines the kyverno application value
 	ValueKyvernoApp = "kyverno"
 )
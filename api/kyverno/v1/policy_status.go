@@ -33,6 +33,9 @@ type PolicyStatus struct {
 	// RuleCount describes total number of rules in a policy
 	// +optional
 	RuleCount RuleCountStatus `json:"rulecount" yaml:"rulecount"`
+	// ValidatingAdmissionPolicy contains status information about the generated ValidatingAdmissionPolicies
+	// +optional
+	ValidatingAdmissionPolicy *ValidatingAdmissionPolicyStatus `json:"validatingadmissionpolicy,omitempty" yaml:"validatingadmissionpolicy,omitempty"`
 }
 
 // RuleCountStatus contains four variables which describes counts for
@@ -75,3 +78,26 @@ type AutogenStatus struct {
 	// Rules is a list of Rule instances. It contains auto generated rules added for pod controllers
 	Rules []Rule `json:"rules,omitempty" yaml:"rules,omitempty"`
 }
+
+// ValidatingAdmissionPolicyStatus contains the translation status of the policy rules
+// into ValidatingAdmissionPolicies.
+type ValidatingAdmissionPolicyStatus struct {
+	// ObservedGeneration is the policy generation the rules were translated from
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty" yaml:"observedGeneration,omitempty"`
+	// Rules contains the translation status of each rule
+	// +optional
+	Rules []ValidatingAdmissionPolicyRuleStatus `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+// ValidatingAdmissionPolicyRuleStatus contains the translation status of a rule.
+type ValidatingAdmissionPolicyRuleStatus struct {
+	// Name is the rule name
+	Name string `json:"name" yaml:"name"`
+	// Translated indicates if the rule was translated into a ValidatingAdmissionPolicy,
+	// translated rules are not evaluated by the Kyverno admission webhook
+	Translated bool `json:"translated" yaml:"translated"`
+	// Message explains why the rule was not translated
+	// +optional
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
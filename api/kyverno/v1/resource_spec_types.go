@@ -5,6 +5,7 @@ import (
 
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type ResourceSpec struct {
@@ -35,6 +36,16 @@ type TargetResourceSpec struct {
 	// ResourceSpec contains the target resources to load when mutating existing resources.
 	ResourceSpec `json:",omitempty" yaml:",omitempty"`
 
+	// Selector is a label selector, only the target resources whose labels match it are loaded.
+	// Label values support variables.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty" yaml:"selector,omitempty"`
+
+	// NamespaceSelector is a label selector, only the target resources in namespaces whose labels
+	// match it are loaded. Cluster scoped resources are not loaded when it is set. Label values support variables.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty" yaml:"namespaceSelector,omitempty"`
+
 	// Context defines variables and data sources that can be used during rule execution.
 	// +optional
 	Context []ContextEntry `json:"context,omitempty" yaml:"context,omitempty"`
@@ -1137,3 +1137,25 @@ func Test_Validate_ClusterPolicy_Generate_Variables(t *testing.T) {
 		assert.Equal(t, len(errs) != 0, testcase.shouldFail, testcase.name)
 	}
 }
+
+func Test_Rule_GetValidationFailureAction(t *testing.T) {
+	audit := Audit
+	specOverrides := []ValidationFailureActionOverride{{Action: Audit, Namespaces: []string{"dev"}}}
+	ruleOverrides := []ValidationFailureActionOverride{{Action: Enforce, Namespaces: []string{"prod"}}}
+	spec := &Spec{ValidationFailureAction: Enforce, ValidationFailureActionOverrides: specOverrides}
+
+	rule := Rule{}
+	action, overrides := rule.GetValidationFailureAction(spec)
+	assert.Equal(t, action, Enforce)
+	assert.DeepEqual(t, overrides, specOverrides)
+
+	rule.Validation.ValidationFailureActionOverrides = ruleOverrides
+	action, overrides = rule.GetValidationFailureAction(spec)
+	assert.Equal(t, action, Enforce)
+	assert.DeepEqual(t, overrides, append(ruleOverrides, specOverrides...))
+
+	rule.Validation.ValidationFailureAction = &audit
+	action, overrides = rule.GetValidationFailureAction(spec)
+	assert.Equal(t, action, Audit)
+	assert.DeepEqual(t, overrides, ruleOverrides)
+}
@@ -129,6 +129,11 @@ func (r Rule) HasValidatePodSecurity() bool {
 	return r.Validation.PodSecurity != nil && !datautils.DeepEqual(r.Validation.PodSecurity, &PodSecurity{})
 }
 
+// HasValidateCEL checks for validate.cel rule
+func (r Rule) HasValidateCEL() bool {
+	return r.Validation.CEL != nil
+}
+
 // HasValidate checks for validate rule
 func (r *Rule) HasValidate() bool {
 	return !datautils.DeepEqual(r.Validation, Validation{})
@@ -155,6 +160,22 @@ func (r *Rule) GetGenerateTypeAndSync() (_ GenerateType, sync bool) {
 	return r.Generation.GetTypeAndSync()
 }
 
+// GetValidationFailureAction returns the validation failure action and overrides applying to the rule.
+// The rule level action takes precedence over the policy level action and overrides, rule level
+// overrides are evaluated before the policy level ones.
+func (r *Rule) GetValidationFailureAction(spec *Spec) (ValidationFailureAction, []ValidationFailureActionOverride) {
+	if r.Validation.ValidationFailureAction != nil {
+		return *r.Validation.ValidationFailureAction, r.Validation.ValidationFailureActionOverrides
+	}
+	if len(r.Validation.ValidationFailureActionOverrides) == 0 {
+		return spec.ValidationFailureAction, spec.ValidationFailureActionOverrides
+	}
+	overrides := make([]ValidationFailureActionOverride, 0, len(r.Validation.ValidationFailureActionOverrides)+len(spec.ValidationFailureActionOverrides))
+	overrides = append(overrides, r.Validation.ValidationFailureActionOverrides...)
+	overrides = append(overrides, spec.ValidationFailureActionOverrides...)
+	return spec.ValidationFailureAction, overrides
+}
+
 func (r *Rule) GetAnyAllConditions() apiextensions.JSON {
 	return FromJSON(r.RawAnyAllConditions)
 }
@@ -413,5 +434,8 @@ func (r *Rule) Validate(path *field.Path, namespaced bool, policyNamespace strin
 	errs = append(errs, r.ValidateMutationRuleTargetNamespace(path, namespaced, policyNamespace)...)
 	errs = append(errs, r.ValidatePSaControlNames(path)...)
 	errs = append(errs, r.ValidateGenerateVariables(path)...)
+	if namespaced && len(r.Validation.ValidationFailureActionOverrides) > 0 {
+		errs = append(errs, field.Forbidden(path.Child("validate", "validationFailureActionOverrides"), "Use of validationFailureActionOverrides is supported only with ClusterPolicy"))
+	}
 	return errs
 }
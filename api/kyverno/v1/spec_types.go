@@ -24,6 +24,8 @@ const (
 	Enforce ValidationFailureAction = "Enforce"
 	// Audit doesn't block the request on failure
 	Audit ValidationFailureAction = "Audit"
+	// Warn doesn't block the request on failure but returns the violation as an admission warning
+	Warn ValidationFailureAction = "Warn"
 )
 
 func (a ValidationFailureAction) Enforce() bool {
@@ -31,15 +33,19 @@ func (a ValidationFailureAction) Enforce() bool {
 }
 
 func (a ValidationFailureAction) Audit() bool {
-	return !a.Enforce()
+	return !a.Enforce() && !a.Warn()
+}
+
+func (a ValidationFailureAction) Warn() bool {
+	return a == Warn
 }
 
 func (a ValidationFailureAction) IsValid() bool {
-	return a == enforceOld || a == auditOld || a == Enforce || a == Audit
+	return a == enforceOld || a == auditOld || a == Enforce || a == Audit || a == Warn
 }
 
 type ValidationFailureActionOverride struct {
-	// +kubebuilder:validation:Enum=audit;enforce;Audit;Enforce
+	// +kubebuilder:validation:Enum=audit;enforce;Audit;Enforce;Warn
 	Action            ValidationFailureAction `json:"action,omitempty" yaml:"action,omitempty"`
 	Namespaces        []string                `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
 	NamespaceSelector *metav1.LabelSelector   `json:"namespaceSelector,omitempty" yaml:"namespaceSelector,omitempty"`
@@ -51,6 +57,11 @@ type Spec struct {
 	// each rule can validate, mutate, or generate resources.
 	Rules []Rule `json:"rules,omitempty" yaml:"rules,omitempty"`
 
+	// Context defines variables and data sources shared by all rules of the policy.
+	// Entries are loaded once per policy evaluation, before the rule level context.
+	// +optional
+	Context []ContextEntry `json:"context,omitempty" yaml:"context,omitempty"`
+
 	// ApplyRules controls how rules in a policy are applied. Rule are processed in
 	// the order of declaration. When set to `One` processing stops after a rule has
 	// been applied i.e. the rule matches and results in a pass, fail, or error. When
@@ -67,10 +78,11 @@ type Spec struct {
 
 	// ValidationFailureAction defines if a validation policy rule violation should block
 	// the admission review request (enforce), or allow (audit) the admission review request
-	// and report an error in a policy report. Optional.
-	// Allowed values are audit or enforce. The default value is "Audit".
+	// and report an error in a policy report. Warn allows the admission review request,
+	// returns the violation as an admission warning and reports it in a policy report. Optional.
+	// Allowed values are audit, enforce or Warn. The default value is "Audit".
 	// +optional
-	// +kubebuilder:validation:Enum=audit;enforce;Audit;Enforce
+	// +kubebuilder:validation:Enum=audit;enforce;Audit;Enforce;Warn
 	// +kubebuilder:default=Audit
 	ValidationFailureAction ValidationFailureAction `json:"validationFailureAction,omitempty" yaml:"validationFailureAction,omitempty"`
 
@@ -96,6 +108,12 @@ type Spec struct {
 	// based on the failure policy. The default timeout is 10s, the value must be between 1 and 30 seconds.
 	WebhookTimeoutSeconds *int32 `json:"webhookTimeoutSeconds,omitempty" yaml:"webhookTimeoutSeconds,omitempty"`
 
+	// Priority orders the mutate policies applied to a resource. Policies with a lower priority are applied first,
+	// policies with a higher priority are applied last and take precedence on the fields mutated by several policies.
+	// Policies with the same priority are ordered by namespace and name. The default value is 0.
+	// +optional
+	Priority int32 `json:"priority,omitempty" yaml:"priority,omitempty"`
+
 	// MutateExistingOnPolicyUpdate controls if a mutateExisting policy is applied on policy events.
 	// Default value is "false".
 	// +optional
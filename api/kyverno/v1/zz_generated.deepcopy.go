@@ -37,6 +37,11 @@ func (in *APICall) DeepCopyInto(out *APICall) {
 		*out = new(ServiceCall)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CacheTTL != nil {
+		in, out := &in.CacheTTL, &out.CacheTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APICall.
@@ -198,6 +203,46 @@ func (in *AutogenStatus) DeepCopy() *AutogenStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CEL) DeepCopyInto(out *CEL) {
+	*out = *in
+	if in.Expressions != nil {
+		in, out := &in.Expressions, &out.Expressions
+		*out = make([]CELExpression, len(*in))
+		copy(*out, *in)
+	}
+	if in.RawParams != nil {
+		in, out := &in.RawParams, &out.RawParams
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CEL.
+func (in *CEL) DeepCopy() *CEL {
+	if in == nil {
+		return nil
+	}
+	out := new(CEL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CELExpression) DeepCopyInto(out *CELExpression) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CELExpression.
+func (in *CELExpression) DeepCopy() *CELExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(CELExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CTLog) DeepCopyInto(out *CTLog) {
 	*out = *in
@@ -380,6 +425,16 @@ func (in *ContextEntry) DeepCopyInto(out *ContextEntry) {
 		*out = new(ConfigMapReference)
 		**out = **in
 	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+	if in.Resource != nil {
+		in, out := &in.Resource, &out.Resource
+		*out = new(ResourceLookup)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.APICall != nil {
 		in, out := &in.APICall, &out.APICall
 		*out = new(APICall)
@@ -395,6 +450,16 @@ func (in *ContextEntry) DeepCopyInto(out *ContextEntry) {
 		*out = new(Variable)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GlobalReference != nil {
+		in, out := &in.GlobalReference, &out.GlobalReference
+		*out = new(GlobalContextEntryReference)
+		**out = **in
+	}
+	if in.OnError != nil {
+		in, out := &in.OnError, &out.OnError
+		*out = new(OnError)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextEntry.
@@ -462,6 +527,11 @@ func (in *ForEachMutation) DeepCopyInto(out *ForEachMutation) {
 		*out = new(apiextensionsv1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RawPatchMerge != nil {
+		in, out := &in.RawPatchMerge, &out.RawPatchMerge
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ForEachMutation != nil {
 		in, out := &in.ForEachMutation, &out.ForEachMutation
 		*out = new(apiextensionsv1.JSON)
@@ -554,6 +624,21 @@ func (in *Generation) DeepCopy() *Generation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalContextEntryReference) DeepCopyInto(out *GlobalContextEntryReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalContextEntryReference.
+func (in *GlobalContextEntryReference) DeepCopy() *GlobalContextEntryReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalContextEntryReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in IgnoreFieldList) DeepCopyInto(out *IgnoreFieldList) {
 	{
@@ -785,6 +870,11 @@ func (in *Mutation) DeepCopyInto(out *Mutation) {
 		*out = new(apiextensionsv1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RawPatchMerge != nil {
+		in, out := &in.RawPatchMerge, &out.RawPatchMerge
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ForEachMutation != nil {
 		in, out := &in.ForEachMutation, &out.ForEachMutation
 		*out = make([]ForEachMutation, len(*in))
@@ -829,6 +919,27 @@ func (in *ObjectFieldBinding) DeepCopy() *ObjectFieldBinding {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnError) DeepCopyInto(out *OnError) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnError.
+func (in *OnError) DeepCopy() *OnError {
+	if in == nil {
+		return nil
+	}
+	out := new(OnError)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSecurity) DeepCopyInto(out *PodSecurity) {
 	*out = *in
@@ -942,6 +1053,11 @@ func (in *PolicyStatus) DeepCopyInto(out *PolicyStatus) {
 	}
 	in.Autogen.DeepCopyInto(&out.Autogen)
 	out.RuleCount = in.RuleCount
+	if in.ValidatingAdmissionPolicy != nil {
+		in, out := &in.ValidatingAdmissionPolicy, &out.ValidatingAdmissionPolicy
+		*out = new(ValidatingAdmissionPolicyStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyStatus.
@@ -1064,6 +1180,27 @@ func (in ResourceFilters) DeepCopy() ResourceFilters {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceLookup) DeepCopyInto(out *ResourceLookup) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceLookup.
+func (in *ResourceLookup) DeepCopy() *ResourceLookup {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceLookup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceSpec) DeepCopyInto(out *ResourceSpec) {
 	*out = *in
@@ -1148,6 +1285,22 @@ func (in *RuleCountStatus) DeepCopy() *RuleCountStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference.
+func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 	*out = *in
@@ -1173,6 +1326,28 @@ func (in *ServiceCall) DeepCopyInto(out *ServiceCall) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(ServiceCallCredentials)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(ServiceCallRetry)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceCall.
@@ -1185,6 +1360,42 @@ func (in *ServiceCall) DeepCopy() *ServiceCall {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceCallCredentials) DeepCopyInto(out *ServiceCallCredentials) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceCallCredentials.
+func (in *ServiceCallCredentials) DeepCopy() *ServiceCallCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceCallCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceCallRetry) DeepCopyInto(out *ServiceCallRetry) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceCallRetry.
+func (in *ServiceCallRetry) DeepCopy() *ServiceCallRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceCallRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Spec) DeepCopyInto(out *Spec) {
 	*out = *in
@@ -1195,6 +1406,13 @@ func (in *Spec) DeepCopyInto(out *Spec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Context != nil {
+		in, out := &in.Context, &out.Context
+		*out = make([]ContextEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ApplyRules != nil {
 		in, out := &in.ApplyRules, &out.ApplyRules
 		*out = new(ApplyRulesType)
@@ -1273,6 +1491,16 @@ func (in *StaticKeyAttestor) DeepCopy() *StaticKeyAttestor {
 func (in *TargetResourceSpec) DeepCopyInto(out *TargetResourceSpec) {
 	*out = *in
 	out.ResourceSpec = in.ResourceSpec
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Context != nil {
 		in, out := &in.Context, &out.Context
 		*out = make([]ContextEntry, len(*in))
@@ -1327,9 +1555,56 @@ func (in *UserInfo) DeepCopy() *UserInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidatingAdmissionPolicyRuleStatus) DeepCopyInto(out *ValidatingAdmissionPolicyRuleStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidatingAdmissionPolicyRuleStatus.
+func (in *ValidatingAdmissionPolicyRuleStatus) DeepCopy() *ValidatingAdmissionPolicyRuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidatingAdmissionPolicyRuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidatingAdmissionPolicyStatus) DeepCopyInto(out *ValidatingAdmissionPolicyStatus) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ValidatingAdmissionPolicyRuleStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidatingAdmissionPolicyStatus.
+func (in *ValidatingAdmissionPolicyStatus) DeepCopy() *ValidatingAdmissionPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidatingAdmissionPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Validation) DeepCopyInto(out *Validation) {
 	*out = *in
+	if in.ValidationFailureAction != nil {
+		in, out := &in.ValidationFailureAction, &out.ValidationFailureAction
+		*out = new(ValidationFailureAction)
+		**out = **in
+	}
+	if in.ValidationFailureActionOverrides != nil {
+		in, out := &in.ValidationFailureActionOverrides, &out.ValidationFailureActionOverrides
+		*out = make([]ValidationFailureActionOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Manifests != nil {
 		in, out := &in.Manifests, &out.Manifests
 		*out = new(Manifests)
@@ -1362,6 +1637,11 @@ func (in *Validation) DeepCopyInto(out *Validation) {
 		*out = new(PodSecurity)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CEL != nil {
+		in, out := &in.CEL, &out.CEL
+		*out = new(CEL)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Validation.
@@ -0,0 +1,59 @@
+package v2alpha1
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func Test_GlobalContextEntry_Sources(t *testing.T) {
+	subject := GlobalContextEntry{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+	}
+	errs := subject.Validate()
+	assert.Assert(t, len(errs) == 1)
+	assert.Equal(t, errs[0].Field, "spec")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeForbidden)
+	subject.Spec.KubernetesResource = &KubernetesResource{Version: "v1", Resource: "deployments"}
+	subject.Spec.APICall = &ExternalAPICall{Service: kyvernov1.ServiceCall{URL: "https://example.com"}}
+	errs = subject.Validate()
+	assert.Assert(t, len(errs) == 1)
+	assert.Equal(t, errs[0].Field, "spec")
+}
+
+func Test_GlobalContextEntry_KubernetesResource(t *testing.T) {
+	subject := GlobalContextEntry{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: GlobalContextEntrySpec{
+			KubernetesResource: &KubernetesResource{
+				Group:    "apps",
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a b"}},
+			},
+		},
+	}
+	errs := subject.Validate()
+	assert.Assert(t, len(errs) == 3)
+	assert.Equal(t, errs[0].Field, "spec.kubernetesResource.version")
+	assert.Equal(t, errs[1].Field, "spec.kubernetesResource.resource")
+	assert.Equal(t, errs[2].Field, "spec.kubernetesResource.selector")
+}
+
+func Test_GlobalContextEntry_APICall(t *testing.T) {
+	subject := GlobalContextEntry{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: GlobalContextEntrySpec{
+			APICall: &ExternalAPICall{
+				RefreshInterval: &metav1.Duration{Duration: -time.Second},
+			},
+		},
+	}
+	errs := subject.Validate()
+	assert.Assert(t, len(errs) == 2)
+	assert.Equal(t, errs[0].Field, "spec.apiCall.service.urlPath")
+	assert.Equal(t, errs[1].Field, "spec.apiCall.refreshInterval")
+	assert.Equal(t, (&ExternalAPICall{}).GetRefreshInterval(), DefaultRefreshInterval)
+}
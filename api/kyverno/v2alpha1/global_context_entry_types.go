@@ -0,0 +1,157 @@
+/*
+Copyright 2020 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// DefaultRefreshInterval is the refresh interval used when an external API call doesn't specify one.
+const DefaultRefreshInterval = 10 * time.Minute
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster,shortName=gctxentry,categories=kyverno
+
+// GlobalContextEntry declares data that is cached cluster-wide and can be referenced by policies.
+type GlobalContextEntry struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the data source of the global context entry.
+	Spec GlobalContextEntrySpec `json:"spec"`
+}
+
+// Validate implements programmatic validation
+func (e *GlobalContextEntry) Validate() (errs field.ErrorList) {
+	return e.Spec.Validate(field.NewPath("spec"))
+}
+
+// GlobalContextEntrySpec stores the data source of a global context entry.
+// Exactly one of KubernetesResource or APICall must be set.
+type GlobalContextEntrySpec struct {
+	// KubernetesResource declares a collection of Kubernetes resources kept up to date by an informer.
+	// +optional
+	KubernetesResource *KubernetesResource `json:"kubernetesResource,omitempty"`
+
+	// APICall declares a JSON web service call polled at a regular interval.
+	// +optional
+	APICall *ExternalAPICall `json:"apiCall,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (s *GlobalContextEntrySpec) Validate(path *field.Path) (errs field.ErrorList) {
+	if (s.KubernetesResource == nil) == (s.APICall == nil) {
+		errs = append(errs, field.Forbidden(path, "exactly one of kubernetesResource or apiCall must be specified"))
+	}
+	if s.KubernetesResource != nil {
+		errs = append(errs, s.KubernetesResource.Validate(path.Child("kubernetesResource"))...)
+	}
+	if s.APICall != nil {
+		errs = append(errs, s.APICall.Validate(path.Child("apiCall"))...)
+	}
+	return errs
+}
+
+// KubernetesResource identifies a collection of Kubernetes resources.
+type KubernetesResource struct {
+	// Group is the API group of the resources, empty for the core group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Version is the API version of the resources.
+	Version string `json:"version"`
+
+	// Resource is the plural resource name (e.g. "deployments").
+	Resource string `json:"resource"`
+
+	// Namespace restricts the collection to a single namespace.
+	// Leave empty for cluster scoped resources or to cache resources from all namespaces.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Selector restricts the collection to resources matching the label selector.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (r *KubernetesResource) Validate(path *field.Path) (errs field.ErrorList) {
+	if r.Version == "" {
+		errs = append(errs, field.Required(path.Child("version"), "a version is required"))
+	}
+	if r.Resource == "" {
+		errs = append(errs, field.Required(path.Child("resource"), "a resource is required"))
+	}
+	if r.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(r.Selector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("selector"), r.Selector, err.Error()))
+		}
+	}
+	return errs
+}
+
+// ExternalAPICall declares a call to a JSON web service.
+type ExternalAPICall struct {
+	// Service is the JSON web service to call.
+	Service kyvernov1.ServiceCall `json:"service"`
+
+	// JMESPath is an optional JSON Match Expression applied to the response before it is cached.
+	// +optional
+	JMESPath string `json:"jmesPath,omitempty"`
+
+	// RefreshInterval is the interval at which the service is called, defaults to 10m.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// GetRefreshInterval returns the refresh interval, falling back to the default one
+func (c *ExternalAPICall) GetRefreshInterval() time.Duration {
+	if c.RefreshInterval == nil {
+		return DefaultRefreshInterval
+	}
+	return c.RefreshInterval.Duration
+}
+
+// Validate implements programmatic validation
+func (c *ExternalAPICall) Validate(path *field.Path) (errs field.ErrorList) {
+	if c.Service.URL == "" {
+		errs = append(errs, field.Required(path.Child("service", "urlPath"), "a service URL is required"))
+	}
+	errs = append(errs, c.Service.Validate(path.Child("service"))...)
+	if c.RefreshInterval != nil && c.RefreshInterval.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("refreshInterval"), c.RefreshInterval.Duration.String(), "the refresh interval must be greater than zero"))
+	}
+	return errs
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GlobalContextEntryList is a list of GlobalContextEntry instances.
+type GlobalContextEntryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []GlobalContextEntry `json:"items"`
+}
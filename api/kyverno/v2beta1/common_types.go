@@ -12,6 +12,20 @@ type Validation struct {
 	// +optional
 	Message string `json:"message,omitempty" yaml:"message,omitempty"`
 
+	// ValidationFailureAction defines if a violation of this rule should block
+	// the admission review request (enforce), or allow (audit) the admission review request
+	// and report an error in a policy report. Warn allows the admission review request and
+	// returns the violation as an admission warning. When set, it takes precedence over the policy
+	// level ValidationFailureAction and ValidationFailureActionOverrides.
+	// +optional
+	// +kubebuilder:validation:Enum=audit;enforce;Audit;Enforce;Warn
+	ValidationFailureAction *kyvernov1.ValidationFailureAction `json:"validationFailureAction,omitempty" yaml:"validationFailureAction,omitempty"`
+
+	// ValidationFailureActionOverrides specifies ValidationFailureAction namespace-wise for this rule.
+	// It takes precedence over the rule and policy level ValidationFailureAction and is only supported with ClusterPolicy.
+	// +optional
+	ValidationFailureActionOverrides []kyvernov1.ValidationFailureActionOverride `json:"validationFailureActionOverrides,omitempty" yaml:"validationFailureActionOverrides,omitempty"`
+
 	// Manifest specifies conditions for manifest verification
 	// +optional
 	Manifests *kyvernov1.Manifests `json:"manifests,omitempty" yaml:"manifests,omitempty"`
@@ -37,10 +51,14 @@ type Validation struct {
 	// by specifying exclusions for Pod Security Standards controls.
 	// +optional
 	PodSecurity *kyvernov1.PodSecurity `json:"podSecurity,omitempty" yaml:"podSecurity,omitempty"`
+
+	// CEL allows validation checks using the Common Expression Language (https://kubernetes.io/docs/reference/using-api/cel/).
+	// +optional
+	CEL *kyvernov1.CEL `json:"cel,omitempty" yaml:"cel,omitempty"`
 }
 
 // ConditionOperator is the operation performed on condition key and value.
-// +kubebuilder:validation:Enum=Equals;NotEquals;AnyIn;AllIn;AnyNotIn;AllNotIn;GreaterThanOrEquals;GreaterThan;LessThanOrEquals;LessThan;DurationGreaterThanOrEquals;DurationGreaterThan;DurationLessThanOrEquals;DurationLessThan
+// +kubebuilder:validation:Enum=Equals;NotEquals;AnyIn;AllIn;AnyNotIn;AllNotIn;GreaterThanOrEquals;GreaterThan;LessThanOrEquals;LessThan;DurationGreaterThanOrEquals;DurationGreaterThan;DurationLessThanOrEquals;DurationLessThan;Matches;NotMatches;AnyMatches;AllMatches;SemverSatisfies
 type ConditionOperator string
 
 // ConditionOperators stores all the valid ConditionOperator types as key-value pairs.
@@ -58,6 +76,11 @@ type ConditionOperator string
 // "DurationGreaterThan" evaluates if the key (duration) is greater than the value (duration)
 // "DurationLessThanOrEquals" evaluates if the key (duration) is less than or equal to the value (duration)
 // "DurationLessThan" evaluates if the key (duration) is greater than the value (duration)
+// "Matches" evaluates if the key matches the value (regular expression or list of regular expressions).
+// "NotMatches" evaluates if the key doesn't match the value (regular expression or list of regular expressions).
+// "AnyMatches" evaluates if any of the keys match the value (regular expression or list of regular expressions).
+// "AllMatches" evaluates if all the keys match the value (regular expression or list of regular expressions).
+// "SemverSatisfies" evaluates if the key (semantic version) satisfies the value (semver range, e.g. ">=1.2 <2.0").
 var ConditionOperators = map[string]ConditionOperator{
 	"Equals":                      ConditionOperator("Equals"),
 	"NotEquals":                   ConditionOperator("NotEquals"),
@@ -73,6 +96,11 @@ var ConditionOperators = map[string]ConditionOperator{
 	"DurationGreaterThan":         ConditionOperator("DurationGreaterThan"),
 	"DurationLessThanOrEquals":    ConditionOperator("DurationLessThanOrEquals"),
 	"DurationLessThan":            ConditionOperator("DurationLessThan"),
+	"Matches":                     ConditionOperator("Matches"),
+	"NotMatches":                  ConditionOperator("NotMatches"),
+	"AnyMatches":                  ConditionOperator("AnyMatches"),
+	"AllMatches":                  ConditionOperator("AllMatches"),
+	"SemverSatisfies":             ConditionOperator("SemverSatisfies"),
 }
 
 // Deny specifies a list of conditions used to pass or fail a validation rule.
@@ -90,7 +118,8 @@ type Condition struct {
 	// Operator is the conditional operation to perform. Valid operators are:
 	// Equals, NotEquals, In, AnyIn, AllIn, NotIn, AnyNotIn, AllNotIn, GreaterThanOrEquals,
 	// GreaterThan, LessThanOrEquals, LessThan, DurationGreaterThanOrEquals, DurationGreaterThan,
-	// DurationLessThanOrEquals, DurationLessThan
+	// DurationLessThanOrEquals, DurationLessThan, Matches, NotMatches, AnyMatches, AllMatches,
+	// SemverSatisfies
 	Operator ConditionOperator `json:"operator,omitempty" yaml:"operator,omitempty"`
 
 	// Value is the conditional value, or set of values. The values can be fixed set
@@ -97,6 +97,11 @@ func (r Rule) HasValidatePodSecurity() bool {
 	return r.Validation.PodSecurity != nil && !datautils.DeepEqual(r.Validation.PodSecurity, &kyvernov1.PodSecurity{})
 }
 
+// HasValidateCEL checks for validate.cel rule
+func (r Rule) HasValidateCEL() bool {
+	return r.Validation.CEL != nil
+}
+
 // HasValidate checks for validate rule
 func (r *Rule) HasValidate() bool {
 	return !datautils.DeepEqual(r.Validation, Validation{})
@@ -190,5 +195,8 @@ func (r *Rule) Validate(path *field.Path, namespaced bool, clusterResources sets
 	errs = append(errs, r.MatchResources.Validate(path.Child("match"), namespaced, clusterResources)...)
 	errs = append(errs, r.ExcludeResources.Validate(path.Child("exclude"), namespaced, clusterResources)...)
 	errs = append(errs, r.ValidateGenerateVariables(path)...)
+	if namespaced && len(r.Validation.ValidationFailureActionOverrides) > 0 {
+		errs = append(errs, field.Forbidden(path.Child("validate", "validationFailureActionOverrides"), "Use of validationFailureActionOverrides is supported only with ClusterPolicy"))
+	}
 	return errs
 }
@@ -14,6 +14,11 @@ type Spec struct {
 	// each rule can validate, mutate, or generate resources.
 	Rules []Rule `json:"rules,omitempty" yaml:"rules,omitempty"`
 
+	// Context defines variables and data sources shared by all rules of the policy.
+	// Entries are loaded once per policy evaluation, before the rule level context.
+	// +optional
+	Context []kyvernov1.ContextEntry `json:"context,omitempty" yaml:"context,omitempty"`
+
 	// ApplyRules controls how rules in a policy are applied. Rule are processed in
 	// the order of declaration. When set to `One` processing stops after a rule has
 	// been applied i.e. the rule matches and results in a pass, fail, or error. When
@@ -29,10 +34,11 @@ type Spec struct {
 
 	// ValidationFailureAction defines if a validation policy rule violation should block
 	// the admission review request (enforce), or allow (audit) the admission review request
-	// and report an error in a policy report. Optional.
-	// Allowed values are audit or enforce. The default value is "Audit".
+	// and report an error in a policy report. Warn allows the admission review request,
+	// returns the violation as an admission warning and reports it in a policy report. Optional.
+	// Allowed values are audit, enforce or Warn. The default value is "Audit".
 	// +optional
-	// +kubebuilder:validation:Enum=audit;enforce;Audit;Enforce
+	// +kubebuilder:validation:Enum=audit;enforce;Audit;Enforce;Warn
 	// +kubebuilder:default=Audit
 	ValidationFailureAction kyvernov1.ValidationFailureAction `json:"validationFailureAction,omitempty" yaml:"validationFailureAction,omitempty"`
 
@@ -58,6 +64,12 @@ type Spec struct {
 	// based on the failure policy. The default timeout is 10s, the value must be between 1 and 30 seconds.
 	WebhookTimeoutSeconds *int32 `json:"webhookTimeoutSeconds,omitempty" yaml:"webhookTimeoutSeconds,omitempty"`
 
+	// Priority orders the mutate policies applied to a resource. Policies with a lower priority are applied first,
+	// policies with a higher priority are applied last and take precedence on the fields mutated by several policies.
+	// Policies with the same priority are ordered by namespace and name. The default value is 0.
+	// +optional
+	Priority int32 `json:"priority,omitempty" yaml:"priority,omitempty"`
+
 	// MutateExistingOnPolicyUpdate controls if a mutateExisting policy is applied on policy events.
 	// Default value is "false".
 	// +optional
@@ -20,12 +20,15 @@ import (
 	kyvernoclient "github.com/kyverno/kyverno/pkg/clients/kyverno"
 	"github.com/kyverno/kyverno/pkg/config"
 	configcontroller "github.com/kyverno/kyverno/pkg/controllers/config"
+	globalcontextcontroller "github.com/kyverno/kyverno/pkg/controllers/globalcontext"
 	policymetricscontroller "github.com/kyverno/kyverno/pkg/controllers/metrics/policy"
 	"github.com/kyverno/kyverno/pkg/cosign"
 	"github.com/kyverno/kyverno/pkg/engine"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	apicallcache "github.com/kyverno/kyverno/pkg/engine/apicall/cache"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
@@ -116,13 +119,25 @@ func createrLeaderControllers(
 func createNonLeaderControllers(
 	configuration config.Configuration,
 	kubeKyvernoInformer kubeinformers.SharedInformerFactory,
+	kyvernoInformer kyvernoinformer.SharedInformerFactory,
+	dynamicClient dclient.Interface,
+	gctxStore store.Store,
+	secretResolver engineapi.SecretResolver,
 ) ([]internal.Controller, func() error) {
 	configurationController := configcontroller.NewController(
 		configuration,
 		kubeKyvernoInformer.Core().V1().ConfigMaps(),
 	)
+	globalContextController := globalcontextcontroller.NewController(
+		kyvernoInformer.Kyverno().V2alpha1().GlobalContextEntries(),
+		dynamicClient,
+		engineapi.NewCredentialsSecretLoader(secretResolver, "", configuration),
+		configuration,
+		gctxStore,
+	)
 	return []internal.Controller{
 			internal.NewController(configcontroller.ControllerName, configurationController, configcontroller.Workers),
+			internal.NewController(globalcontextcontroller.ControllerName, globalContextController, globalcontextcontroller.Workers),
 		},
 		nil
 }
@@ -135,6 +150,9 @@ func main() {
 		imageSignatureRepository  string
 		allowInsecureRegistry     bool
 		leaderElectionRetryPeriod time.Duration
+		apiCallCacheMaxEntries    int
+		apiCallCacheMaxEntrySize  int
+		resourceContextInformers  string
 	)
 	flagset := flag.NewFlagSet("updaterequest-controller", flag.ExitOnError)
 	flagset.IntVar(&genWorkers, "genWorkers", 10, "Workers for the background controller.")
@@ -143,6 +161,9 @@ func main() {
 	flagset.BoolVar(&allowInsecureRegistry, "allowInsecureRegistry", false, "Whether to allow insecure connections to registries. Don't use this for anything but testing.")
 	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
 	flagset.DurationVar(&leaderElectionRetryPeriod, "leaderElectionRetryPeriod", leaderelection.DefaultRetryPeriod, "Configure leader election retry period.")
+	flagset.IntVar(&apiCallCacheMaxEntries, "apiCallCacheMaxEntries", apicallcache.DefaultMaxEntries, "Maximum number of API call responses cached for context entries with a cacheTTL.")
+	flagset.IntVar(&apiCallCacheMaxEntrySize, "apiCallCacheMaxEntrySize", apicallcache.DefaultMaxEntrySize, "Maximum size in bytes of a cached API call response.")
+	flagset.StringVar(&resourceContextInformers, "resourceContextInformers", "", "Comma separated list of group version resources (e.g. apps/v1/deployments,v1/configmaps) served from informers to resource context entries, other resources are fetched from the API server.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
@@ -207,6 +228,41 @@ func main() {
 		logger.Error(err, "failed to create config map resolver")
 		os.Exit(1)
 	}
+	informerBasedSecretResolver, err := resolvers.NewInformerBasedSecretResolver(cacheInformer.Core().V1().Secrets().Lister())
+	if err != nil {
+		logger.Error(err, "failed to create informer based secret resolver")
+		os.Exit(1)
+	}
+	clientBasedSecretResolver, err := resolvers.NewClientBasedSecretResolver(kubeClient)
+	if err != nil {
+		logger.Error(err, "failed to create client based secret resolver")
+		os.Exit(1)
+	}
+	secretResolver, err := engineapi.NewNamespacedResourceResolver(informerBasedSecretResolver, clientBasedSecretResolver)
+	if err != nil {
+		logger.Error(err, "failed to create secret resolver")
+		os.Exit(1)
+	}
+	resourceInformers, err := resolvers.ParseGroupVersionResources(resourceContextInformers)
+	if err != nil {
+		logger.Error(err, "failed to parse resource context informers")
+		os.Exit(1)
+	}
+	informerBasedResourceResolver, err := resolvers.NewInformerBasedResourceResolver(signalCtx, dynamicClient, resyncPeriod, resolvers.DefaultInformerIdleTimeout, resourceInformers...)
+	if err != nil {
+		logger.Error(err, "failed to create informer based resource resolver")
+		os.Exit(1)
+	}
+	clientBasedResourceResolver, err := resolvers.NewClientBasedResourceResolver(dynamicClient)
+	if err != nil {
+		logger.Error(err, "failed to create client based resource resolver")
+		os.Exit(1)
+	}
+	resourceResolver, err := engineapi.NewResourceResolver(informerBasedResourceResolver, clientBasedResourceResolver)
+	if err != nil {
+		logger.Error(err, "failed to create resource resolver")
+		os.Exit(1)
+	}
 	configuration, err := config.NewConfiguration(kubeClient)
 	if err != nil {
 		logger.Error(err, "failed to initialize configuration")
@@ -227,11 +283,19 @@ func main() {
 		kyvernoInformer.Kyverno().V1().Policies(),
 		&wg,
 	)
+	gctxStore := store.New()
+	apiCallCache := apicallcache.New(logger.WithName("apicall-cache"), apiCallCacheMaxEntries, apiCallCacheMaxEntrySize)
 	engine := engine.NewEngine(
 		configuration,
 		dClient,
 		rclient,
-		engineapi.DefaultContextLoaderFactory(configMapResolver),
+		engineapi.DefaultContextLoaderFactory(
+			configMapResolver,
+			engineapi.WithGlobalContext(gctxStore),
+			engineapi.WithAPICallCache(apiCallCache),
+			engineapi.WithSecretResolver(secretResolver, configuration),
+			engineapi.WithResourceResolver(resourceResolver),
+		),
 		// TODO: do we need exceptions here ?
 		nil,
 	)
@@ -239,6 +303,10 @@ func main() {
 	nonLeaderControllers, nonLeaderBootstrap := createNonLeaderControllers(
 		configuration,
 		kubeKyvernoInformer,
+		kyvernoInformer,
+		dClient,
+		gctxStore,
+		secretResolver,
 	)
 	// start informers and wait for cache sync
 	if !internal.StartInformersAndWaitForCacheSync(signalCtx, logger, kyvernoInformer, kubeKyvernoInformer, cacheInformer) {
@@ -3,6 +3,7 @@ package apply
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -21,6 +22,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/openapi"
 	policy2 "github.com/kyverno/kyverno/pkg/policy"
 	gitutils "github.com/kyverno/kyverno/pkg/utils/git"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/dynamic"
@@ -49,23 +51,26 @@ type SkippedInvalidPolicies struct {
 }
 
 type ApplyCommandConfig struct {
-	KubeConfig      string
-	Context         string
-	Namespace       string
-	MutateLogPath   string
-	VariablesString string
-	ValuesFile      string
-	UserInfoPath    string
-	Cluster         bool
-	PolicyReport    bool
-	Stdin           bool
-	RegistryAccess  bool
-	AuditWarn       bool
-	ResourcePaths   []string
-	PolicyPaths     []string
-	GitBranch       string
-	warnExitCode    int
-	warnNoPassed    bool
+	KubeConfig        string
+	Context           string
+	Namespace         string
+	MutateLogPath     string
+	VariablesString   string
+	ValuesFile        string
+	UserInfoPath      string
+	Cluster           bool
+	PolicyReport      bool
+	Stdin             bool
+	RegistryAccess    bool
+	AuditWarn         bool
+	ResourcePaths     []string
+	PolicyPaths       []string
+	GitBranch         string
+	Explain           bool
+	CheckIdempotency  bool
+	ShowMutationOrder bool
+	warnExitCode      int
+	warnNoPassed      bool
 }
 
 var (
@@ -146,6 +151,13 @@ To apply policy with variables:
               group: <group of parent resource>
               version: <version of parent resource>
 
+To explain how each rule was evaluated (match and exclude decisions, context entries, preconditions and failed patterns):
+        kyverno apply /path/to/policy.yaml --resource /path/to/resource.yaml --explain
+
+To check that mutations are idempotent (applying the mutate policies a second time doesn't patch the resource again,
+nor break validate rules or the OpenAPI schema), violations are counted as failures:
+        kyverno apply /path/to/folderOfPolicies --resource /path/to/resource.yaml --check-idempotency
+
 More info: https://kyverno.io/docs/kyverno-cli/
 `
 
@@ -196,6 +208,9 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVarP(&applyCommandConfig.AuditWarn, "audit-warn", "", false, "If set to true, will flag audit policies as warnings instead of failures")
 	cmd.Flags().IntVar(&applyCommandConfig.warnExitCode, "warn-exit-code", 0, "Set the exit code for warnings; if failures or errors are found, will exit 1")
 	cmd.Flags().BoolVarP(&applyCommandConfig.warnNoPassed, "warn-no-pass", "", false, "Specify if warning exit code should be raised if no objects satisfied a policy; can be used together with --warn-exit-code flag")
+	cmd.Flags().BoolVarP(&applyCommandConfig.Explain, "explain", "", false, "If set to true, prints how each rule was evaluated (match and exclude decisions, context entries, preconditions and failed patterns)")
+	cmd.Flags().BoolVarP(&applyCommandConfig.ShowMutationOrder, "show-mutation-order", "", false, "If set to true, prints the order in which the mutate policies are applied")
+	cmd.Flags().BoolVarP(&applyCommandConfig.CheckIdempotency, "check-idempotency", "", false, "If set to true, applies the mutate policies twice to each resource and fails when the second pass patches the resource again or breaks validate rules or the OpenAPI schema")
 	return cmd
 }
 
@@ -381,10 +396,20 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 		}
 	}
 
+	// policies are applied in the order the admission controller applies their mutations
+	policyutils.SortByPriority(policies)
+	if c.ShowMutationOrder && len(resources) > 0 && !c.Stdin {
+		printMutationOrder(os.Stdout, policies)
+	}
+
 	rc = &common.ResultCounts{}
 	skipInvalidPolicies.skipped = make([]string, 0)
 	skipInvalidPolicies.invalid = make([]string, 0)
 
+	// policies applied on each resource and their variables, for the idempotency check
+	var appliedPolicies []kyvernov1.PolicyInterface
+	resourceVariables := map[*unstructured.Unstructured]map[string]map[string]interface{}{}
+
 	for _, policy := range policies {
 		_, err := policy2.Validate(policy, nil, nil, true, openApiManager)
 		if err != nil {
@@ -411,12 +436,17 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 		}
 
 		kindOnwhichPolicyIsApplied := common.GetKindsFromPolicy(policy, subresources, dClient)
+		appliedPolicies = append(appliedPolicies, policy)
 
 		for _, resource := range resources {
 			thisPolicyResourceValues, err := common.CheckVariableForPolicy(valuesMap, globalValMap, policy.GetName(), resource.GetName(), resource.GetKind(), variables, kindOnwhichPolicyIsApplied, variable)
 			if err != nil {
 				return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError(fmt.Sprintf("policy `%s` have variables. pass the values for the variables for resource `%s` using set/values_file flag", policy.GetName(), resource.GetName()), err)
 			}
+			if resourceVariables[resource] == nil {
+				resourceVariables[resource] = map[string]map[string]interface{}{}
+			}
+			resourceVariables[resource][policy.GetName()] = thisPolicyResourceValues
 			applyPolicyConfig := common.ApplyPolicyConfig{
 				Policy:               policy,
 				Resource:             resource,
@@ -432,18 +462,62 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 				Client:               dClient,
 				AuditWarn:            c.AuditWarn,
 				Subresources:         subresources,
+				Explain:              c.Explain,
 			}
-			_, info, err := common.ApplyPolicyOnResource(applyPolicyConfig)
+			ers, info, err := common.ApplyPolicyOnResource(applyPolicyConfig)
 			if err != nil {
 				return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError(fmt.Errorf("failed to apply policy %v on resource %v", policy.GetName(), resource.GetName()).Error(), err)
 			}
+			if c.Explain {
+				if err := common.PrintExplanations(os.Stdout, ers); err != nil {
+					return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("failed to print explanations", err)
+				}
+			}
 			pvInfos = append(pvInfos, info)
 		}
 	}
 
+	if c.CheckIdempotency {
+		for _, resource := range resources {
+			violations := common.CheckMutationIdempotency(common.IdempotencyCheckConfig{
+				Policies:             appliedPolicies,
+				Resource:             resource,
+				Variables:            resourceVariables[resource],
+				UserInfo:             userInfo,
+				NamespaceSelectorMap: namespaceSelectorMap,
+				Client:               dClient,
+				Subresources:         subresources,
+				OpenApiValidator:     openApiManager,
+			})
+			common.PrintIdempotencyViolations(os.Stdout, resource, violations)
+			rc.Fail += len(violations)
+		}
+	}
+
 	return rc, resources, skipInvalidPolicies, pvInfos, nil
 }
 
+// printMutationOrder prints the order in which mutate policies are applied, when several policies mutate resources
+func printMutationOrder(w io.Writer, policies []kyvernov1.PolicyInterface) {
+	var mutatePolicies []kyvernov1.PolicyInterface
+	for _, policy := range policies {
+		if policy.GetSpec().HasMutate() {
+			mutatePolicies = append(mutatePolicies, policy)
+		}
+	}
+	if len(mutatePolicies) < 2 {
+		return
+	}
+	fmt.Fprintf(w, "\nmutation order:\n")
+	for i, policy := range mutatePolicies {
+		name := policy.GetName()
+		if policy.GetNamespace() != "" {
+			name = policy.GetNamespace() + "/" + name
+		}
+		fmt.Fprintf(w, "%d. %s (priority %d)\n", i+1, name, policy.GetSpec().Priority)
+	}
+}
+
 // checkMutateLogPath - checking path for printing mutated resource (-o flag)
 func checkMutateLogPath(mutateLogPath string) (mutateLogPathIsDir bool, err error) {
 	if mutateLogPath != "" {
@@ -1,14 +1,18 @@
 package apply
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	preport "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	"gotest.tools/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func Test_Apply(t *testing.T) {
@@ -226,3 +230,35 @@ func copyFileToThisDir(sourceFile string) (string, error) {
 
 	return filepath.Base(sourceFile), os.WriteFile(filepath.Base(sourceFile), input, 0644)
 }
+
+func Test_printMutationOrder(t *testing.T) {
+	mutation := kyvernov1.Rule{
+		Name:     "mutate",
+		Mutation: kyvernov1.Mutation{PatchesJSON6902: `[{"op":"add","path":"/metadata/labels/team","value":"x"}]`},
+	}
+	validation := kyvernov1.Rule{
+		Name:       "validate",
+		Validation: kyvernov1.Validation{Message: "label required", RawPattern: &apiextv1.JSON{Raw: []byte(`{"metadata":{"labels":{"team":"?*"}}}`)}},
+	}
+	policies := []kyvernov1.PolicyInterface{
+		&kyvernov1.Policy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tenant", Name: "labels"},
+			Spec:       kyvernov1.Spec{Rules: []kyvernov1.Rule{mutation}},
+		},
+		&kyvernov1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "require-labels"},
+			Spec:       kyvernov1.Spec{Rules: []kyvernov1.Rule{validation}},
+		},
+		&kyvernov1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "platform-overrides"},
+			Spec:       kyvernov1.Spec{Rules: []kyvernov1.Rule{mutation}, Priority: 10},
+		},
+	}
+	var out bytes.Buffer
+	printMutationOrder(&out, policies)
+	assert.Equal(t, out.String(), "\nmutation order:\n1. tenant/labels (priority 0)\n2. platform-overrides (priority 10)\n")
+
+	out.Reset()
+	printMutationOrder(&out, policies[:2])
+	assert.Equal(t, out.String(), "")
+}
@@ -0,0 +1,51 @@
+package conflicts
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/pkg/policy/conflicts"
+	"github.com/spf13/cobra"
+)
+
+var conflictsHelp = `
+
+To check policies for conflicting mutate rules:
+        kyverno conflicts /path/to/policy1.yaml /path/to/folderOfPolicies
+
+Mutate rules of different policies conflict when they match the same resources and write different values
+at the same path, from patchStrategicMerge, patchMerge, patchesJson6902 or foreach patches. Exclusions and
+preconditions are not considered. The command exits with an error when conflicts are found.
+`
+
+// Command returns conflicts command
+func Command() *cobra.Command {
+	return &cobra.Command{
+		Use:     "conflicts",
+		Short:   "Detects conflicting mutate rules across policies.",
+		Example: conflictsHelp,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, policyPaths []string) error {
+			cmd.SilenceUsage = true
+			return run(cmd.OutOrStdout(), policyPaths)
+		},
+	}
+}
+
+func run(w io.Writer, policyPaths []string) error {
+	policies, err := common.GetPoliciesFromPaths(memfs.New(), policyPaths, false, "")
+	if err != nil {
+		return fmt.Errorf("failed to load policies: %w", err)
+	}
+	found := conflicts.Find(policies)
+	for _, conflict := range found {
+		fmt.Fprintln(w, conflict.String())
+	}
+	if len(found) > 0 {
+		return fmt.Errorf("conflicts found: %d", len(found))
+	}
+	fmt.Fprintf(w, "no conflicts found in %d policies\n", len(policies))
+	return nil
+}
@@ -0,0 +1,56 @@
+package conflicts
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+const policies = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: pull-always
+spec:
+  rules:
+  - name: always
+    match:
+      any:
+      - resources:
+          kinds: [Pod]
+    mutate:
+      patchStrategicMerge:
+        spec:
+          containers:
+          - (name): "*"
+            imagePullPolicy: Always
+---
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: pull-if-not-present
+spec:
+  rules:
+  - name: if-not-present
+    match:
+      any:
+      - resources:
+          kinds: [Pod]
+    mutate:
+      patchesJson6902: |-
+        - op: replace
+          path: /spec/containers/0/imagePullPolicy
+          value: IfNotPresent
+`
+
+func Test_run(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policies.yaml")
+	assert.NilError(t, os.WriteFile(path, []byte(policies), 0o600))
+	var out bytes.Buffer
+	err := run(&out, []string{path})
+	assert.Error(t, err, "conflicts found: 1")
+	assert.Equal(t, out.String(), `mutate rule pull-always/always conflicts with rule pull-if-not-present/if-not-present at path /spec/containers/*/imagePullPolicy: "Always" != "IfNotPresent"`+"\n")
+}
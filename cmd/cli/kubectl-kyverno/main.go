@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apply"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/conflicts"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/jp"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/oci"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
@@ -32,6 +33,7 @@ func main() {
 		apply.Command(),
 		test.Command(),
 		jp.Command(),
+		conflicts.Command(),
 	}
 
 	if enableExperimental() {
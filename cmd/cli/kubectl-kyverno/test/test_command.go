@@ -30,6 +30,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/openapi"
 	policy2 "github.com/kyverno/kyverno/pkg/policy"
 	gitutils "github.com/kyverno/kyverno/pkg/utils/git"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	"github.com/spf13/cobra"
 	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
@@ -167,7 +168,7 @@ func Command() *cobra.Command {
 	var cmd *cobra.Command
 	var testCase string
 	var fileName, gitBranch string
-	var registryAccess, failOnly, removeColor, manifestValidate, manifestMutate bool
+	var registryAccess, failOnly, removeColor, manifestValidate, manifestMutate, explain, checkIdempotency bool
 	cmd = &cobra.Command{
 		Use: "test <path_to_folder_Containing_test.yamls> [flags]\n  kyverno test <path_to_gitRepository_with_dir> --git-branch <branchName>\n  kyverno test --manifest-mutate > kyverno-test.yaml\n  kyverno test --manifest-validate > kyverno-test.yaml",
 		// Args:    cobra.ExactArgs(1),
@@ -189,7 +190,7 @@ func Command() *cobra.Command {
 				manifest.PrintValidate()
 			} else {
 				store.SetRegistryAccess(registryAccess)
-				_, err = testCommandExecute(dirPath, fileName, gitBranch, testCase, failOnly, removeColor)
+				_, err = testCommandExecute(dirPath, fileName, gitBranch, testCase, failOnly, removeColor, explain, checkIdempotency)
 				if err != nil {
 					log.Log.V(3).Info("a directory is required")
 					return err
@@ -206,6 +207,8 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVarP(&registryAccess, "registry", "", false, "If set to true, access the image registry using local docker credentials to populate external data")
 	cmd.Flags().BoolVarP(&failOnly, "fail-only", "", false, "If set to true, display all the failing test only as output for the test command")
 	cmd.Flags().BoolVarP(&removeColor, "remove-color", "", false, "Remove any color from output")
+	cmd.Flags().BoolVarP(&explain, "explain", "", false, "If set to true, prints how each rule was evaluated (match and exclude decisions, context entries, preconditions and failed patterns)")
+	cmd.Flags().BoolVarP(&checkIdempotency, "check-idempotency", "", false, "If set to true, applies the mutate policies twice to each resource and fails when the second pass patches the resource again or breaks validate rules or the OpenAPI schema")
 	return cmd
 }
 
@@ -232,7 +235,7 @@ type testFilter struct {
 
 var ftable []Table
 
-func testCommandExecute(dirPath []string, fileName string, gitBranch string, testCase string, failOnly bool, removeColor bool) (rc *resultCounts, err error) {
+func testCommandExecute(dirPath []string, fileName string, gitBranch string, testCase string, failOnly bool, removeColor bool, explain bool, checkIdempotency bool) (rc *resultCounts, err error) {
 	var errors []error
 	fs := memfs.New()
 	rc = &resultCounts{}
@@ -352,7 +355,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 					errors = append(errors, sanitizederror.NewWithError("failed to convert to JSON", err))
 					continue
 				}
-				if err := applyPoliciesFromPath(fs, policyBytes, true, policyresoucePath, rc, openApiManager, tf, failOnly, removeColor); err != nil {
+				if err := applyPoliciesFromPath(fs, policyBytes, true, policyresoucePath, rc, openApiManager, tf, failOnly, removeColor, explain, checkIdempotency); err != nil {
 					return rc, sanitizederror.NewWithError("failed to apply test command", err)
 				}
 			}
@@ -364,7 +367,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 	} else {
 		var testFiles int
 		path := filepath.Clean(dirPath[0])
-		errors = getLocalDirTestFiles(fs, path, fileName, rc, &testFiles, openApiManager, tf, failOnly, removeColor)
+		errors = getLocalDirTestFiles(fs, path, fileName, rc, &testFiles, openApiManager, tf, failOnly, removeColor, explain, checkIdempotency)
 
 		if testFiles == 0 {
 			fmt.Printf("\n No test files found. Please provide test YAML files named kyverno-test.yaml \n")
@@ -393,7 +396,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 	return rc, nil
 }
 
-func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *resultCounts, testFiles *int, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor bool) []error {
+func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *resultCounts, testFiles *int, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor, explain, checkIdempotency bool) []error {
 	var errors []error
 
 	files, err := os.ReadDir(path)
@@ -402,7 +405,7 @@ func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *result
 	}
 	for _, file := range files {
 		if file.IsDir() {
-			getLocalDirTestFiles(fs, filepath.Join(path, file.Name()), fileName, rc, testFiles, openApiManager, tf, failOnly, removeColor)
+			getLocalDirTestFiles(fs, filepath.Join(path, file.Name()), fileName, rc, testFiles, openApiManager, tf, failOnly, removeColor, explain, checkIdempotency)
 			continue
 		}
 		if file.Name() == fileName {
@@ -418,7 +421,7 @@ func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *result
 				errors = append(errors, sanitizederror.NewWithError("failed to convert json", err))
 				continue
 			}
-			if err := applyPoliciesFromPath(fs, valuesBytes, false, path, rc, openApiManager, tf, failOnly, removeColor); err != nil {
+			if err := applyPoliciesFromPath(fs, valuesBytes, false, path, rc, openApiManager, tf, failOnly, removeColor, explain, checkIdempotency); err != nil {
 				errors = append(errors, sanitizederror.NewWithError(fmt.Sprintf("failed to apply test command from file %s", file.Name()), err))
 				continue
 			}
@@ -730,7 +733,7 @@ func getFullPath(paths []string, policyResourcePath string, isGit bool) []string
 	return paths
 }
 
-func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool, policyResourcePath string, rc *resultCounts, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor bool) (err error) {
+func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool, policyResourcePath string, rc *resultCounts, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor, explain, checkIdempotency bool) (err error) {
 	engineResponses := make([]*engineapi.EngineResponse, 0)
 	var dClient dclient.Interface
 	values := &api.Test{}
@@ -872,12 +875,17 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 		fmt.Printf("applying %s to %s... \n", msgPolicies, msgResources)
 	}
 
+	// policies applied on each resource and their variables, for the idempotency check
+	var appliedPolicies []kyvernov1.PolicyInterface
+	resourceVariables := map[*unstructured.Unstructured]map[string]map[string]interface{}{}
+
 	for _, policy := range policies {
 		_, err := policy2.Validate(policy, nil, nil, true, openApiManager)
 		if err != nil {
 			log.Log.Error(err, "skipping invalid policy", "name", policy.GetName())
 			continue
 		}
+		appliedPolicies = append(appliedPolicies, policy)
 
 		matches := common.HasVariables(policy)
 		variable := common.RemoveDuplicateAndObjectVariables(matches)
@@ -898,6 +906,10 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 			if err != nil {
 				return sanitizederror.NewWithError(fmt.Sprintf("policy `%s` have variables. pass the values for the variables for resource `%s` using set/values_file flag", policy.GetName(), resource.GetName()), err)
 			}
+			if resourceVariables[resource] == nil {
+				resourceVariables[resource] = map[string]map[string]interface{}{}
+			}
+			resourceVariables[resource][policy.GetName()] = thisPolicyResourceValues
 			applyPolicyConfig := common.ApplyPolicyConfig{
 				Policy:                    policy,
 				Resource:                  resource,
@@ -910,6 +922,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 				RuleToCloneSourceResource: ruleToCloneSourceResource,
 				Client:                    dClient,
 				Subresources:              subresources,
+				Explain:                   explain,
 			}
 			ers, info, err := common.ApplyPolicyOnResource(applyPolicyConfig)
 			if err != nil {
@@ -919,11 +932,34 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 			pvInfos = append(pvInfos, info)
 		}
 	}
+	if explain {
+		if err := common.PrintExplanations(os.Stdout, engineResponses); err != nil {
+			return sanitizederror.NewWithError("failed to print explanations", err)
+		}
+	}
 	resultsMap, testResults := buildPolicyResults(engineResponses, values.Results, pvInfos, policyResourcePath, fs, isGit)
 	resultErr := printTestResult(resultsMap, testResults, rc, failOnly, removeColor)
 	if resultErr != nil {
 		return sanitizederror.NewWithError("failed to print test result:", resultErr)
 	}
+	if checkIdempotency {
+		// mutations are applied in the order used by the admission controller
+		policyutils.SortByPriority(appliedPolicies)
+		for _, resource := range checkableResources {
+			violations := common.CheckMutationIdempotency(common.IdempotencyCheckConfig{
+				Policies:             appliedPolicies,
+				Resource:             resource,
+				Variables:            resourceVariables[resource],
+				UserInfo:             userInfo,
+				NamespaceSelectorMap: namespaceSelectorMap,
+				Client:               dClient,
+				Subresources:         subresources,
+				OpenApiValidator:     openApiManager,
+			})
+			common.PrintIdempotencyViolations(os.Stdout, resource, violations)
+			rc.Fail += len(violations)
+		}
+	}
 
 	return
 }
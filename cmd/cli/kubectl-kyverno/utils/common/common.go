@@ -49,6 +49,8 @@ type Policy struct {
 	Name      string     `json:"name"`
 	Resources []Resource `json:"resources"`
 	Rules     []Rule     `json:"rules"`
+	// Values are loaded with the policy level context entries
+	Values map[string]interface{} `json:"values"`
 }
 
 type Rule struct {
@@ -95,6 +97,7 @@ type ApplyPolicyConfig struct {
 	Client                    dclient.Interface
 	AuditWarn                 bool
 	Subresources              []Subresource
+	Explain                   bool
 }
 
 // HasVariables - check for variables in the policy
@@ -228,6 +231,7 @@ func RemoveDuplicateAndObjectVariables(matches [][]string) string {
 func GetVariable(variablesString, valuesFile string, fs billy.Filesystem, isGit bool, policyResourcePath string) (map[string]string, map[string]string, map[string]map[string]Resource, map[string]map[string]string, []Subresource, error) {
 	valuesMapResource := make(map[string]map[string]Resource)
 	valuesMapRule := make(map[string]map[string]Rule)
+	valuesMapPolicy := make(map[string]map[string]interface{})
 	namespaceSelectorMap := make(map[string]map[string]string)
 	variables := make(map[string]string)
 	subresources := make([]Subresource, 0)
@@ -327,6 +331,10 @@ func GetVariable(variablesString, valuesFile string, fs billy.Filesystem, isGit
 				}
 				valuesMapRule[p.Name] = ruleMap
 			}
+
+			if p.Values != nil {
+				valuesMapPolicy[p.Name] = p.Values
+			}
 		}
 
 		for _, n := range values.NamespaceSelectors {
@@ -358,10 +366,19 @@ func GetVariable(variablesString, valuesFile string, fs billy.Filesystem, isGit
 			})
 		}
 		storePolicies = append(storePolicies, store.Policy{
-			Name:  policyName,
-			Rules: storeRules,
+			Name:   policyName,
+			Rules:  storeRules,
+			Values: valuesMapPolicy[policyName],
 		})
 	}
+	for policyName, values := range valuesMapPolicy {
+		if _, ok := valuesMapRule[policyName]; !ok {
+			storePolicies = append(storePolicies, store.Policy{
+				Name:   policyName,
+				Values: values,
+			})
+		}
+	}
 
 	store.SetPolicies(storePolicies...)
 
@@ -372,11 +389,6 @@ func GetVariable(variablesString, valuesFile string, fs billy.Filesystem, isGit
 func ApplyPolicyOnResource(c ApplyPolicyConfig) ([]*engineapi.EngineResponse, Info, error) {
 	var engineResponses []*engineapi.EngineResponse
 	namespaceLabels := make(map[string]string)
-	operationIsDelete := false
-
-	if c.Variables["request.operation"] == "DELETE" {
-		operationIsDelete = true
-	}
 
 	policyWithNamespaceSelector := false
 OuterLoop:
@@ -423,6 +435,72 @@ OuterLoop:
 	resPath := fmt.Sprintf("%s/%s/%s", c.Resource.GetNamespace(), c.Resource.GetKind(), c.Resource.GetName())
 	log.Log.V(3).Info("applying policy on resource", "policy", c.Policy.GetName(), "resource", resPath)
 
+	eng, policyContext := newPolicyContext(c, namespaceLabels)
+
+	mutateResponse := eng.Mutate(context.Background(), policyContext)
+	engineResponses = append(engineResponses, &mutateResponse)
+
+	err := processMutateEngineResponse(c, &mutateResponse, resPath)
+	if err != nil {
+		if !sanitizederror.IsErrorSanitized(err) {
+			return engineResponses, Info{}, sanitizederror.NewWithError("failed to print mutated result", err)
+		}
+	}
+
+	var policyHasValidate bool
+	for _, rule := range autogen.ComputeRules(c.Policy) {
+		if rule.HasValidate() || rule.HasVerifyImageChecks() {
+			policyHasValidate = true
+		}
+	}
+
+	policyContext = policyContext.WithNewResource(mutateResponse.PatchedResource)
+
+	var info Info
+	var validateResponse engineapi.EngineResponse
+	if policyHasValidate {
+		validateResponse = eng.Validate(context.Background(), policyContext)
+		info = ProcessValidateEngineResponse(c.Policy, &validateResponse, resPath, c.Rc, c.PolicyReport, c.AuditWarn)
+	}
+
+	if !validateResponse.IsEmpty() {
+		engineResponses = append(engineResponses, &validateResponse)
+	}
+
+	verifyImageResponse, _ := eng.VerifyAndPatchImages(context.TODO(), policyContext)
+	if !verifyImageResponse.IsEmpty() {
+		engineResponses = append(engineResponses, &verifyImageResponse)
+		info = ProcessValidateEngineResponse(c.Policy, &verifyImageResponse, resPath, c.Rc, c.PolicyReport, c.AuditWarn)
+	}
+
+	var policyHasGenerate bool
+	for _, rule := range autogen.ComputeRules(c.Policy) {
+		if rule.HasGenerate() {
+			policyHasGenerate = true
+		}
+	}
+
+	if policyHasGenerate {
+		generateResponse := eng.ApplyBackgroundChecks(context.TODO(), policyContext)
+		if !generateResponse.IsEmpty() {
+			newRuleResponse, err := handleGeneratePolicy(&generateResponse, *policyContext, c.RuleToCloneSourceResource)
+			if err != nil {
+				log.Log.Error(err, "failed to apply generate policy")
+			} else {
+				generateResponse.PolicyResponse.Rules = newRuleResponse
+			}
+			engineResponses = append(engineResponses, &generateResponse)
+		}
+		updateResultCounts(c.Policy, &generateResponse, resPath, c.Rc, c.AuditWarn)
+	}
+
+	return engineResponses, info, nil
+}
+
+// newPolicyContext returns the engine and the policy context used to apply the configured policy on the configured resource
+func newPolicyContext(c ApplyPolicyConfig, namespaceLabels map[string]string) (engineapi.Engine, *engine.PolicyContext) {
+	operationIsDelete := c.Variables["request.operation"] == "DELETE"
+
 	resourceRaw, err := c.Resource.MarshalJSON()
 	if err != nil {
 		log.Log.Error(err, "failed to marshal resource")
@@ -488,66 +566,9 @@ OuterLoop:
 		WithNewResource(*updatedResource).
 		WithNamespaceLabels(namespaceLabels).
 		WithAdmissionInfo(c.UserInfo).
-		WithResourceKind(gvk, subresource)
-
-	mutateResponse := eng.Mutate(context.Background(), policyContext)
-	engineResponses = append(engineResponses, &mutateResponse)
-
-	err = processMutateEngineResponse(c, &mutateResponse, resPath)
-	if err != nil {
-		if !sanitizederror.IsErrorSanitized(err) {
-			return engineResponses, Info{}, sanitizederror.NewWithError("failed to print mutated result", err)
-		}
-	}
-
-	var policyHasValidate bool
-	for _, rule := range autogen.ComputeRules(c.Policy) {
-		if rule.HasValidate() || rule.HasVerifyImageChecks() {
-			policyHasValidate = true
-		}
-	}
-
-	policyContext = policyContext.WithNewResource(mutateResponse.PatchedResource)
-
-	var info Info
-	var validateResponse engineapi.EngineResponse
-	if policyHasValidate {
-		validateResponse = eng.Validate(context.Background(), policyContext)
-		info = ProcessValidateEngineResponse(c.Policy, &validateResponse, resPath, c.Rc, c.PolicyReport, c.AuditWarn)
-	}
-
-	if !validateResponse.IsEmpty() {
-		engineResponses = append(engineResponses, &validateResponse)
-	}
-
-	verifyImageResponse, _ := eng.VerifyAndPatchImages(context.TODO(), policyContext)
-	if !verifyImageResponse.IsEmpty() {
-		engineResponses = append(engineResponses, &verifyImageResponse)
-		info = ProcessValidateEngineResponse(c.Policy, &verifyImageResponse, resPath, c.Rc, c.PolicyReport, c.AuditWarn)
-	}
-
-	var policyHasGenerate bool
-	for _, rule := range autogen.ComputeRules(c.Policy) {
-		if rule.HasGenerate() {
-			policyHasGenerate = true
-		}
-	}
-
-	if policyHasGenerate {
-		generateResponse := eng.ApplyBackgroundChecks(context.TODO(), policyContext)
-		if !generateResponse.IsEmpty() {
-			newRuleResponse, err := handleGeneratePolicy(&generateResponse, *policyContext, c.RuleToCloneSourceResource)
-			if err != nil {
-				log.Log.Error(err, "failed to apply generate policy")
-			} else {
-				generateResponse.PolicyResponse.Rules = newRuleResponse
-			}
-			engineResponses = append(engineResponses, &generateResponse)
-		}
-		updateResultCounts(c.Policy, &generateResponse, resPath, c.Rc, c.AuditWarn)
-	}
-
-	return engineResponses, info, nil
+		WithResourceKind(gvk, subresource).
+		WithExplain(c.Explain)
+	return eng, policyContext
 }
 
 // PrintMutatedOutput - function to print output in provided file or directory
@@ -700,6 +721,7 @@ func ProcessValidateEngineResponse(policy kyvernov1.PolicyInterface, validateRes
 	var violatedRules []kyvernov1.ViolatedRule
 
 	printCount := 0
+	actions := validateResponse.GetRuleValidationFailureActions()
 	for _, policyRule := range autogen.ComputeRules(policy) {
 		ruleFoundInEngineResponse := false
 		if !policyRule.HasValidate() && !policyRule.HasVerifyImageChecks() && !policyRule.HasVerifyImages() {
@@ -722,12 +744,17 @@ func ProcessValidateEngineResponse(policy kyvernov1.PolicyInterface, validateRes
 
 				case engineapi.RuleStatusFail:
 					auditWarning := false
+					warning := false
 					ann := policy.GetAnnotations()
 					if scored, ok := ann[kyvernov1.AnnotationPolicyScored]; ok && scored == "false" {
 						rc.Warn++
 						vrule.Status = policyreportv1alpha2.StatusWarn
 						break
-					} else if auditWarn && validateResponse.GetValidationFailureAction().Audit() {
+					} else if action := actions.Get(valResponseRule.Name); action.Warn() {
+						rc.Warn++
+						warning = true
+						vrule.Status = policyreportv1alpha2.StatusWarn
+					} else if auditWarn && action.Audit() {
 						rc.Warn++
 						auditWarning = true
 						vrule.Status = policyreportv1alpha2.StatusWarn
@@ -740,6 +767,8 @@ func ProcessValidateEngineResponse(policy kyvernov1.PolicyInterface, validateRes
 						if printCount < 1 {
 							if auditWarning {
 								fmt.Printf("\npolicy %s -> resource %s failed as audit warning: \n", policy.GetName(), resPath)
+							} else if warning {
+								fmt.Printf("\npolicy %s -> resource %s failed as warning: \n", policy.GetName(), resPath)
 							} else {
 								fmt.Printf("\npolicy %s -> resource %s failed: \n", policy.GetName(), resPath)
 							}
@@ -797,6 +826,7 @@ func buildPVInfo(er *engineapi.EngineResponse, violatedRules []kyvernov1.Violate
 
 func updateResultCounts(policy kyvernov1.PolicyInterface, engineResponse *engineapi.EngineResponse, resPath string, rc *ResultCounts, auditWarn bool) {
 	printCount := 0
+	actions := engineResponse.GetRuleValidationFailureActions()
 	for _, policyRule := range autogen.ComputeRules(policy) {
 		ruleFoundInEngineResponse := false
 		for i, ruleResponse := range engineResponse.PolicyResponse.Rules {
@@ -812,7 +842,7 @@ func updateResultCounts(policy kyvernov1.PolicyInterface, engineResponse *engine
 					}
 					fmt.Printf("%d. %s - %s\n", i+1, ruleResponse.Name, ruleResponse.Message)
 
-					if auditWarn && engineResponse.GetValidationFailureAction().Audit() {
+					if action := actions.Get(ruleResponse.Name); action.Warn() || (auditWarn && action.Audit()) {
 						rc.Warn++
 					} else {
 						rc.Fail++
@@ -831,6 +861,15 @@ func updateResultCounts(policy kyvernov1.PolicyInterface, engineResponse *engine
 func SetInStoreContext(mutatedPolicies []kyvernov1.PolicyInterface, variables map[string]string) map[string]string {
 	storePolicies := make([]store.Policy, 0)
 	for _, policy := range mutatedPolicies {
+		policyVal := make(map[string]interface{})
+		for _, contextVar := range policy.GetSpec().Context {
+			for k, v := range variables {
+				if strings.HasPrefix(k, contextVar.Name) {
+					policyVal[k] = v
+					delete(variables, k)
+				}
+			}
+		}
 		storeRules := make([]store.Rule, 0)
 		for _, rule := range autogen.ComputeRules(policy) {
 			contextVal := make(map[string]interface{})
@@ -850,8 +889,9 @@ func SetInStoreContext(mutatedPolicies []kyvernov1.PolicyInterface, variables ma
 			}
 		}
 		storePolicies = append(storePolicies, store.Policy{
-			Name:  policy.GetName(),
-			Rules: storeRules,
+			Name:   policy.GetName(),
+			Rules:  storeRules,
+			Values: policyVal,
 		})
 	}
 
@@ -117,6 +117,32 @@ func Test_NamespaceSelector(t *testing.T) {
 	}
 }
 
+func Test_Explain(t *testing.T) {
+	policyArray, _ := yamlutils.GetPolicy(policyNamespaceSelector)
+	resourceArray, _ := GetResource([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"nginx","namespace":"test1"},"spec":{"containers":[{"image":"nginx:latest","name":"test-fail"}]}}`))
+	applyPolicyConfig := ApplyPolicyConfig{
+		Policy:   policyArray[0],
+		Resource: resourceArray[0],
+		NamespaceSelectorMap: map[string]map[string]string{
+			"test1": {
+				"foo.com/managed-state": "managed",
+			},
+		},
+		Rc:      &ResultCounts{},
+		Explain: true,
+	}
+	responses, _, err := ApplyPolicyOnResource(applyPolicyConfig)
+	assert.NilError(t, err)
+	explanations := BuildExplanations(responses)
+	assert.Equal(t, len(explanations), 1)
+	assert.Equal(t, explanations[0].Policy, "enforce-pod-name")
+	assert.Equal(t, explanations[0].Resource, "test1/Pod/nginx")
+	assert.Assert(t, len(explanations[0].Rules) > 0)
+	assert.Equal(t, explanations[0].Rules[0].Name, "validate-name")
+	assert.Equal(t, explanations[0].Rules[0].Match.Matched, true)
+	assert.Equal(t, explanations[0].Rules[0].Match.Clause, "match")
+}
+
 func Test_IsGitSourcePath(t *testing.T) {
 	type TestCase struct {
 		path    []string
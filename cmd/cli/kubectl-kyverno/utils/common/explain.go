@@ -0,0 +1,55 @@
+package common
+
+import (
+	"fmt"
+	"io"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"sigs.k8s.io/yaml"
+)
+
+// Explanation stores the rules evaluation traces of a policy applied on a resource
+type Explanation struct {
+	Policy   string                `json:"policy"`
+	Resource string                `json:"resource"`
+	Rules    []engineapi.RuleTrace `json:"rules"`
+}
+
+// BuildExplanations groups the rules evaluation traces recorded in the engine responses by policy and resource
+func BuildExplanations(responses []*engineapi.EngineResponse) []Explanation {
+	var explanations []Explanation
+	index := map[string]int{}
+	for _, response := range responses {
+		if response == nil || len(response.PolicyResponse.Traces) == 0 {
+			continue
+		}
+		resource := response.Resource
+		resPath := fmt.Sprintf("%s/%s/%s", resource.GetNamespace(), resource.GetKind(), resource.GetName())
+		key := response.Policy.GetName() + "|" + resPath
+		i, ok := index[key]
+		if !ok {
+			i = len(explanations)
+			index[key] = i
+			explanations = append(explanations, Explanation{
+				Policy:   response.Policy.GetName(),
+				Resource: resPath,
+			})
+		}
+		explanations[i].Rules = append(explanations[i].Rules, response.PolicyResponse.Traces...)
+	}
+	return explanations
+}
+
+// PrintExplanations prints the rules evaluation traces recorded in the engine responses
+func PrintExplanations(w io.Writer, responses []*engineapi.EngineResponse) error {
+	for _, explanation := range BuildExplanations(responses) {
+		data, err := yaml.Marshal(explanation)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/openapi"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// IdempotencyCheckConfig configures the mutation idempotency check of a resource
+type IdempotencyCheckConfig struct {
+	// Policies are applied in order, they are expected to be sorted by priority
+	Policies []kyvernov1.PolicyInterface
+	Resource *unstructured.Unstructured
+	// Variables contains the variables of each policy, by policy name
+	Variables            map[string]map[string]interface{}
+	UserInfo             kyvernov1beta1.RequestInfo
+	NamespaceSelectorMap map[string]map[string]string
+	Client               dclient.Interface
+	Subresources         []Subresource
+	OpenApiValidator     openapi.ValidateInterface
+}
+
+// IdempotencyViolation describes why the mutations of a resource are not idempotent
+type IdempotencyViolation struct {
+	// Policy and Rule are empty for OpenAPI schema violations
+	Policy  string
+	Rule    string
+	Message string
+}
+
+// CheckMutationIdempotency applies the mutate policies to the resource twice, as the admission controller does when
+// the webhook is reinvoked. It reports the rules patching the resource again in the second pass, the validate rules
+// passing on the resource before the second pass and failing after it, and the OpenAPI schema violations introduced
+// by the mutations.
+func CheckMutationIdempotency(c IdempotencyCheckConfig) []IdempotencyViolation {
+	var violations []IdempotencyViolation
+	once, _ := c.mutate(*c.Resource)
+	twice, patched := c.mutate(once)
+	for _, rule := range patched {
+		violations = append(violations, IdempotencyViolation{
+			Policy:  rule.policy,
+			Rule:    rule.rule,
+			Message: "rule patched the resource again when the mutations were applied a second time",
+		})
+	}
+	passed := c.passedValidateRules(*c.Resource).Union(c.passedValidateRules(once))
+	for _, rule := range c.failedValidateRules(twice) {
+		if passed.Has(rule.String()) {
+			violations = append(violations, IdempotencyViolation{
+				Policy:  rule.policy,
+				Rule:    rule.rule,
+				Message: "rule no longer passes when the mutations are applied a second time",
+			})
+		}
+	}
+	// resources without a known schema, e.g. custom resources when the CRD is not available, are not checked
+	if c.OpenApiValidator != nil && c.OpenApiValidator.ValidateResource(*c.Resource.DeepCopy(), c.Resource.GetAPIVersion(), c.Resource.GetKind()) == nil {
+		if err := c.OpenApiValidator.ValidateResource(*twice.DeepCopy(), twice.GetAPIVersion(), twice.GetKind()); err != nil {
+			violations = append(violations, IdempotencyViolation{
+				Message: fmt.Sprintf("mutated resource doesn't match the OpenAPI schema: %v", err),
+			})
+		}
+	}
+	return violations
+}
+
+// PrintIdempotencyViolations prints the mutation idempotency violations of a resource
+func PrintIdempotencyViolations(w io.Writer, resource *unstructured.Unstructured, violations []IdempotencyViolation) {
+	if len(violations) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\nmutations of resource %s/%s/%s are not idempotent:\n", resource.GetNamespace(), resource.GetKind(), resource.GetName())
+	for i, violation := range violations {
+		if violation.Policy == "" {
+			fmt.Fprintf(w, "%d. %s\n", i+1, violation.Message)
+		} else {
+			fmt.Fprintf(w, "%d. %s/%s: %s\n", i+1, violation.Policy, violation.Rule, violation.Message)
+		}
+	}
+}
+
+type policyRule struct {
+	policy string
+	rule   string
+}
+
+func (r policyRule) String() string {
+	return r.policy + "/" + r.rule
+}
+
+func (c IdempotencyCheckConfig) applyPolicyConfig(policy kyvernov1.PolicyInterface, resource *unstructured.Unstructured) ApplyPolicyConfig {
+	return ApplyPolicyConfig{
+		Policy:               policy,
+		Resource:             resource,
+		Variables:            c.Variables[policy.GetName()],
+		UserInfo:             c.UserInfo,
+		NamespaceSelectorMap: c.NamespaceSelectorMap,
+		Client:               c.Client,
+		Subresources:         c.Subresources,
+	}
+}
+
+// mutate applies the mutate policies in order, it returns the mutated resource and the rules that patched it
+func (c IdempotencyCheckConfig) mutate(resource unstructured.Unstructured) (unstructured.Unstructured, []policyRule) {
+	var patched []policyRule
+	for _, policy := range c.Policies {
+		if !policy.GetSpec().HasMutate() {
+			continue
+		}
+		eng, policyContext := newPolicyContext(c.applyPolicyConfig(policy, &resource), c.NamespaceSelectorMap[resource.GetNamespace()])
+		response := eng.Mutate(context.Background(), policyContext)
+		for _, rule := range response.PolicyResponse.Rules {
+			if rule.Status == engineapi.RuleStatusPass && len(rule.Patches) > 0 {
+				patched = append(patched, policyRule{policy: policy.GetName(), rule: rule.Name})
+			}
+		}
+		resource = response.PatchedResource
+	}
+	return resource, patched
+}
+
+func (c IdempotencyCheckConfig) validate(resource unstructured.Unstructured, status engineapi.RuleStatus) []policyRule {
+	var rules []policyRule
+	for _, policy := range c.Policies {
+		if !policy.GetSpec().HasValidate() {
+			continue
+		}
+		eng, policyContext := newPolicyContext(c.applyPolicyConfig(policy, &resource), c.NamespaceSelectorMap[resource.GetNamespace()])
+		response := eng.Validate(context.Background(), policyContext)
+		for _, rule := range response.PolicyResponse.Rules {
+			if rule.Status == status {
+				rules = append(rules, policyRule{policy: policy.GetName(), rule: rule.Name})
+			}
+		}
+	}
+	return rules
+}
+
+func (c IdempotencyCheckConfig) passedValidateRules(resource unstructured.Unstructured) sets.Set[string] {
+	passed := sets.New[string]()
+	for _, rule := range c.validate(resource, engineapi.RuleStatusPass) {
+		passed.Insert(rule.String())
+	}
+	return passed
+}
+
+func (c IdempotencyCheckConfig) failedValidateRules(resource unstructured.Unstructured) []policyRule {
+	return c.validate(resource, engineapi.RuleStatusFail)
+}
@@ -0,0 +1,175 @@
+package common
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+var idempotencyResource = []byte(`{
+	"apiVersion": "v1",
+	"kind": "Pod",
+	"metadata": {"name": "nginx", "namespace": "default"},
+	"spec": {"containers": [{"name": "nginx", "image": "nginx:1.25"}]}
+}`)
+
+func newIdempotencyPolicy(t *testing.T, name, rules string) kyvernov1.PolicyInterface {
+	var policy kyvernov1.ClusterPolicy
+	assert.NilError(t, yaml.Unmarshal([]byte(rules), &policy.Spec))
+	policy.Name = name
+	return &policy
+}
+
+// schemaValidator rejects resources labelled with schema: invalid
+type schemaValidator struct{}
+
+func (schemaValidator) ValidateResource(resource unstructured.Unstructured, _, _ string) error {
+	if resource.GetLabels()["schema"] == "invalid" {
+		return errors.New("invalid label")
+	}
+	return nil
+}
+
+func (schemaValidator) ValidatePolicyMutation(kyvernov1.PolicyInterface) error {
+	return nil
+}
+
+func Test_CheckMutationIdempotency(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies []string
+		want     []IdempotencyViolation
+	}{{
+		name: "idempotent",
+		policies: []string{`
+rules:
+- name: label
+  match:
+    any:
+    - resources:
+        kinds: [Pod]
+  mutate:
+    patchStrategicMerge:
+      metadata:
+        labels:
+          team: platform
+`},
+	}, {
+		name: "appended container",
+		policies: []string{`
+rules:
+- name: sidecar
+  match:
+    any:
+    - resources:
+        kinds: [Pod]
+  mutate:
+    patchesJson6902: |-
+      - op: add
+        path: /spec/containers/-
+        value: {name: sidecar, image: busybox}
+`},
+		want: []IdempotencyViolation{{
+			Policy:  "policy-0",
+			Rule:    "sidecar",
+			Message: "rule patched the resource again when the mutations were applied a second time",
+		}},
+	}, {
+		name: "validation broken by the second pass",
+		policies: []string{`
+rules:
+- name: sidecar
+  match:
+    any:
+    - resources:
+        kinds: [Pod]
+  mutate:
+    patchesJson6902: |-
+      - op: add
+        path: /spec/containers/-
+        value: {name: sidecar, image: busybox}
+`, `
+validationFailureAction: Enforce
+rules:
+- name: max-containers
+  match:
+    any:
+    - resources:
+        kinds: [Pod]
+  validate:
+    message: at most 2 containers
+    pattern:
+      spec:
+        containers:
+          $size: "<= 2"
+`},
+		want: []IdempotencyViolation{{
+			Policy:  "policy-0",
+			Rule:    "sidecar",
+			Message: "rule patched the resource again when the mutations were applied a second time",
+		}, {
+			Policy:  "policy-1",
+			Rule:    "max-containers",
+			Message: "rule no longer passes when the mutations are applied a second time",
+		}},
+	}, {
+		name: "schema",
+		policies: []string{`
+rules:
+- name: label
+  match:
+    any:
+    - resources:
+        kinds: [Pod]
+  mutate:
+    patchStrategicMerge:
+      metadata:
+        labels:
+          schema: invalid
+`},
+		want: []IdempotencyViolation{{
+			Message: "mutated resource doesn't match the OpenAPI schema: invalid label",
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var policies []kyvernov1.PolicyInterface
+			for i, rules := range tt.policies {
+				policies = append(policies, newIdempotencyPolicy(t, "policy-"+strconv.Itoa(i), rules))
+			}
+			resource, err := kubeutils.BytesToUnstructured(idempotencyResource)
+			assert.NilError(t, err)
+			got := CheckMutationIdempotency(IdempotencyCheckConfig{
+				Policies:         policies,
+				Resource:         resource,
+				OpenApiValidator: schemaValidator{},
+			})
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
+
+func Test_PrintIdempotencyViolations(t *testing.T) {
+	resource, err := kubeutils.BytesToUnstructured(idempotencyResource)
+	assert.NilError(t, err)
+	var out bytes.Buffer
+	PrintIdempotencyViolations(&out, resource, []IdempotencyViolation{
+		{Policy: "add-sidecar", Rule: "sidecar", Message: "rule patched the resource again when the mutations were applied a second time"},
+		{Message: "mutated resource doesn't match the OpenAPI schema: invalid label"},
+	})
+	assert.Equal(t, out.String(), `
+mutations of resource default/Pod/nginx are not idempotent:
+1. add-sidecar/sidecar: rule patched the resource again when the mutations were applied a second time
+2. mutated resource doesn't match the OpenAPI schema: invalid label
+`)
+	out.Reset()
+	PrintIdempotencyViolations(&out, resource, nil)
+	assert.Equal(t, out.String(), "")
+}
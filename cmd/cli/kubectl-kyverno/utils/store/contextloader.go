@@ -41,40 +41,54 @@ func (l *mockContextLoader) Load(
 	_ registryclient.Client,
 	contextEntries []kyvernov1.ContextEntry,
 	jsonContext enginecontext.Interface,
-) error {
-	rule := GetPolicyRule(l.policyName, l.ruleName)
-	if rule != nil && len(rule.Values) > 0 {
-		variables := rule.Values
-		for key, value := range variables {
-			if err := jsonContext.AddVariable(key, value); err != nil {
-				return err
-			}
+) ([]string, error) {
+	// the policy level context entries are loaded with an empty rule and use the policy values
+	var rule *Rule
+	var variables map[string]interface{}
+	if l.ruleName == "" {
+		if policy := GetPolicy(l.policyName); policy != nil {
+			variables = policy.Values
+		}
+	} else if rule = GetPolicyRule(l.policyName, l.ruleName); rule != nil {
+		variables = rule.Values
+	}
+	for key, value := range variables {
+		if err := jsonContext.AddVariable(key, value); err != nil {
+			return nil, err
 		}
 	}
 	hasRegistryAccess := GetRegistryAccess()
 	// Context Variable should be loaded after the values loaded from values file
+	var fallbacks []string
 	for _, entry := range contextEntries {
+		var err error
 		if entry.ImageRegistry != nil && hasRegistryAccess {
 			rclient := GetRegistryClient()
-			if err := engineapi.LoadImageData(ctx, rclient, l.logger, entry, jsonContext); err != nil {
-				return err
-			}
+			err = engineapi.LoadImageData(ctx, rclient, l.logger, entry, jsonContext, nil)
 		} else if entry.Variable != nil {
-			if err := engineapi.LoadVariable(l.logger, entry, jsonContext); err != nil {
-				return err
-			}
+			err = engineapi.LoadVariable(l.logger, entry, jsonContext, nil)
 		} else if entry.APICall != nil && IsApiCallAllowed() {
-			if err := engineapi.LoadAPIData(ctx, l.logger, entry, jsonContext, client); err != nil {
-				return err
+			err = engineapi.LoadAPIData(ctx, l.logger, entry, jsonContext, client, nil, nil, nil)
+		} else {
+			continue
+		}
+		if err != nil {
+			if err := engineapi.HandleContextEntryError(l.logger, entry, jsonContext, err); err != nil {
+				engineapi.TraceContextEntry(ctx, entry, engineapi.ContextEntryError, err)
+				return nil, err
 			}
+			engineapi.TraceContextEntry(ctx, entry, engineapi.ContextEntryFallback, err)
+			fallbacks = append(fallbacks, entry.Name)
+		} else {
+			engineapi.TraceContextEntry(ctx, entry, engineapi.ContextEntryLoaded, nil)
 		}
 	}
 	if rule != nil && len(rule.ForEachValues) > 0 {
 		for key, value := range rule.ForEachValues {
 			if err := jsonContext.AddVariable(key, value[GetForeachElement()]); err != nil {
-				return err
+				return nil, err
 			}
 		}
 	}
-	return nil
+	return fallbacks, nil
 }
@@ -11,6 +11,8 @@ type Context struct {
 type Policy struct {
 	Name  string `json:"name"`
 	Rules []Rule `json:"rules"`
+	// Values are loaded with the policy level context entries
+	Values map[string]interface{} `json:"values"`
 }
 
 type Rule struct {
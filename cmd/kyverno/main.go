@@ -25,15 +25,19 @@ import (
 	configcontroller "github.com/kyverno/kyverno/pkg/controllers/config"
 	genericloggingcontroller "github.com/kyverno/kyverno/pkg/controllers/generic/logging"
 	genericwebhookcontroller "github.com/kyverno/kyverno/pkg/controllers/generic/webhook"
+	globalcontextcontroller "github.com/kyverno/kyverno/pkg/controllers/globalcontext"
 	policymetricscontroller "github.com/kyverno/kyverno/pkg/controllers/metrics/policy"
 	openapicontroller "github.com/kyverno/kyverno/pkg/controllers/openapi"
 	policycachecontroller "github.com/kyverno/kyverno/pkg/controllers/policycache"
+	vapcontroller "github.com/kyverno/kyverno/pkg/controllers/validatingadmissionpolicy"
 	webhookcontroller "github.com/kyverno/kyverno/pkg/controllers/webhook"
 	"github.com/kyverno/kyverno/pkg/cosign"
 	"github.com/kyverno/kyverno/pkg/engine"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	apicallcache "github.com/kyverno/kyverno/pkg/engine/apicall/cache"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
@@ -54,6 +58,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apiserver "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	kubeinformers "k8s.io/client-go/informers"
+	admissionregistrationv1alpha1informers "k8s.io/client-go/informers/admissionregistration/v1alpha1"
 	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	kyamlopenapi "sigs.k8s.io/kustomize/kyaml/openapi"
@@ -106,6 +111,7 @@ func sanityChecks(apiserverClient apiserver.Interface) error {
 func createNonLeaderControllers(
 	eng engineapi.Engine,
 	genWorkers int,
+	generateValidatingAdmissionPolicy bool,
 	kubeInformer kubeinformers.SharedInformerFactory,
 	kubeKyvernoInformer kubeinformers.SharedInformerFactory,
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
@@ -114,12 +120,22 @@ func createNonLeaderControllers(
 	configuration config.Configuration,
 	policyCache policycache.Cache,
 	manager openapi.Manager,
+	gctxStore store.Store,
+	secretResolver engineapi.SecretResolver,
 ) ([]internal.Controller, func() error) {
+	var vapInformer admissionregistrationv1alpha1informers.ValidatingAdmissionPolicyInformer
+	var vapbindingInformer admissionregistrationv1alpha1informers.ValidatingAdmissionPolicyBindingInformer
+	if generateValidatingAdmissionPolicy {
+		vapInformer = kubeInformer.Admissionregistration().V1alpha1().ValidatingAdmissionPolicies()
+		vapbindingInformer = kubeInformer.Admissionregistration().V1alpha1().ValidatingAdmissionPolicyBindings()
+	}
 	policyCacheController := policycachecontroller.NewController(
 		dynamicClient,
 		policyCache,
 		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
 		kyvernoInformer.Kyverno().V1().Policies(),
+		vapInformer,
+		vapbindingInformer,
 	)
 	openApiController := openapicontroller.NewController(
 		dynamicClient,
@@ -129,10 +145,18 @@ func createNonLeaderControllers(
 		configuration,
 		kubeKyvernoInformer.Core().V1().ConfigMaps(),
 	)
+	globalContextController := globalcontextcontroller.NewController(
+		kyvernoInformer.Kyverno().V2alpha1().GlobalContextEntries(),
+		dynamicClient,
+		engineapi.NewCredentialsSecretLoader(secretResolver, "", configuration),
+		configuration,
+		gctxStore,
+	)
 	return []internal.Controller{
 			internal.NewController(policycachecontroller.ControllerName, policyCacheController, policycachecontroller.Workers),
 			internal.NewController(openapicontroller.ControllerName, openApiController, openapicontroller.Workers),
 			internal.NewController(configcontroller.ControllerName, configurationController, configcontroller.Workers),
+			internal.NewController(globalcontextcontroller.ControllerName, globalContextController, globalcontextcontroller.Workers),
 		},
 		func() error {
 			return policyCacheController.WarmUp()
@@ -141,6 +165,7 @@ func createNonLeaderControllers(
 
 func createrLeaderControllers(
 	admissionReports bool,
+	generateValidatingAdmissionPolicy bool,
 	serverIP string,
 	webhookTimeout int,
 	autoUpdateWebhooks bool,
@@ -203,34 +228,48 @@ func createrLeaderControllers(
 		genericwebhookcontroller.Fail,
 		genericwebhookcontroller.None,
 	)
-	return []internal.Controller{
-			internal.NewController(certmanager.ControllerName, certManager, certmanager.Workers),
-			internal.NewController(webhookcontroller.ControllerName, webhookController, webhookcontroller.Workers),
-			internal.NewController(exceptionWebhookControllerName, exceptionWebhookController, 1),
-		},
-		nil,
-		nil
+	leaderControllers := []internal.Controller{
+		internal.NewController(certmanager.ControllerName, certManager, certmanager.Workers),
+		internal.NewController(webhookcontroller.ControllerName, webhookController, webhookcontroller.Workers),
+		internal.NewController(exceptionWebhookControllerName, exceptionWebhookController, 1),
+	}
+	if generateValidatingAdmissionPolicy {
+		vapController := vapcontroller.NewController(
+			kubeClient,
+			kyvernoClient,
+			dynamicClient.Discovery(),
+			kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+			kubeInformer.Admissionregistration().V1alpha1().ValidatingAdmissionPolicies(),
+			kubeInformer.Admissionregistration().V1alpha1().ValidatingAdmissionPolicyBindings(),
+		)
+		leaderControllers = append(leaderControllers, internal.NewController(vapcontroller.ControllerName, vapController, vapcontroller.Workers))
+	}
+	return leaderControllers, nil, nil
 }
 
 func main() {
 	var (
 		// TODO: this has been added to backward support command line arguments
 		// will be removed in future and the configuration will be set only via configmaps
-		serverIP                   string
-		webhookTimeout             int
-		genWorkers                 int
-		maxQueuedEvents            int
-		autoUpdateWebhooks         bool
-		imagePullSecrets           string
-		imageSignatureRepository   string
-		allowInsecureRegistry      bool
-		webhookRegistrationTimeout time.Duration
-		admissionReports           bool
-		dumpPayload                bool
-		leaderElectionRetryPeriod  time.Duration
-		enablePolicyException      bool
-		exceptionNamespace         string
-		servicePort                int
+		serverIP                          string
+		webhookTimeout                    int
+		genWorkers                        int
+		maxQueuedEvents                   int
+		autoUpdateWebhooks                bool
+		imagePullSecrets                  string
+		imageSignatureRepository          string
+		allowInsecureRegistry             bool
+		webhookRegistrationTimeout        time.Duration
+		admissionReports                  bool
+		dumpPayload                       bool
+		leaderElectionRetryPeriod         time.Duration
+		enablePolicyException             bool
+		exceptionNamespace                string
+		servicePort                       int
+		generateValidatingAdmissionPolicy bool
+		apiCallCacheMaxEntries            int
+		apiCallCacheMaxEntrySize          int
+		resourceContextInformers          string
 	)
 	flagset := flag.NewFlagSet("kyverno", flag.ExitOnError)
 	flagset.BoolVar(&dumpPayload, "dumpPayload", false, "Set this flag to activate/deactivate debug mode.")
@@ -250,6 +289,10 @@ func main() {
 	flagset.StringVar(&exceptionNamespace, "exceptionNamespace", "", "Configure the namespace to accept PolicyExceptions.")
 	flagset.BoolVar(&enablePolicyException, "enablePolicyException", false, "Enable PolicyException feature.")
 	flagset.IntVar(&servicePort, "servicePort", 443, "Port used by the Kyverno Service resource and for webhook configurations.")
+	flagset.BoolVar(&generateValidatingAdmissionPolicy, "generateValidatingAdmissionPolicy", false, "Set this flag to 'true' to generate validating admission policies from opted in cluster policies (requires the ValidatingAdmissionPolicy API).")
+	flagset.IntVar(&apiCallCacheMaxEntries, "apiCallCacheMaxEntries", apicallcache.DefaultMaxEntries, "Maximum number of API call responses cached for context entries with a cacheTTL.")
+	flagset.IntVar(&apiCallCacheMaxEntrySize, "apiCallCacheMaxEntrySize", apicallcache.DefaultMaxEntrySize, "Maximum size in bytes of a cached API call response.")
+	flagset.StringVar(&resourceContextInformers, "resourceContextInformers", "", "Comma separated list of group version resources (e.g. apps/v1/deployments,v1/configmaps) served from informers to resource context entries, other resources are fetched from the API server.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
@@ -322,6 +365,41 @@ func main() {
 		logger.Error(err, "failed to create config map resolver")
 		os.Exit(1)
 	}
+	informerBasedSecretResolver, err := resolvers.NewInformerBasedSecretResolver(cacheInformer.Core().V1().Secrets().Lister())
+	if err != nil {
+		logger.Error(err, "failed to create informer based secret resolver")
+		os.Exit(1)
+	}
+	clientBasedSecretResolver, err := resolvers.NewClientBasedSecretResolver(kubeClient)
+	if err != nil {
+		logger.Error(err, "failed to create client based secret resolver")
+		os.Exit(1)
+	}
+	secretResolver, err := engineapi.NewNamespacedResourceResolver(informerBasedSecretResolver, clientBasedSecretResolver)
+	if err != nil {
+		logger.Error(err, "failed to create secret resolver")
+		os.Exit(1)
+	}
+	resourceInformers, err := resolvers.ParseGroupVersionResources(resourceContextInformers)
+	if err != nil {
+		logger.Error(err, "failed to parse resource context informers")
+		os.Exit(1)
+	}
+	informerBasedResourceResolver, err := resolvers.NewInformerBasedResourceResolver(signalCtx, dynamicClient, resyncPeriod, resolvers.DefaultInformerIdleTimeout, resourceInformers...)
+	if err != nil {
+		logger.Error(err, "failed to create informer based resource resolver")
+		os.Exit(1)
+	}
+	clientBasedResourceResolver, err := resolvers.NewClientBasedResourceResolver(dynamicClient)
+	if err != nil {
+		logger.Error(err, "failed to create client based resource resolver")
+		os.Exit(1)
+	}
+	resourceResolver, err := engineapi.NewResourceResolver(informerBasedResourceResolver, clientBasedResourceResolver)
+	if err != nil {
+		logger.Error(err, "failed to create resource resolver")
+		os.Exit(1)
+	}
 	configuration, err := config.NewConfiguration(kubeClient)
 	if err != nil {
 		logger.Error(err, "failed to initialize configuration")
@@ -384,17 +462,26 @@ func main() {
 			exceptionsLister = lister
 		}
 	}
+	gctxStore := store.New()
+	apiCallCache := apicallcache.New(logger.WithName("apicall-cache"), apiCallCacheMaxEntries, apiCallCacheMaxEntrySize)
 	eng := engine.NewEngine(
 		configuration,
 		dClient,
 		rclient,
-		engineapi.DefaultContextLoaderFactory(configMapResolver),
+		engineapi.DefaultContextLoaderFactory(
+			configMapResolver,
+			engineapi.WithGlobalContext(gctxStore),
+			engineapi.WithAPICallCache(apiCallCache),
+			engineapi.WithSecretResolver(secretResolver, configuration),
+			engineapi.WithResourceResolver(resourceResolver),
+		),
 		exceptionsLister,
 	)
 	// create non leader controllers
 	nonLeaderControllers, nonLeaderBootstrap := createNonLeaderControllers(
 		eng,
 		genWorkers,
+		generateValidatingAdmissionPolicy,
 		kubeInformer,
 		kubeKyvernoInformer,
 		kyvernoInformer,
@@ -403,6 +490,8 @@ func main() {
 		configuration,
 		policyCache,
 		openApiManager,
+		gctxStore,
+		secretResolver,
 	)
 	// start informers and wait for cache sync
 	if !internal.StartInformersAndWaitForCacheSync(signalCtx, logger, kyvernoInformer, kubeInformer, kubeKyvernoInformer, cacheInformer) {
@@ -435,6 +524,7 @@ func main() {
 			// create leader controllers
 			leaderControllers, warmup, err := createrLeaderControllers(
 				admissionReports,
+				generateValidatingAdmissionPolicy,
 				serverIP,
 				webhookTimeout,
 				autoUpdateWebhooks,
@@ -498,6 +588,8 @@ func main() {
 	policyHandlers := webhookspolicy.NewHandlers(
 		dClient,
 		openApiManager,
+		kyvernoInformer.Kyverno().V1().ClusterPolicies().Lister(),
+		kyvernoInformer.Kyverno().V1().Policies().Lister(),
 	)
 	resourceHandlers := webhooksresource.NewHandlers(
 		eng,
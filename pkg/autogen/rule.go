@@ -105,6 +105,18 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 		rule.Mutation = newMutation
 		return rule
 	}
+	if target := rule.Mutation.GetPatchMerge(); target != nil {
+		newMutation := kyvernov1.Mutation{}
+		newMutation.SetPatchMerge(
+			map[string]interface{}{
+				"spec": map[string]interface{}{
+					tplKey: target,
+				},
+			},
+		)
+		rule.Mutation = newMutation
+		return rule
+	}
 	if len(rule.Mutation.ForEachMutation) > 0 && rule.Mutation.ForEachMutation != nil {
 		var newForEachMutation []kyvernov1.ForEachMutation
 		for _, foreach := range rule.Mutation.ForEachMutation {
@@ -113,13 +125,23 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 				Context:          foreach.Context,
 				AnyAllConditions: foreach.AnyAllConditions,
 			}
-			temp.SetPatchStrategicMerge(
-				map[string]interface{}{
-					"spec": map[string]interface{}{
-						tplKey: foreach.GetPatchStrategicMerge(),
+			if patchMerge := foreach.GetPatchMerge(); patchMerge != nil {
+				temp.SetPatchMerge(
+					map[string]interface{}{
+						"spec": map[string]interface{}{
+							tplKey: patchMerge,
+						},
 					},
-				},
-			)
+				)
+			} else {
+				temp.SetPatchStrategicMerge(
+					map[string]interface{}{
+						"spec": map[string]interface{}{
+							tplKey: foreach.GetPatchStrategicMerge(),
+						},
+					},
+				)
+			}
 			newForEachMutation = append(newForEachMutation, temp)
 		}
 		rule.Mutation = kyvernov1.Mutation{
@@ -211,6 +233,11 @@ func getAutogenRuleName(prefix string, name string) string {
 	return name
 }
 
+// GetAutogenRuleNames returns the names of the rules that can be generated from a rule for pod controllers
+func GetAutogenRuleNames(name string) []string {
+	return []string{getAutogenRuleName("autogen", name), getAutogenRuleName("autogen-cronjob", name)}
+}
+
 func isAutogenRuleName(name string) bool {
 	return strings.HasPrefix(name, "autogen-")
 }
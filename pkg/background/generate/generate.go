@@ -297,6 +297,18 @@ func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext
 	applyRules := policy.GetSpec().GetApplyRules()
 	applyCount := 0
 
+	// add policy context entries, they are shared by all rules
+	if contextEntries := policy.GetSpec().Context; len(contextEntries) != 0 {
+		fallbacks, err := c.engine.ContextLoader(policy, kyvernov1.Rule{})(context.TODO(), contextEntries, jsonContext)
+		if err != nil {
+			log.Error(err, "cannot add policy context entries to context")
+			return nil, err
+		}
+		if len(fallbacks) != 0 {
+			log.V(2).Info("policy context entries fell back", "entries", fallbacks)
+		}
+	}
+
 	for _, rule := range autogen.ComputeRules(policy) {
 		var err error
 		if !rule.HasGenerate() {
@@ -314,10 +326,14 @@ func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext
 		}
 
 		// add configmap json data to context
-		if err := c.engine.ContextLoader(policyContext.Policy(), rule)(context.TODO(), rule.Context, policyContext.JSONContext()); err != nil {
+		fallbacks, err := c.engine.ContextLoader(policyContext.Policy(), rule)(context.TODO(), rule.Context, policyContext.JSONContext())
+		if err != nil {
 			log.Error(err, "cannot add configmaps to context")
 			return nil, err
 		}
+		if len(fallbacks) != 0 {
+			log.V(2).Info("rule context entries fell back", "rule", rule.Name, "entries", fallbacks)
+		}
 
 		if rule, err = variables.SubstituteAllInRule(log, policyContext.JSONContext(), rule); err != nil {
 			log.Error(err, "variable substitution failed for rule %s", rule.Name)
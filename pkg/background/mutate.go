@@ -6,7 +6,9 @@ import (
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	common "github.com/kyverno/kyverno/pkg/background/common"
 	"github.com/kyverno/kyverno/pkg/config"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 func (c *controller) handleMutatePolicyAbsence(ur *kyvernov1beta1.UpdateRequest) error {
@@ -19,3 +21,39 @@ func (c *controller) handleMutatePolicyAbsence(ur *kyvernov1beta1.UpdateRequest)
 		metav1.ListOptions{LabelSelector: metav1.FormatLabelSelector(selector)},
 	)
 }
+
+// hasPrecedingMutations checks if the same trigger has a pending mutate update request, not processed yet, for a policy
+// whose mutations are applied before the update request policy ones
+func (c *controller) hasPrecedingMutations(ur *kyvernov1beta1.UpdateRequest) (bool, error) {
+	policy, err := c.getPolicy(ur.Spec.Policy)
+	if err != nil {
+		return false, err
+	}
+	// mutate update requests are labelled with their policy and trigger, only the trigger labels are used
+	selector := common.MutateLabelsSet(ur.Spec.Policy, ur.Spec.GetResource())
+	delete(selector, kyvernov1beta1.URMutatePolicyLabel)
+	urs, err := c.urLister.List(labels.SelectorFromSet(selector))
+	if err != nil {
+		return false, err
+	}
+	for _, other := range urs {
+		if other.Spec.GetRequestType() != kyvernov1beta1.Mutate || other.Spec.Policy == ur.Spec.Policy {
+			continue
+		}
+		// update requests that already failed once don't hold the others back
+		if other.Status.State != kyvernov1beta1.Pending || other.Status.Message != "" {
+			continue
+		}
+		if other.Spec.GetResource() != ur.Spec.GetResource() {
+			continue
+		}
+		otherPolicy, err := c.getPolicy(other.Spec.Policy)
+		if err != nil {
+			continue
+		}
+		if policyutils.AppliedBefore(otherPolicy, policy) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
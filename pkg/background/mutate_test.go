@@ -0,0 +1,118 @@
+package background
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	kyvernov1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func Test_hasPrecedingMutations(t *testing.T) {
+	trigger := kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "dictionary"}
+	otherTrigger := kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "other"}
+	newPolicy := func(name string, priority int32) *kyvernov1.ClusterPolicy {
+		return &kyvernov1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       kyvernov1.Spec{Priority: priority},
+		}
+	}
+	newUR := func(name, policy string, resource kyvernov1.ResourceSpec, message string) *kyvernov1beta1.UpdateRequest {
+		return &kyvernov1beta1.UpdateRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: config.KyvernoNamespace(),
+				Name:      name,
+				Labels:    common.MutateLabelsSet(policy, resource),
+			},
+			Spec: kyvernov1beta1.UpdateRequestSpec{
+				Type:     kyvernov1beta1.Mutate,
+				Policy:   policy,
+				Resource: resource,
+			},
+			Status: kyvernov1beta1.UpdateRequestStatus{
+				State:   kyvernov1beta1.Pending,
+				Message: message,
+			},
+		}
+	}
+	policies := []*kyvernov1.ClusterPolicy{
+		newPolicy("platform", -10),
+		newPolicy("tenant", 0),
+		newPolicy("overrides", 10),
+	}
+	tests := []struct {
+		name string
+		ur   *kyvernov1beta1.UpdateRequest
+		urs  []*kyvernov1beta1.UpdateRequest
+		want bool
+	}{{
+		name: "no other update request",
+		ur:   newUR("ur-tenant", "tenant", trigger, ""),
+		want: false,
+	}, {
+		name: "lower priority pending",
+		ur:   newUR("ur-tenant", "tenant", trigger, ""),
+		urs:  []*kyvernov1beta1.UpdateRequest{newUR("ur-platform", "platform", trigger, "")},
+		want: true,
+	}, {
+		name: "higher priority pending",
+		ur:   newUR("ur-tenant", "tenant", trigger, ""),
+		urs:  []*kyvernov1beta1.UpdateRequest{newUR("ur-overrides", "overrides", trigger, "")},
+		want: false,
+	}, {
+		name: "lower priority for another trigger",
+		ur:   newUR("ur-tenant", "tenant", trigger, ""),
+		urs:  []*kyvernov1beta1.UpdateRequest{newUR("ur-platform", "platform", otherTrigger, "")},
+		want: false,
+	}, {
+		name: "lower priority failed once",
+		ur:   newUR("ur-tenant", "tenant", trigger, ""),
+		urs:  []*kyvernov1beta1.UpdateRequest{newUR("ur-platform", "platform", trigger, "failed to update target resource")},
+		want: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policyIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, policy := range policies {
+				assert.NilError(t, policyIndexer.Add(policy))
+			}
+			urIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			assert.NilError(t, urIndexer.Add(tt.ur))
+			for _, ur := range tt.urs {
+				assert.NilError(t, urIndexer.Add(ur))
+			}
+			c := &controller{
+				cpolLister: kyvernov1listers.NewClusterPolicyLister(policyIndexer),
+				urLister:   kyvernov1beta1listers.NewUpdateRequestLister(urIndexer).UpdateRequests(config.KyvernoNamespace()),
+			}
+			got, err := c.hasPrecedingMutations(tt.ur)
+			assert.NilError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func Test_handleErrPrecedingMutations(t *testing.T) {
+	c := &controller{
+		queue: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		waits: workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond, time.Millisecond),
+	}
+	defer c.queue.ShutDown()
+	key := "kyverno/ur-tenant"
+	for i := 0; i < maxWaits; i++ {
+		c.handleErr(errPrecedingMutations, key)
+	}
+	// waiting doesn't consume the retries of processing errors
+	assert.Equal(t, c.waits.NumRequeues(key), maxWaits)
+	assert.Equal(t, c.queue.NumRequeues(key), 0)
+	c.handleErr(nil, key)
+	assert.Equal(t, c.waits.NumRequeues(key), 0)
+}
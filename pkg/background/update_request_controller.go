@@ -2,6 +2,7 @@ package background
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -31,8 +32,13 @@ import (
 
 const (
 	maxRetries = 10
+	// maxWaits is the number of times an update request waits for the mutations of policies applied before
+	maxWaits = 10
 )
 
+// errPrecedingMutations is returned when an update request waits for the mutations of policies applied before
+var errPrecedingMutations = errors.New("waiting for preceding mutations")
+
 type Controller interface {
 	// Run starts workers
 	Run(context.Context, int)
@@ -55,6 +61,9 @@ type controller struct {
 
 	// queue
 	queue workqueue.RateLimitingInterface
+	// waits delays update requests waiting for preceding mutations, it counts the waits separately
+	// from the queue requeues so that waiting doesn't consume the retries of processing errors
+	waits workqueue.RateLimiter
 
 	eventGen               event.Interface
 	configuration          config.Configuration
@@ -84,6 +93,7 @@ func NewController(
 		urLister:               urLister,
 		nsLister:               namespaceInformer.Lister(),
 		queue:                  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "background"),
+		waits:                  workqueue.NewItemExponentialFailureRateLimiter(100*time.Millisecond, 10*time.Second),
 		eventGen:               eventGen,
 		configuration:          dynamicConfig,
 		informerCacheResolvers: informerCacheResolvers,
@@ -138,11 +148,19 @@ func (c *controller) processNextWorkItem() bool {
 func (c *controller) handleErr(err error, key interface{}) {
 	if err == nil {
 		c.queue.Forget(key)
+		c.waits.Forget(key)
+		return
+	}
+
+	if errors.Is(err, errPrecedingMutations) {
+		logger.V(4).Info("waiting for the mutations of policies applied before", "key", key)
+		c.queue.AddAfter(key, c.waits.When(key))
 		return
 	}
 
 	if apierrors.IsNotFound(err) {
 		c.queue.Forget(key)
+		c.waits.Forget(key)
 		logger.V(4).Info("Dropping update request from the queue", "key", key, "error", err.Error())
 		return
 	}
@@ -155,6 +173,7 @@ func (c *controller) handleErr(err error, key interface{}) {
 
 	logger.Error(err, "failed to process update request", "key", key)
 	c.queue.Forget(key)
+	c.waits.Forget(key)
 }
 
 func (c *controller) syncUpdateRequest(key string) error {
@@ -178,6 +197,18 @@ func (c *controller) syncUpdateRequest(key string) error {
 	}
 
 	if ur.Status.State == kyvernov1beta1.Pending {
+		if ur.Spec.GetRequestType() == kyvernov1beta1.Mutate {
+			// the update request doesn't wait forever, it is processed once the waits are exhausted
+			if c.waits.NumRequeues(key) < maxWaits {
+				waiting, err := c.hasPrecedingMutations(ur)
+				if err != nil {
+					return err
+				}
+				if waiting {
+					return errPrecedingMutations
+				}
+			}
+		}
 		if err := c.processUR(ur); err != nil {
 			return fmt.Errorf("failed to process UR %s: %v", key, err)
 		}
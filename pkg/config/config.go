@@ -155,6 +155,8 @@ type Configuration interface {
 	GetWebhooks() []WebhookConfig
 	// GetWebhookAnnotations returns annotations to set on webhook configs
 	GetWebhookAnnotations() map[string]string
+	// GetSecretContextNamespaces returns the namespaces secret context entries of any policy can load secrets from
+	GetSecretContextNamespaces() []string
 	// Load loads configuration from a configmap
 	Load(cm *corev1.ConfigMap)
 }
@@ -172,6 +174,7 @@ type configuration struct {
 	generateSuccessEvents         bool
 	webhooks                      []WebhookConfig
 	webhookAnnotations            map[string]string
+	secretContextNamespaces       []string
 	mux                           sync.RWMutex
 }
 
@@ -275,6 +278,12 @@ func (cd *configuration) GetWebhookAnnotations() map[string]string {
 	return cd.webhookAnnotations
 }
 
+func (cd *configuration) GetSecretContextNamespaces() []string {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.secretContextNamespaces
+}
+
 func (cd *configuration) Load(cm *corev1.ConfigMap) {
 	if cm != nil {
 		cd.load(cm)
@@ -298,6 +307,7 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 	cd.excludedClusterRoles = []string{}
 	cd.generateSuccessEvents = false
 	cd.webhooks = nil
+	cd.secretContextNamespaces = nil
 	cd.excludedGroups = append(cd.excludedGroups, defaultExcludedGroups...)
 	cd.excludedUsernames = append(cd.excludedUsernames, defaultExcludedUsernames...)
 	// load filters
@@ -389,6 +399,11 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 			cd.webhookAnnotations = webhookAnnotations
 		}
 	}
+	// load secret context namespaces
+	secretContextNamespaces, ok := cm.Data["secretContextNamespaces"]
+	if ok {
+		cd.secretContextNamespaces = parseStrings(secretContextNamespaces)
+	}
 }
 
 func (cd *configuration) unload() {
@@ -403,6 +418,7 @@ func (cd *configuration) unload() {
 	cd.excludedClusterRoles = []string{}
 	cd.generateSuccessEvents = false
 	cd.webhooks = nil
+	cd.secretContextNamespaces = nil
 	cd.webhookAnnotations = nil
 	cd.excludedGroups = append(cd.excludedGroups, defaultExcludedGroups...)
 	cd.excludedUsernames = append(cd.excludedUsernames, defaultExcludedUsernames...)
@@ -0,0 +1,141 @@
+package globalcontext
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	"github.com/kyverno/kyverno/pkg/globalcontext/externalapi"
+	"github.com/kyverno/kyverno/pkg/globalcontext/k8sresource"
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "global-context"
+	maxRetries     = 10
+)
+
+type controller struct {
+	// clients
+	client dclient.Interface
+
+	// secrets loads the credentials of external API calls
+	secrets apicall.SecretLoader
+
+	// configuration is used by the JMESPath functions applied to external API responses
+	configuration config.Configuration
+
+	// listers
+	gctxentryLister kyvernov2alpha1listers.GlobalContextEntryLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	// state
+	store       store.Store
+	lock        sync.Mutex
+	generations map[string]int64
+}
+
+func NewController(
+	gctxentryInformer kyvernov2alpha1informers.GlobalContextEntryInformer,
+	client dclient.Interface,
+	secrets apicall.SecretLoader,
+	configuration config.Configuration,
+	store store.Store,
+) controllers.Controller {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+	c := &controller{
+		client:          client,
+		secrets:         secrets,
+		configuration:   configuration,
+		gctxentryLister: gctxentryInformer.Lister(),
+		queue:           queue,
+		store:           store,
+		generations:     map[string]int64{},
+	}
+	controllerutils.AddDefaultEventHandlers(logger, gctxentryInformer.Informer(), queue)
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+// upToDate returns true if the cached entry was built from the current generation of the resource.
+func (c *controller) upToDate(name string, generation int64) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	cached, ok := c.generations[name]
+	return ok && cached == generation
+}
+
+func (c *controller) setGeneration(name string, generation int64, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if ok {
+		c.generations[name] = generation
+	} else {
+		delete(c.generations, name)
+	}
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, name string) error {
+	gctxentry, err := c.gctxentryLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			c.store.Delete(name)
+			c.setGeneration(name, 0, false)
+			return nil
+		}
+		return err
+	}
+	if c.upToDate(name, gctxentry.GetGeneration()) {
+		return nil
+	}
+	if errs := gctxentry.Validate(); len(errs) != 0 {
+		logger.Error(errs.ToAggregate(), "invalid global context entry")
+		c.store.Delete(name)
+		c.setGeneration(name, gctxentry.GetGeneration(), true)
+		return nil
+	}
+	entry, err := c.makeEntry(ctx, logger, gctxentry)
+	if err != nil {
+		return err
+	}
+	c.store.Set(name, entry)
+	c.setGeneration(name, gctxentry.GetGeneration(), true)
+	return nil
+}
+
+func (c *controller) makeEntry(ctx context.Context, logger logr.Logger, gctxentry *kyvernov2alpha1.GlobalContextEntry) (store.Entry, error) {
+	if resource := gctxentry.Spec.KubernetesResource; resource != nil {
+		selector := labels.Everything()
+		if resource.Selector != nil {
+			s, err := metav1.LabelSelectorAsSelector(resource.Selector)
+			if err != nil {
+				return nil, err
+			}
+			selector = s
+		}
+		gvr := schema.GroupVersionResource{Group: resource.Group, Version: resource.Version, Resource: resource.Resource}
+		return k8sresource.New(ctx, c.client.GetDynamicInterface(), gvr, resource.Namespace, selector), nil
+	}
+	return externalapi.New(ctx, logger, c.client, c.secrets, c.configuration, gctxentry.Name, *gctxentry.Spec.APICall), nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	policyChangesMetric "github.com/kyverno/kyverno/pkg/metrics/policychanges"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 )
@@ -27,7 +28,9 @@ func (pc *controller) registerPolicyChangesMetricUpdatePolicy(ctx context.Contex
 		logger.Error(err, "error occurred while registering kyverno_policy_changes_total metrics for the above policy's updation", "name", oldP.GetName())
 	}
 	// curP will require a new kyverno_policy_changes_total metric if the above update involved change in the following fields:
-	if curSpec.BackgroundProcessingEnabled() != oldSpec.BackgroundProcessingEnabled() || curSpec.ValidationFailureAction.Enforce() != oldSpec.ValidationFailureAction.Enforce() {
+	oldMode, _ := metrics.GetPolicyValidationMode(oldP)
+	curMode, _ := metrics.GetPolicyValidationMode(curP)
+	if curSpec.BackgroundProcessingEnabled() != oldSpec.BackgroundProcessingEnabled() || curMode != oldMode {
 		err = policyChangesMetric.RegisterPolicy(ctx, pc.metricsConfig, curP, policyChangesMetric.PolicyUpdated)
 		if err != nil {
 			logger.Error(err, "error occurred while registering kyverno_policy_changes_total metrics for the above policy's updation", "name", curP.GetName())
@@ -10,11 +10,15 @@ import (
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/controllers"
+	vapcontroller "github.com/kyverno/kyverno/pkg/controllers/validatingadmissionpolicy"
 	pcache "github.com/kyverno/kyverno/pkg/policycache"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	admissionregistrationv1alpha1informers "k8s.io/client-go/informers/admissionregistration/v1alpha1"
+	admissionregistrationv1alpha1listers "k8s.io/client-go/listers/admissionregistration/v1alpha1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 )
@@ -37,6 +41,9 @@ type controller struct {
 	// listers
 	cpolLister kyvernov1listers.ClusterPolicyLister
 	polLister  kyvernov1listers.PolicyLister
+	// vapLister and vapbindingLister are nil when ValidatingAdmissionPolicy generation is disabled
+	vapLister        admissionregistrationv1alpha1listers.ValidatingAdmissionPolicyLister
+	vapbindingLister admissionregistrationv1alpha1listers.ValidatingAdmissionPolicyBindingLister
 
 	// queue
 	queue workqueue.RateLimitingInterface
@@ -45,7 +52,16 @@ type controller struct {
 	client dclient.Interface
 }
 
-func NewController(client dclient.Interface, pcache pcache.Cache, cpolInformer kyvernov1informers.ClusterPolicyInformer, polInformer kyvernov1informers.PolicyInformer) Controller {
+// NewController creates a policy cache controller, the ValidatingAdmissionPolicy informers are nil when
+// ValidatingAdmissionPolicy generation is disabled and translated rules are never excluded from the cache.
+func NewController(
+	client dclient.Interface,
+	pcache pcache.Cache,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	polInformer kyvernov1informers.PolicyInformer,
+	vapInformer admissionregistrationv1alpha1informers.ValidatingAdmissionPolicyInformer,
+	vapbindingInformer admissionregistrationv1alpha1informers.ValidatingAdmissionPolicyBindingInformer,
+) Controller {
 	c := controller{
 		cache:      pcache,
 		cpolLister: cpolInformer.Lister(),
@@ -55,6 +71,22 @@ func NewController(client dclient.Interface, pcache pcache.Cache, cpolInformer k
 	}
 	controllerutils.AddDefaultEventHandlers(logger, cpolInformer.Informer(), c.queue)
 	controllerutils.AddDefaultEventHandlers(logger, polInformer.Informer(), c.queue)
+	if vapInformer != nil && vapbindingInformer != nil {
+		c.vapLister = vapInformer.Lister()
+		c.vapbindingLister = vapbindingInformer.Lister()
+		controllerutils.AddEventHandlersT(
+			vapInformer.Informer(),
+			func(obj *admissionregistrationv1alpha1.ValidatingAdmissionPolicy) { c.enqueuePolicy(obj) },
+			func(_, obj *admissionregistrationv1alpha1.ValidatingAdmissionPolicy) { c.enqueuePolicy(obj) },
+			func(obj *admissionregistrationv1alpha1.ValidatingAdmissionPolicy) { c.enqueuePolicy(obj) },
+		)
+		controllerutils.AddEventHandlersT(
+			vapbindingInformer.Informer(),
+			func(obj *admissionregistrationv1alpha1.ValidatingAdmissionPolicyBinding) { c.enqueuePolicy(obj) },
+			func(_, obj *admissionregistrationv1alpha1.ValidatingAdmissionPolicyBinding) { c.enqueuePolicy(obj) },
+			func(obj *admissionregistrationv1alpha1.ValidatingAdmissionPolicyBinding) { c.enqueuePolicy(obj) },
+		)
+	}
 	return &c
 }
 
@@ -81,7 +113,7 @@ func (c *controller) WarmUp() error {
 		if key, err := cache.MetaNamespaceKeyFunc(policy); err != nil {
 			return err
 		} else {
-			return c.cache.Set(key, policy, c.client.Discovery())
+			return c.cache.Set(key, vapcontroller.ExcludeTranslatedRules(policy, c.vapLister, c.vapbindingLister), c.client.Discovery())
 		}
 	}
 	return nil
@@ -99,7 +131,15 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 		}
 		return err
 	}
-	return c.cache.Set(key, policy, c.client.Discovery())
+	return c.cache.Set(key, vapcontroller.ExcludeTranslatedRules(policy, c.vapLister, c.vapbindingLister), c.client.Discovery())
+}
+
+// enqueuePolicy enqueues the cluster policy a generated ValidatingAdmissionPolicy or binding belongs to,
+// the rules enforced by the webhook depend on the generated resources.
+func (c *controller) enqueuePolicy(obj metav1.Object) {
+	if name := vapcontroller.PolicyKey(obj); name != "" {
+		c.queue.Add(name)
+	}
 }
 
 func (c *controller) loadPolicy(namespace, name string) (kyvernov1.PolicyInterface, error) {
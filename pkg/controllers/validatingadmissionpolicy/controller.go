@@ -0,0 +1,219 @@
+package validatingadmissionpolicy
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	admissionregistrationv1alpha1informers "k8s.io/client-go/informers/admissionregistration/v1alpha1"
+	"k8s.io/client-go/kubernetes"
+	admissionregistrationv1alpha1listers "k8s.io/client-go/listers/admissionregistration/v1alpha1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 2
+	ControllerName = "validatingadmissionpolicy-controller"
+	maxRetries     = 10
+	// policyNameLabel references the policy a resource was generated from
+	policyNameLabel = "validatingadmissionpolicy.kyverno.io/policy-name"
+)
+
+type controller struct {
+	// clients
+	client          kubernetes.Interface
+	kyvernoClient   versioned.Interface
+	discoveryClient resourceFinder
+
+	// listers
+	cpolLister       kyvernov1listers.ClusterPolicyLister
+	vapLister        admissionregistrationv1alpha1listers.ValidatingAdmissionPolicyLister
+	vapbindingLister admissionregistrationv1alpha1listers.ValidatingAdmissionPolicyBindingLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+}
+
+func NewController(
+	client kubernetes.Interface,
+	kyvernoClient versioned.Interface,
+	discoveryClient dclient.IDiscovery,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	vapInformer admissionregistrationv1alpha1informers.ValidatingAdmissionPolicyInformer,
+	vapbindingInformer admissionregistrationv1alpha1informers.ValidatingAdmissionPolicyBindingInformer,
+) controllers.Controller {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+	c := &controller{
+		client:           client,
+		kyvernoClient:    kyvernoClient,
+		discoveryClient:  discoveryClient,
+		cpolLister:       cpolInformer.Lister(),
+		vapLister:        vapInformer.Lister(),
+		vapbindingLister: vapbindingInformer.Lister(),
+		queue:            queue,
+	}
+	controllerutils.AddDefaultEventHandlers(logger, cpolInformer.Informer(), queue)
+	controllerutils.AddEventHandlersT(
+		vapInformer.Informer(),
+		func(obj *admissionregistrationv1alpha1.ValidatingAdmissionPolicy) { c.enqueuePolicy(obj) },
+		func(_, obj *admissionregistrationv1alpha1.ValidatingAdmissionPolicy) { c.enqueuePolicy(obj) },
+		func(obj *admissionregistrationv1alpha1.ValidatingAdmissionPolicy) { c.enqueuePolicy(obj) },
+	)
+	controllerutils.AddEventHandlersT(
+		vapbindingInformer.Informer(),
+		func(obj *admissionregistrationv1alpha1.ValidatingAdmissionPolicyBinding) { c.enqueuePolicy(obj) },
+		func(_, obj *admissionregistrationv1alpha1.ValidatingAdmissionPolicyBinding) { c.enqueuePolicy(obj) },
+		func(obj *admissionregistrationv1alpha1.ValidatingAdmissionPolicyBinding) { c.enqueuePolicy(obj) },
+	)
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger.V(3), ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+// enqueuePolicy enqueues the policy a generated resource belongs to.
+func (c *controller) enqueuePolicy(obj metav1.Object) {
+	if name := PolicyKey(obj); name != "" {
+		c.queue.Add(name)
+	}
+}
+
+func (c *controller) getGeneratedPolicies(name string) ([]*admissionregistrationv1alpha1.ValidatingAdmissionPolicy, error) {
+	return c.vapLister.List(labels.SelectorFromSet(labels.Set{policyNameLabel: name}))
+}
+
+func (c *controller) getGeneratedBindings(name string) ([]*admissionregistrationv1alpha1.ValidatingAdmissionPolicyBinding, error) {
+	return c.vapbindingLister.List(labels.SelectorFromSet(labels.Set{policyNameLabel: name}))
+}
+
+func (c *controller) cleanup(ctx context.Context, name string, vaps []*admissionregistrationv1alpha1.ValidatingAdmissionPolicy, bindings []*admissionregistrationv1alpha1.ValidatingAdmissionPolicyBinding) error {
+	observedBindings, err := c.getGeneratedBindings(name)
+	if err != nil {
+		return err
+	}
+	if err := controllerutils.Cleanup(ctx, observedBindings, bindings, c.client.AdmissionregistrationV1alpha1().ValidatingAdmissionPolicyBindings()); err != nil {
+		return err
+	}
+	observedPolicies, err := c.getGeneratedPolicies(name)
+	if err != nil {
+		return err
+	}
+	return controllerutils.Cleanup(ctx, observedPolicies, vaps, c.client.AdmissionregistrationV1alpha1().ValidatingAdmissionPolicies())
+}
+
+func (c *controller) buildMetadata(obj metav1.Object, policy kyvernov1.PolicyInterface) {
+	controllerutils.SetManagedByKyvernoLabel(obj)
+	controllerutils.SetLabel(obj, policyNameLabel, policy.GetName())
+	// TODO: find a better way to do that, it looks like resources returned by WATCH don't have the GVK
+	controllerutils.SetOwner(obj, "kyverno.io/v1", "ClusterPolicy", policy.GetName(), policy.GetUID())
+}
+
+func (c *controller) updateStatus(ctx context.Context, policy *kyvernov1.ClusterPolicy, status *kyvernov1.ValidatingAdmissionPolicyStatus) error {
+	_, err := controllerutils.UpdateStatus(
+		ctx,
+		policy,
+		c.kyvernoClient.KyvernoV1().ClusterPolicies(),
+		func(policy *kyvernov1.ClusterPolicy) error {
+			policy.Status.ValidatingAdmissionPolicy = status
+			return nil
+		},
+	)
+	return err
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	policy, err := c.cpolLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return c.cleanup(ctx, name, nil, nil)
+		}
+		return err
+	}
+	if !isEnabled(policy) {
+		if err := c.cleanup(ctx, name, nil, nil); err != nil {
+			return err
+		}
+		return c.updateStatus(ctx, policy, nil)
+	}
+	spec := policy.GetSpec()
+	status := &kyvernov1.ValidatingAdmissionPolicyStatus{ObservedGeneration: policy.GetGeneration()}
+	var vaps []*admissionregistrationv1alpha1.ValidatingAdmissionPolicy
+	var bindings []*admissionregistrationv1alpha1.ValidatingAdmissionPolicyBinding
+	for _, rule := range autogen.ComputeRules(policy) {
+		if !rule.HasValidate() {
+			continue
+		}
+		ruleStatus := kyvernov1.ValidatingAdmissionPolicyRuleStatus{Name: rule.Name}
+		if err := checkValidationFailureAction(spec, rule); err != nil {
+			ruleStatus.Message = err.Error()
+			status.Rules = append(status.Rules, ruleStatus)
+			continue
+		}
+		vapName, err := policyName(policy, rule)
+		if err != nil {
+			ruleStatus.Message = err.Error()
+			status.Rules = append(status.Rules, ruleStatus)
+			continue
+		}
+		vapSpec, err := translateRule(c.discoveryClient, spec, rule)
+		if err != nil {
+			logger.V(3).Info("rule can not be translated", "rule", rule.Name, "reason", err.Error())
+			ruleStatus.Message = err.Error()
+			status.Rules = append(status.Rules, ruleStatus)
+			continue
+		}
+		vap, err := controllerutils.CreateOrUpdate(
+			ctx,
+			vapName,
+			c.vapLister,
+			c.client.AdmissionregistrationV1alpha1().ValidatingAdmissionPolicies(),
+			func(vap *admissionregistrationv1alpha1.ValidatingAdmissionPolicy) error {
+				c.buildMetadata(vap, policy)
+				vap.Spec = *vapSpec
+				return nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+		binding, err := controllerutils.CreateOrUpdate(
+			ctx,
+			vapName,
+			c.vapbindingLister,
+			c.client.AdmissionregistrationV1alpha1().ValidatingAdmissionPolicyBindings(),
+			func(binding *admissionregistrationv1alpha1.ValidatingAdmissionPolicyBinding) error {
+				c.buildMetadata(binding, policy)
+				binding.Spec = admissionregistrationv1alpha1.ValidatingAdmissionPolicyBindingSpec{
+					PolicyName:     vapName,
+					MatchResources: vapSpec.MatchConstraints.DeepCopy(),
+				}
+				return nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+		vaps = append(vaps, vap)
+		bindings = append(bindings, binding)
+		ruleStatus.Translated = true
+		status.Rules = append(status.Rules, ruleStatus)
+	}
+	if err := c.cleanup(ctx, name, vaps, bindings); err != nil {
+		return err
+	}
+	return c.updateStatus(ctx, policy, status)
+}
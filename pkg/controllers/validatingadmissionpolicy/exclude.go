@@ -0,0 +1,84 @@
+package validatingadmissionpolicy
+
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	admissionregistrationv1alpha1listers "k8s.io/client-go/listers/admissionregistration/v1alpha1"
+)
+
+// PolicyKey returns the key of the policy a generated ValidatingAdmissionPolicy or binding belongs to,
+// it returns an empty string if the resource was not generated by kyverno.
+func PolicyKey(obj metav1.Object) string {
+	if !controllerutils.IsManagedByKyverno(obj) {
+		return ""
+	}
+	return controllerutils.GetLabel(obj, policyNameLabel)
+}
+
+// ExcludeTranslatedRules removes the validate rules enforced by the generated ValidatingAdmissionPolicies.
+// A rule is removed only if the status was computed from the current policy generation, the rule was translated
+// together with the rules generated from it for pod controllers, and all the generated policies and bindings exist.
+// In any other case the rule is kept and enforced by the admission webhook.
+func ExcludeTranslatedRules(
+	policy kyvernov1.PolicyInterface,
+	vapLister admissionregistrationv1alpha1listers.ValidatingAdmissionPolicyLister,
+	vapbindingLister admissionregistrationv1alpha1listers.ValidatingAdmissionPolicyBindingLister,
+) kyvernov1.PolicyInterface {
+	if vapLister == nil || vapbindingLister == nil || policy.IsNamespaced() || !isEnabled(policy) {
+		return policy
+	}
+	status := policy.GetStatus().ValidatingAdmissionPolicy
+	if status == nil || len(status.Rules) == 0 || status.ObservedGeneration != policy.GetGeneration() {
+		return policy
+	}
+	enforced := sets.New[string]()
+	notEnforced := sets.New[string]()
+	for _, rule := range status.Rules {
+		if rule.Translated && isGenerated(policy, rule.Name, vapLister, vapbindingLister) {
+			enforced.Insert(rule.Name)
+		} else {
+			notEnforced.Insert(rule.Name)
+		}
+	}
+	var rules []kyvernov1.Rule
+	for _, rule := range policy.GetSpec().Rules {
+		if !enforced.Has(rule.Name) || notEnforced.HasAny(autogen.GetAutogenRuleNames(rule.Name)...) {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == len(policy.GetSpec().Rules) {
+		return policy
+	}
+	policy = policy.CreateDeepCopy()
+	policy.GetSpec().Rules = rules
+	return policy
+}
+
+// isGenerated checks that the ValidatingAdmissionPolicy and binding generated for a rule exist.
+func isGenerated(
+	policy kyvernov1.PolicyInterface,
+	rule string,
+	vapLister admissionregistrationv1alpha1listers.ValidatingAdmissionPolicyLister,
+	vapbindingLister admissionregistrationv1alpha1listers.ValidatingAdmissionPolicyBindingLister,
+) bool {
+	name := policy.GetName() + "-" + rule
+	vap, err := vapLister.Get(name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "failed to get validating admission policy", "name", name)
+		}
+		return false
+	}
+	binding, err := vapbindingLister.Get(name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "failed to get validating admission policy binding", "name", name)
+		}
+		return false
+	}
+	return PolicyKey(vap) == policy.GetName() && PolicyKey(binding) == policy.GetName()
+}
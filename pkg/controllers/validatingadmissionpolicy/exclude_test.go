@@ -0,0 +1,77 @@
+package validatingadmissionpolicy
+
+import (
+	"encoding/json"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
+	admissionregistrationv1alpha1listers "k8s.io/client-go/listers/admissionregistration/v1alpha1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func Test_ExcludeTranslatedRules(t *testing.T) {
+	rawPolicy := []byte(`{
+		"metadata": {
+		  "name": "disallow-host-network",
+		  "generation": 2,
+		  "annotations": {"kyverno.io/generate-validating-admission-policy": "true"}
+		},
+		"spec": {
+		  "validationFailureAction": "Enforce",
+		  "rules": [
+			{
+			  "name": "host-network",
+			  "match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			  "validate": {"cel": {"expressions": [{"expression": "!has(object.spec.hostNetwork) || !object.spec.hostNetwork"}]}}
+			}
+		  ]
+		},
+		"status": {
+		  "validatingadmissionpolicy": {
+			"observedGeneration": 2,
+			"rules": [
+			  {"name": "host-network", "translated": true},
+			  {"name": "autogen-host-network", "translated": true},
+			  {"name": "autogen-cronjob-host-network", "translated": true}
+			]
+		  }
+		}
+	  }`)
+	var policy *kyvernov1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+	vapIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	vapbindingIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	vapLister := admissionregistrationv1alpha1listers.NewValidatingAdmissionPolicyLister(vapIndexer)
+	vapbindingLister := admissionregistrationv1alpha1listers.NewValidatingAdmissionPolicyBindingLister(vapbindingIndexer)
+	c := &controller{}
+	for _, rule := range policy.Status.ValidatingAdmissionPolicy.Rules {
+		vap := &admissionregistrationv1alpha1.ValidatingAdmissionPolicy{}
+		vap.Name = policy.Name + "-" + rule.Name
+		c.buildMetadata(vap, policy)
+		assert.NilError(t, vapIndexer.Add(vap))
+		binding := &admissionregistrationv1alpha1.ValidatingAdmissionPolicyBinding{}
+		binding.Name = vap.Name
+		c.buildMetadata(binding, policy)
+		assert.NilError(t, vapbindingIndexer.Add(binding))
+	}
+	// translated rules are enforced by the ValidatingAdmissionPolicy
+	assert.Equal(t, len(ExcludeTranslatedRules(policy, vapLister, vapbindingLister).GetSpec().Rules), 0)
+	// the original policy is not modified
+	assert.Equal(t, len(policy.Spec.Rules), 1)
+	// rules are kept when ValidatingAdmissionPolicy generation is disabled
+	assert.Equal(t, len(ExcludeTranslatedRules(policy, nil, nil).GetSpec().Rules), 1)
+	// rules are kept when the status was computed from a previous generation
+	updated := policy.DeepCopy()
+	updated.Generation = 3
+	assert.Equal(t, len(ExcludeTranslatedRules(updated, vapLister, vapbindingLister).GetSpec().Rules), 1)
+	// rules are kept when a generated binding doesn't exist
+	binding, _ := vapbindingLister.Get(policy.Name + "-autogen-cronjob-host-network")
+	assert.NilError(t, vapbindingIndexer.Delete(binding))
+	assert.Equal(t, len(ExcludeTranslatedRules(policy, vapLister, vapbindingLister).GetSpec().Rules), 1)
+	assert.NilError(t, vapbindingIndexer.Add(binding))
+	// the rule is kept if a rule generated from it was not translated
+	policy.Status.ValidatingAdmissionPolicy.Rules[2].Translated = false
+	assert.Equal(t, len(ExcludeTranslatedRules(policy, vapLister, vapbindingLister).GetSpec().Rules), 1)
+}
@@ -0,0 +1,513 @@
+package validatingadmissionpolicy
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
+	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+type resourceFinder interface {
+	FindResources(group, version, kind, subresource string) (map[dclient.TopLevelApiDescription]metav1.APIResource, error)
+}
+
+var (
+	identifier   = regexp.MustCompile(`^[_a-zA-Z][_a-zA-Z0-9]*$`)
+	keyVariable  = regexp.MustCompile(`^\{\{\s*(.*?)\s*\}\}$`)
+	celReserved  = sets.New("as", "break", "const", "continue", "else", "false", "for", "function", "if", "import", "in", "let", "loop", "package", "namespace", "null", "return", "true", "var", "void")
+	requestPaths = sets.New("operation", "name", "userInfo", "kind", "resource", "subResource")
+)
+
+// isEnabled checks if a policy opted in ValidatingAdmissionPolicy generation.
+func isEnabled(policy kyvernov1.PolicyInterface) bool {
+	return policy.GetAnnotations()[kyvernov1.AnnotationGenerateValidatingAdmissionPolicy] == "true"
+}
+
+// checkValidationFailureAction checks if the validation failure action of a rule can be represented by a ValidatingAdmissionPolicy.
+func checkValidationFailureAction(spec *kyvernov1.Spec, rule kyvernov1.Rule) error {
+	action, overrides := rule.GetValidationFailureAction(spec)
+	if !action.Enforce() {
+		return errors.New("only rules in Enforce mode can be translated")
+	}
+	if len(overrides) != 0 {
+		return errors.New("validationFailureActionOverrides are not supported")
+	}
+	return nil
+}
+
+// policyName returns the name of the ValidatingAdmissionPolicy generated for a rule.
+func policyName(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) (string, error) {
+	name := policy.GetName() + "-" + rule.Name
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) != 0 {
+		return "", fmt.Errorf("rule name can not be used to generate a resource name: %s", strings.Join(errs, ", "))
+	}
+	return name, nil
+}
+
+// translateRule builds the ValidatingAdmissionPolicy spec corresponding to a validate rule,
+// the returned error explains why the rule could not be translated.
+func translateRule(finder resourceFinder, spec *kyvernov1.Spec, rule kyvernov1.Rule) (*admissionregistrationv1alpha1.ValidatingAdmissionPolicySpec, error) {
+	if len(spec.Context) != 0 || len(rule.Context) != 0 {
+		return nil, errors.New("context entries are not supported")
+	}
+	if rule.GetAnyAllConditions() != nil {
+		return nil, errors.New("preconditions are not supported")
+	}
+	if !isEmptyMatch(rule.ExcludeResources) {
+		return nil, errors.New("exclude is not supported")
+	}
+	validations, err := translateValidations(rule)
+	if err != nil {
+		return nil, err
+	}
+	match, err := translateMatch(finder, rule.MatchResources)
+	if err != nil {
+		return nil, err
+	}
+	failurePolicy := admissionregistrationv1alpha1.Fail
+	if spec.GetFailurePolicy() == kyvernov1.Ignore {
+		failurePolicy = admissionregistrationv1alpha1.Ignore
+	}
+	return &admissionregistrationv1alpha1.ValidatingAdmissionPolicySpec{
+		MatchConstraints: match,
+		Validations:      validations,
+		FailurePolicy:    &failurePolicy,
+	}, nil
+}
+
+func isEmptyMatch(match kyvernov1.MatchResources) bool {
+	return len(match.Any) == 0 && len(match.All) == 0 && match.UserInfo.IsEmpty() && match.ResourceDescription.IsEmpty()
+}
+
+func translateValidations(rule kyvernov1.Rule) ([]admissionregistrationv1alpha1.Validation, error) {
+	if rule.HasValidateCEL() {
+		if rule.Validation.CEL.RawParams != nil {
+			return nil, errors.New("cel params are not supported")
+		}
+		var validations []admissionregistrationv1alpha1.Validation
+		for _, expression := range rule.Validation.CEL.Expressions {
+			message := expression.Message
+			if message == "" {
+				message = rule.Validation.Message
+			}
+			if err := checkMessage(message); err != nil {
+				return nil, err
+			}
+			validations = append(validations, admissionregistrationv1alpha1.Validation{
+				Expression: expression.Expression,
+				Message:    message,
+			})
+		}
+		return validations, nil
+	}
+	if rule.Validation.Deny != nil {
+		if err := checkMessage(rule.Validation.Message); err != nil {
+			return nil, err
+		}
+		expression, err := translateDeny(rule.Validation.Deny)
+		if err != nil {
+			return nil, err
+		}
+		return []admissionregistrationv1alpha1.Validation{{
+			Expression: expression,
+			Message:    rule.Validation.Message,
+		}}, nil
+	}
+	return nil, errors.New("only cel and deny validations are supported")
+}
+
+func checkMessage(message string) error {
+	if regex.RegexVariables.MatchString(message) {
+		return errors.New("variables in messages are not supported")
+	}
+	return nil
+}
+
+// translateDeny builds a CEL expression that evaluates to false when the deny conditions are met.
+func translateDeny(deny *kyvernov1.Deny) (string, error) {
+	conditions, err := apiutils.ApiextensionsJsonToKyvernoConditions(deny.GetAnyAllConditions())
+	if err != nil {
+		return "", err
+	}
+	var parts []string
+	switch typed := conditions.(type) {
+	case kyvernov1.AnyAllConditions:
+		if len(typed.AnyConditions) != 0 {
+			expression, err := translateConditions(typed.AnyConditions, " || ")
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, expression)
+		}
+		if len(typed.AllConditions) != 0 {
+			expression, err := translateConditions(typed.AllConditions, " && ")
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, expression)
+		}
+	case []kyvernov1.Condition:
+		if len(typed) != 0 {
+			expression, err := translateConditions(typed, " && ")
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, expression)
+		}
+	}
+	if len(parts) == 0 {
+		return "", errors.New("deny without conditions is not supported")
+	}
+	return "!(" + strings.Join(parts, " && ") + ")", nil
+}
+
+func translateConditions(conditions []kyvernov1.Condition, separator string) (string, error) {
+	var expressions []string
+	for _, condition := range conditions {
+		expression, err := translateCondition(condition)
+		if err != nil {
+			return "", err
+		}
+		expressions = append(expressions, "("+expression+")")
+	}
+	return "(" + strings.Join(expressions, separator) + ")", nil
+}
+
+func translateCondition(condition kyvernov1.Condition) (string, error) {
+	rawKey, ok := condition.GetKey().(string)
+	if !ok {
+		return "", errors.New("condition keys must be a variable")
+	}
+	key, err := translateKey(rawKey)
+	if err != nil {
+		return "", err
+	}
+	value := condition.GetValue()
+	if err := regex.ObjectHasVariables(value); err != nil {
+		return "", errors.New("variables in condition values are not supported")
+	}
+	switch condition.Operator {
+	case kyvernov1.ConditionOperators["Equal"], kyvernov1.ConditionOperators["Equals"]:
+		literal, err := scalarLiteral(value)
+		if err != nil {
+			return "", err
+		}
+		return key + " == " + literal, nil
+	case kyvernov1.ConditionOperators["NotEqual"], kyvernov1.ConditionOperators["NotEquals"]:
+		literal, err := scalarLiteral(value)
+		if err != nil {
+			return "", err
+		}
+		return key + " != " + literal, nil
+	case kyvernov1.ConditionOperators["In"], kyvernov1.ConditionOperators["AllIn"]:
+		return membershipCondition(key, value, "all", false)
+	case kyvernov1.ConditionOperators["AnyIn"]:
+		return membershipCondition(key, value, "exists", false)
+	case kyvernov1.ConditionOperators["NotIn"], kyvernov1.ConditionOperators["AnyNotIn"]:
+		return membershipCondition(key, value, "exists", true)
+	case kyvernov1.ConditionOperators["AllNotIn"]:
+		return membershipCondition(key, value, "all", true)
+	case kyvernov1.ConditionOperators["GreaterThan"]:
+		return numericCondition(key, ">", value)
+	case kyvernov1.ConditionOperators["GreaterThanOrEquals"]:
+		return numericCondition(key, ">=", value)
+	case kyvernov1.ConditionOperators["LessThan"]:
+		return numericCondition(key, "<", value)
+	case kyvernov1.ConditionOperators["LessThanOrEquals"]:
+		return numericCondition(key, "<=", value)
+	}
+	return "", fmt.Errorf("operator %s is not supported", condition.Operator)
+}
+
+// translateKey converts a condition key referencing the admission request into a CEL path,
+// only plain field selections are supported.
+func translateKey(key string) (string, error) {
+	matches := keyVariable.FindStringSubmatch(key)
+	if matches == nil {
+		return "", fmt.Errorf("condition key %s must be a single variable", key)
+	}
+	segments, err := splitPath(matches[1])
+	if err != nil {
+		return "", err
+	}
+	if len(segments) < 2 || segments[0] != "request" {
+		return "", fmt.Errorf("condition key %s must reference the admission request", key)
+	}
+	var path string
+	switch segments[1] {
+	case "object", "oldObject":
+		path = segments[1]
+	default:
+		if !requestPaths.Has(segments[1]) {
+			return "", fmt.Errorf("condition key %s is not supported", key)
+		}
+		path = "request." + segments[1]
+	}
+	for _, segment := range segments[2:] {
+		if identifier.MatchString(segment) && !celReserved.Has(segment) {
+			path += "." + segment
+		} else {
+			path += "[" + strconv.Quote(segment) + "]"
+		}
+	}
+	return path, nil
+}
+
+// splitPath splits a JMESPath field selection expression, quoted identifiers are supported.
+func splitPath(path string) ([]string, error) {
+	var segments []string
+	for len(path) > 0 {
+		var segment string
+		if path[0] == '"' {
+			end := strings.Index(path[1:], `"`)
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %s", path)
+			}
+			segment, path = path[1:end+1], path[end+2:]
+		} else {
+			end := strings.Index(path, ".")
+			if end < 0 {
+				end = len(path)
+			}
+			segment, path = path[:end], path[end:]
+			if !identifier.MatchString(segment) {
+				return nil, fmt.Errorf("invalid path segment %s", segment)
+			}
+		}
+		segments = append(segments, segment)
+		if len(path) > 0 {
+			if path[0] != '.' || len(path) == 1 {
+				return nil, fmt.Errorf("invalid path %s", path)
+			}
+			path = path[1:]
+		}
+	}
+	return segments, nil
+}
+
+func numericCondition(key, operator string, value interface{}) (string, error) {
+	switch value.(type) {
+	case float64, int64:
+		literal, err := scalarLiteral(value)
+		if err != nil {
+			return "", err
+		}
+		return key + " " + operator + " " + literal, nil
+	}
+	return "", fmt.Errorf("operator %s is only supported with numeric values", operator)
+}
+
+func scalarLiteral(value interface{}) (string, error) {
+	switch typed := value.(type) {
+	case nil:
+		return "null", nil
+	case bool:
+		return strconv.FormatBool(typed), nil
+	case string:
+		if strings.ContainsAny(typed, "*?") {
+			return "", errors.New("wildcards in condition values are not supported")
+		}
+		return strconv.Quote(typed), nil
+	case int64:
+		return strconv.FormatInt(typed, 10), nil
+	case float64:
+		if typed == float64(int64(typed)) {
+			return strconv.FormatInt(int64(typed), 10), nil
+		}
+		return strconv.FormatFloat(typed, 'f', -1, 64), nil
+	}
+	return "", fmt.Errorf("condition value of type %T is not supported", value)
+}
+
+// membershipCondition translates the In family of operators. A key resolving to a list is checked element by element,
+// with the all or exists macro depending on the operator, as Kyverno compares sets for list keys.
+func membershipCondition(key string, value interface{}, macro string, negate bool) (string, error) {
+	literal, err := listLiteral(value)
+	if err != nil {
+		return "", err
+	}
+	scalar := key + " in " + literal
+	element := "k in " + literal
+	if negate {
+		scalar = "!(" + scalar + ")"
+		element = "!(" + element + ")"
+	}
+	return "type(" + key + ") == list ? " + key + "." + macro + "(k, " + element + ") : " + scalar, nil
+}
+
+func listLiteral(value interface{}) (string, error) {
+	list, ok := value.([]interface{})
+	if !ok {
+		return "", errors.New("condition value must be a list")
+	}
+	var literals []string
+	for _, item := range list {
+		literal, err := scalarLiteral(item)
+		if err != nil {
+			return "", err
+		}
+		literals = append(literals, literal)
+	}
+	return "[" + strings.Join(literals, ", ") + "]", nil
+}
+
+// translateMatch converts the match block of a rule into ValidatingAdmissionPolicy match constraints.
+func translateMatch(finder resourceFinder, match kyvernov1.MatchResources) (*admissionregistrationv1alpha1.MatchResources, error) {
+	var filters kyvernov1.ResourceFilters
+	if len(match.Any) != 0 {
+		filters = match.Any
+	} else if len(match.All) > 1 {
+		return nil, errors.New("match.all with more than one filter is not supported")
+	} else if len(match.All) == 1 {
+		filters = match.All
+	} else {
+		filters = kyvernov1.ResourceFilters{{UserInfo: match.UserInfo, ResourceDescription: match.ResourceDescription}}
+	}
+	var result *admissionregistrationv1alpha1.MatchResources
+	for _, filter := range filters {
+		translated, err := translateFilter(finder, filter)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = translated
+		} else {
+			if !datautils.DeepEqual(result.NamespaceSelector, translated.NamespaceSelector) || !datautils.DeepEqual(result.ObjectSelector, translated.ObjectSelector) {
+				return nil, errors.New("match filters with different selectors are not supported")
+			}
+			result.ResourceRules = append(result.ResourceRules, translated.ResourceRules...)
+		}
+	}
+	return result, nil
+}
+
+func translateFilter(finder resourceFinder, filter kyvernov1.ResourceFilter) (*admissionregistrationv1alpha1.MatchResources, error) {
+	if !filter.UserInfo.IsEmpty() {
+		return nil, errors.New("userInfo is not supported")
+	}
+	description := filter.ResourceDescription
+	if len(description.Kinds) == 0 {
+		return nil, errors.New("match must specify kinds")
+	}
+	if len(description.Annotations) != 0 {
+		return nil, errors.New("annotations are not supported")
+	}
+	var names []string
+	if description.Name != "" {
+		names = append(names, description.Name)
+	}
+	names = append(names, description.Names...)
+	for _, name := range names {
+		if strings.ContainsAny(name, "*?") {
+			return nil, errors.New("wildcards in names are not supported")
+		}
+	}
+	namespaceSelector := &metav1.LabelSelector{}
+	if description.NamespaceSelector != nil {
+		namespaceSelector = description.NamespaceSelector.DeepCopy()
+	}
+	if len(description.Namespaces) != 0 {
+		for _, namespace := range description.Namespaces {
+			if strings.ContainsAny(namespace, "*?") {
+				return nil, errors.New("wildcards in namespaces are not supported")
+			}
+		}
+		namespaceSelector.MatchExpressions = append(namespaceSelector.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      "kubernetes.io/metadata.name",
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   description.Namespaces,
+		})
+	}
+	objectSelector := &metav1.LabelSelector{}
+	if description.Selector != nil {
+		for _, value := range description.Selector.MatchLabels {
+			if strings.ContainsAny(value, "*?") {
+				return nil, errors.New("wildcards in label selectors are not supported")
+			}
+		}
+		objectSelector = description.Selector.DeepCopy()
+	}
+	operations := []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update}
+	if len(description.Operations) != 0 {
+		operations = nil
+		for _, operation := range description.Operations {
+			operations = append(operations, admissionregistrationv1.OperationType(operation))
+		}
+	}
+	var resourceRules []admissionregistrationv1alpha1.NamedRuleWithOperations
+	for _, kind := range description.Kinds {
+		rules, err := translateKind(finder, kind)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			resourceRules = append(resourceRules, admissionregistrationv1alpha1.NamedRuleWithOperations{
+				ResourceNames: names,
+				RuleWithOperations: admissionregistrationv1alpha1.RuleWithOperations{
+					Operations: operations,
+					Rule:       rule,
+				},
+			})
+		}
+	}
+	matchPolicy := admissionregistrationv1alpha1.Equivalent
+	return &admissionregistrationv1alpha1.MatchResources{
+		NamespaceSelector: namespaceSelector,
+		ObjectSelector:    objectSelector,
+		ResourceRules:     resourceRules,
+		MatchPolicy:       &matchPolicy,
+	}, nil
+}
+
+func translateKind(finder resourceFinder, selector string) ([]admissionregistrationv1alpha1.Rule, error) {
+	scope := admissionregistrationv1.AllScopes
+	group, version, kind, subresource := kubeutils.ParseKindSelector(selector)
+	if kind == "*" {
+		resource := "*"
+		if subresource != "" {
+			resource = "*/" + subresource
+		}
+		return []admissionregistrationv1alpha1.Rule{{
+			APIGroups:   []string{group},
+			APIVersions: []string{version},
+			Resources:   []string{resource},
+			Scope:       &scope,
+		}}, nil
+	}
+	gvrss, err := finder.FindResources(group, version, kind, subresource)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find resource %s: %w", selector, err)
+	}
+	gvrsList := make([]dclient.TopLevelApiDescription, 0, len(gvrss))
+	for gvrs := range gvrss {
+		gvrsList = append(gvrsList, gvrs)
+	}
+	// sort resources to produce a stable output
+	sort.Slice(gvrsList, func(i, j int) bool {
+		return gvrsList[i].GroupVersion.String()+"/"+gvrsList[i].ResourceSubresource() < gvrsList[j].GroupVersion.String()+"/"+gvrsList[j].ResourceSubresource()
+	})
+	var rules []admissionregistrationv1alpha1.Rule
+	for _, gvrs := range gvrsList {
+		rules = append(rules, admissionregistrationv1alpha1.Rule{
+			APIGroups:   []string{gvrs.Group},
+			APIVersions: []string{gvrs.Version},
+			Resources:   []string{gvrs.ResourceSubresource()},
+			Scope:       &scope,
+		})
+	}
+	return rules, nil
+}
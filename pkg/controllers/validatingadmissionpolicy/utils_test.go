@@ -0,0 +1,213 @@
+package validatingadmissionpolicy
+
+import (
+	"encoding/json"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/policycache"
+	"gotest.tools/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_translateKey(t *testing.T) {
+	testCases := []struct {
+		key      string
+		expected string
+		wantErr  bool
+	}{
+		{key: "{{ request.object.spec.replicas }}", expected: "object.spec.replicas"},
+		{key: "{{request.oldObject.metadata.name}}", expected: "oldObject.metadata.name"},
+		{key: `{{ request.object.metadata.labels."app.kubernetes.io/name" }}`, expected: `object.metadata.labels["app.kubernetes.io/name"]`},
+		{key: "{{ request.object.metadata.namespace }}", expected: `object.metadata["namespace"]`},
+		{key: "{{ request.operation }}", expected: "request.operation"},
+		{key: "{{ request.roles }}", wantErr: true},
+		{key: "{{ request.object.spec.containers[].name }}", wantErr: true},
+		{key: "{{ length(request.object.spec.containers) }}", wantErr: true},
+		{key: "{{ serviceAccountName }}", wantErr: true},
+		{key: "request.object.spec", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			actual, err := translateKey(tc.key)
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+				assert.Equal(t, actual, tc.expected)
+			}
+		})
+	}
+}
+
+func Test_translateDeny(t *testing.T) {
+	testCases := []struct {
+		name       string
+		conditions string
+		expected   string
+		wantErr    bool
+	}{
+		{
+			name:       "any",
+			conditions: `{"any":[{"key":"{{ request.object.spec.replicas }}","operator":"GreaterThan","value":3},{"key":"{{ request.object.spec.paused }}","operator":"Equals","value":true}]}`,
+			expected:   "!(((object.spec.replicas > 3) || (object.spec.paused == true)))",
+		},
+		{
+			name:       "any and all",
+			conditions: `{"any":[{"key":"{{ request.operation }}","operator":"AnyIn","value":["CREATE","UPDATE"]}],"all":[{"key":"{{ request.object.metadata.name }}","operator":"NotEquals","value":"foo"}]}`,
+			expected:   `!(((type(request.operation) == list ? request.operation.exists(k, k in ["CREATE", "UPDATE"]) : request.operation in ["CREATE", "UPDATE"])) && ((object.metadata.name != "foo")))`,
+		},
+		{
+			name:       "list key",
+			conditions: `{"any":[{"key":"{{ request.userInfo.groups }}","operator":"AnyNotIn","value":["system:masters"]}]}`,
+			expected:   `!(((type(request.userInfo.groups) == list ? request.userInfo.groups.exists(k, !(k in ["system:masters"])) : !(request.userInfo.groups in ["system:masters"]))))`,
+		},
+		{
+			name:       "all in",
+			conditions: `{"all":[{"key":"{{ request.object.spec.tags }}","operator":"AllIn","value":["a","b"]}]}`,
+			expected:   `!(((type(object.spec.tags) == list ? object.spec.tags.all(k, k in ["a", "b"]) : object.spec.tags in ["a", "b"])))`,
+		},
+		{
+			name:       "legacy",
+			conditions: `[{"key":"{{ request.object.spec.replicas }}","operator":"LessThan","value":1.5}]`,
+			expected:   "!(((object.spec.replicas < 1.5)))",
+		},
+		{
+			name:       "wildcard",
+			conditions: `{"any":[{"key":"{{ request.object.metadata.name }}","operator":"Equals","value":"foo-*"}]}`,
+			wantErr:    true,
+		},
+		{
+			name:       "variable value",
+			conditions: `{"any":[{"key":"{{ request.object.metadata.name }}","operator":"Equals","value":"{{ request.namespace }}"}]}`,
+			wantErr:    true,
+		},
+		{
+			name:       "duration operator",
+			conditions: `{"any":[{"key":"{{ request.object.spec.timeout }}","operator":"DurationGreaterThan","value":"1h"}]}`,
+			wantErr:    true,
+		},
+		{
+			name:       "empty",
+			conditions: `{}`,
+			wantErr:    true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var conditions interface{}
+			assert.NilError(t, json.Unmarshal([]byte(tc.conditions), &conditions))
+			deny := &kyvernov1.Deny{}
+			deny.SetAnyAllConditions(conditions)
+			actual, err := translateDeny(deny)
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+				assert.Equal(t, actual, tc.expected)
+			}
+		})
+	}
+}
+
+func Test_translateRule(t *testing.T) {
+	scope := admissionregistrationv1.AllScopes
+	matchPolicy := admissionregistrationv1alpha1.Equivalent
+	failurePolicy := admissionregistrationv1alpha1.Fail
+	spec := &kyvernov1.Spec{ValidationFailureAction: kyvernov1.Enforce}
+	rule := kyvernov1.Rule{
+		Name: "check-replicas",
+		MatchResources: kyvernov1.MatchResources{
+			Any: kyvernov1.ResourceFilters{{
+				ResourceDescription: kyvernov1.ResourceDescription{
+					Kinds:      []string{"Deployment"},
+					Namespaces: []string{"prod"},
+				},
+			}},
+		},
+		Validation: kyvernov1.Validation{
+			Message: "too many replicas",
+			CEL: &kyvernov1.CEL{
+				Expressions: []kyvernov1.CELExpression{{Expression: "object.spec.replicas <= 5"}},
+			},
+		},
+	}
+	actual, err := translateRule(policycache.TestResourceFinder{}, spec, rule)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, actual, &admissionregistrationv1alpha1.ValidatingAdmissionPolicySpec{
+		MatchConstraints: &admissionregistrationv1alpha1.MatchResources{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      "kubernetes.io/metadata.name",
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{"prod"},
+				}},
+			},
+			ObjectSelector: &metav1.LabelSelector{},
+			ResourceRules: []admissionregistrationv1alpha1.NamedRuleWithOperations{{
+				RuleWithOperations: admissionregistrationv1alpha1.RuleWithOperations{
+					Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+					Rule: admissionregistrationv1alpha1.Rule{
+						APIGroups:   []string{"apps"},
+						APIVersions: []string{"v1"},
+						Resources:   []string{"deployments"},
+						Scope:       &scope,
+					},
+				},
+			}},
+			MatchPolicy: &matchPolicy,
+		},
+		Validations: []admissionregistrationv1alpha1.Validation{{
+			Expression: "object.spec.replicas <= 5",
+			Message:    "too many replicas",
+		}},
+		FailurePolicy: &failurePolicy,
+	})
+
+	withContext := *rule.DeepCopy()
+	withContext.Context = []kyvernov1.ContextEntry{{Name: "foo"}}
+	_, err = translateRule(policycache.TestResourceFinder{}, spec, withContext)
+	assert.Error(t, err, "context entries are not supported")
+
+	withPolicyContext := spec.DeepCopy()
+	withPolicyContext.Context = []kyvernov1.ContextEntry{{Name: "foo"}}
+	_, err = translateRule(policycache.TestResourceFinder{}, withPolicyContext, rule)
+	assert.Error(t, err, "context entries are not supported")
+
+	withUserInfo := *rule.DeepCopy()
+	withUserInfo.MatchResources.Any[0].UserInfo = kyvernov1.UserInfo{Roles: []string{"admin"}}
+	_, err = translateRule(policycache.TestResourceFinder{}, spec, withUserInfo)
+	assert.Error(t, err, "userInfo is not supported")
+
+	withMessageVariables := *rule.DeepCopy()
+	withMessageVariables.Validation.Message = "{{ request.object.metadata.name }} has too many replicas"
+	_, err = translateRule(policycache.TestResourceFinder{}, spec, withMessageVariables)
+	assert.Error(t, err, "variables in messages are not supported")
+
+	withPattern := *rule.DeepCopy()
+	withPattern.Validation.CEL = nil
+	withPattern.Validation.SetPattern(map[string]interface{}{"spec": map[string]interface{}{"replicas": "<=5"}})
+	_, err = translateRule(policycache.TestResourceFinder{}, spec, withPattern)
+	assert.Error(t, err, "only cel and deny validations are supported")
+}
+
+func Test_checkValidationFailureAction(t *testing.T) {
+	audit := kyvernov1.Audit
+	enforce := kyvernov1.Enforce
+	assert.NilError(t, checkValidationFailureAction(&kyvernov1.Spec{ValidationFailureAction: kyvernov1.Enforce}, kyvernov1.Rule{}))
+	assert.Error(t, checkValidationFailureAction(&kyvernov1.Spec{ValidationFailureAction: kyvernov1.Audit}, kyvernov1.Rule{}), "only rules in Enforce mode can be translated")
+	assert.Error(t, checkValidationFailureAction(&kyvernov1.Spec{
+		ValidationFailureAction:          kyvernov1.Enforce,
+		ValidationFailureActionOverrides: []kyvernov1.ValidationFailureActionOverride{{Action: kyvernov1.Audit}},
+	}, kyvernov1.Rule{}), "validationFailureActionOverrides are not supported")
+	assert.NilError(t, checkValidationFailureAction(
+		&kyvernov1.Spec{ValidationFailureAction: kyvernov1.Audit},
+		kyvernov1.Rule{Validation: kyvernov1.Validation{ValidationFailureAction: &enforce}},
+	))
+	assert.Error(t, checkValidationFailureAction(
+		&kyvernov1.Spec{ValidationFailureAction: kyvernov1.Enforce},
+		kyvernov1.Rule{Validation: kyvernov1.Validation{ValidationFailureAction: &audit}},
+	), "only rules in Enforce mode can be translated")
+}
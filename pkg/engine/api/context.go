@@ -2,20 +2,28 @@ package api
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
 	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-func LoadVariable(logger logr.Logger, entry kyvernov1.ContextEntry, ctx enginecontext.Interface) (err error) {
+func LoadVariable(logger logr.Logger, entry kyvernov1.ContextEntry, ctx enginecontext.Interface, configuration config.Configuration) (err error) {
 	path := ""
 	if entry.Variable.JMESPath != "" {
 		jp, err := variables.SubstituteAll(logger, ctx, entry.Variable.JMESPath)
@@ -45,7 +53,7 @@ func LoadVariable(logger logr.Logger, entry kyvernov1.ContextEntry, ctx engineco
 			return fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, entry.Variable.Value, err)
 		}
 		if path != "" {
-			variable, err := applyJMESPath(path, variable)
+			variable, err := applyJMESPath(path, variable, configuration)
 			if err == nil {
 				output = variable
 			} else if defaultValue == nil {
@@ -63,7 +71,7 @@ func LoadVariable(logger logr.Logger, entry kyvernov1.ContextEntry, ctx engineco
 			}
 		}
 	}
-	logger.V(4).Info("evaluated output", "variable name", entry.Name, "output", output)
+	logger.V(4).Info("evaluated output", "variable name", entry.Name, "output", ctx.Redact(fmt.Sprint(output)))
 	if output == nil {
 		return fmt.Errorf("unable to add context entry for variable %s since it evaluated to nil", entry.Name)
 	}
@@ -74,8 +82,8 @@ func LoadVariable(logger logr.Logger, entry kyvernov1.ContextEntry, ctx engineco
 	}
 }
 
-func LoadImageData(ctx context.Context, rclient registryclient.Client, logger logr.Logger, entry kyvernov1.ContextEntry, enginectx enginecontext.Interface) error {
-	imageData, err := fetchImageData(ctx, rclient, logger, entry, enginectx)
+func LoadImageData(ctx context.Context, rclient registryclient.Client, logger logr.Logger, entry kyvernov1.ContextEntry, enginectx enginecontext.Interface, configuration config.Configuration) error {
+	imageData, err := fetchImageData(ctx, rclient, logger, entry, enginectx, configuration)
 	if err != nil {
 		return err
 	}
@@ -89,8 +97,8 @@ func LoadImageData(ctx context.Context, rclient registryclient.Client, logger lo
 	return nil
 }
 
-func LoadAPIData(ctx context.Context, logger logr.Logger, entry kyvernov1.ContextEntry, enginectx enginecontext.Interface, client dclient.Interface) error {
-	executor, err := apicall.New(ctx, entry, enginectx, client, logger)
+func LoadAPIData(ctx context.Context, logger logr.Logger, entry kyvernov1.ContextEntry, enginectx enginecontext.Interface, client dclient.Interface, cache apicall.Cache, secrets apicall.SecretLoader, configuration config.Configuration) error {
+	executor, err := apicall.New(ctx, entry, enginectx, client, cache, secrets, configuration, logger)
 	if err != nil {
 		return fmt.Errorf("failed to initialize APICall: %w", err)
 	}
@@ -100,6 +108,46 @@ func LoadAPIData(ctx context.Context, logger logr.Logger, entry kyvernov1.Contex
 	return nil
 }
 
+func LoadGlobalContext(logger logr.Logger, entry kyvernov1.ContextEntry, enginectx enginecontext.Interface, gctxStore store.Store, configuration config.Configuration) error {
+	if gctxStore == nil {
+		return fmt.Errorf("global context is not available for context entry %s", entry.Name)
+	}
+	gctxEntry, ok := gctxStore.Get(entry.GlobalReference.Name)
+	if !ok {
+		return fmt.Errorf("global context entry %s not found", entry.GlobalReference.Name)
+	}
+	data, err := gctxEntry.Get()
+	if err != nil {
+		return fmt.Errorf("failed to get global context entry %s: %w", entry.GlobalReference.Name, err)
+	}
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal global context entry %s: %w", entry.GlobalReference.Name, err)
+	}
+	if entry.GlobalReference.JMESPath != "" {
+		path, err := variables.SubstituteAll(logger, enginectx, entry.GlobalReference.JMESPath)
+		if err != nil {
+			return fmt.Errorf("failed to substitute variables in context entry %s JMESPath %s: %w", entry.Name, entry.GlobalReference.JMESPath, err)
+		}
+		var document interface{}
+		if err := json.Unmarshal(jsonData, &document); err != nil {
+			return fmt.Errorf("failed to unmarshal global context entry %s: %w", entry.GlobalReference.Name, err)
+		}
+		results, err := applyJMESPath(path.(string), document, configuration)
+		if err != nil {
+			return fmt.Errorf("failed to apply JMESPath %s for context entry %s: %w", path, entry.Name, err)
+		}
+		jsonData, err = json.Marshal(results)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JMESPath results for context entry %s: %w", entry.Name, err)
+		}
+	}
+	if err := enginectx.AddContextEntry(entry.Name, jsonData); err != nil {
+		return fmt.Errorf("failed to add global context entry %s to context entry %s: %w", entry.GlobalReference.Name, entry.Name, err)
+	}
+	return nil
+}
+
 func LoadConfigMap(ctx context.Context, logger logr.Logger, entry kyvernov1.ContextEntry, enginectx enginecontext.Interface, resolver ConfigmapResolver) error {
 	data, err := fetchConfigMap(ctx, logger, entry, enginectx, resolver)
 	if err != nil {
@@ -112,7 +160,63 @@ func LoadConfigMap(ctx context.Context, logger logr.Logger, entry kyvernov1.Cont
 	return nil
 }
 
-func fetchImageData(ctx context.Context, rclient registryclient.Client, logger logr.Logger, entry kyvernov1.ContextEntry, enginectx enginecontext.Interface) (interface{}, error) {
+func LoadSecret(ctx context.Context, logger logr.Logger, entry kyvernov1.ContextEntry, enginectx enginecontext.Interface, resolver SecretResolver, policyNamespace string, allowedNamespaces []string) error {
+	data, err := fetchSecret(ctx, logger, entry, enginectx, resolver, policyNamespace, allowedNamespaces)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve secret for context entry %s: %v", entry.Name, err)
+	}
+	// the secret data must never be disclosed in messages
+	enginectx.AddSensitiveValue(string(data))
+	enginectx.AddSensitiveValue(base64.StdEncoding.EncodeToString(data))
+	jsonData, err := json.Marshal(string(data))
+	if err != nil {
+		return fmt.Errorf("failed to marshal secret for context entry %s: %v", entry.Name, err)
+	}
+	if err := enginectx.AddContextEntry(entry.Name, jsonData); err != nil {
+		return fmt.Errorf("failed to add secret for context entry %s: %v", entry.Name, err)
+	}
+	return nil
+}
+
+// LoadResource loads the resources of a resource context entry, namespaced policies (policyNamespace is not empty)
+// can only look up resources in their own namespace.
+func LoadResource(ctx context.Context, logger logr.Logger, entry kyvernov1.ContextEntry, enginectx enginecontext.Interface, client dclient.Interface, resolver ResourceResolver, policyNamespace string) error {
+	data, err := fetchResource(ctx, logger, entry, enginectx, client, resolver, policyNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve resource for context entry %s: %v", entry.Name, err)
+	}
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal resource for context entry %s: %v", entry.Name, err)
+	}
+	if err := enginectx.AddContextEntry(entry.Name, jsonData); err != nil {
+		return fmt.Errorf("failed to add resource for context entry %s: %v", entry.Name, err)
+	}
+	return nil
+}
+
+// HandleContextEntryError applies the onError behaviour of a context entry that failed to load.
+// It returns nil when the entry fell back (ignored or set to its default value) and the original error otherwise.
+func HandleContextEntryError(logger logr.Logger, entry kyvernov1.ContextEntry, enginectx enginecontext.Interface, err error) error {
+	switch entry.OnError.GetAction() {
+	case kyvernov1.OnErrorIgnore:
+		logger.V(2).Info("ignoring context entry load failure", "name", entry.Name, "error", err.Error())
+		return nil
+	case kyvernov1.OnErrorUseDefault:
+		if entry.OnError.Default == nil {
+			return err
+		}
+		logger.V(2).Info("using default value after context entry load failure", "name", entry.Name, "error", err.Error())
+		if err := enginectx.AddContextEntry(entry.Name, entry.OnError.Default.Raw); err != nil {
+			return fmt.Errorf("failed to add default value for context entry %s: %w", entry.Name, err)
+		}
+		return nil
+	default:
+		return err
+	}
+}
+
+func fetchImageData(ctx context.Context, rclient registryclient.Client, logger logr.Logger, entry kyvernov1.ContextEntry, enginectx enginecontext.Interface, configuration config.Configuration) (interface{}, error) {
 	ref, err := variables.SubstituteAll(logger, enginectx, entry.ImageRegistry.Reference)
 	if err != nil {
 		return nil, fmt.Errorf("ailed to substitute variables in context entry %s %s: %v", entry.Name, entry.ImageRegistry.Reference, err)
@@ -130,7 +234,7 @@ func fetchImageData(ctx context.Context, rclient registryclient.Client, logger l
 		return nil, err
 	}
 	if path != "" {
-		imageData, err = applyJMESPath(path.(string), imageData)
+		imageData, err = applyJMESPath(path.(string), imageData, configuration)
 		if err != nil {
 			return nil, fmt.Errorf("failed to apply JMESPath (%s) results to context entry %s, error: %v", entry.ImageRegistry.JMESPath, entry.Name, err)
 		}
@@ -193,8 +297,9 @@ func fetchImageDataMap(ctx context.Context, rclient registryclient.Client, ref s
 	return untyped, nil
 }
 
-func applyJMESPath(jmesPath string, data interface{}) (interface{}, error) {
-	jp, err := jmespath.New(jmesPath)
+// applyJMESPath evaluates a JMESPath expression, the configuration is used by functions like image_normalize
+func applyJMESPath(jmesPath string, data interface{}, configuration config.Configuration) (interface{}, error) {
+	jp, err := jmespath.New(jmesPath, jmespath.WithConfiguration(configuration))
 	if err != nil {
 		return nil, fmt.Errorf("failed to compile JMESPath: %s, error: %v", jmesPath, err)
 	}
@@ -227,3 +332,156 @@ func fetchConfigMap(ctx context.Context, logger logr.Logger, entry kyvernov1.Con
 	}
 	return data, nil
 }
+
+func fetchSecret(ctx context.Context, logger logr.Logger, entry kyvernov1.ContextEntry, enginectx enginecontext.Interface, resolver SecretResolver, policyNamespace string, allowedNamespaces []string) ([]byte, error) {
+	if resolver == nil {
+		return nil, fmt.Errorf("secrets are not available")
+	}
+	name, err := variables.SubstituteAll(logger, enginectx, entry.Secret.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in context %s secret.name %s: %v", entry.Name, entry.Secret.Name, err)
+	}
+	namespace, err := variables.SubstituteAll(logger, enginectx, entry.Secret.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in context %s secret.namespace %s: %v", entry.Name, entry.Secret.Namespace, err)
+	}
+	key, err := variables.SubstituteAll(logger, enginectx, entry.Secret.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in context %s secret.key %s: %v", entry.Name, entry.Secret.Key, err)
+	}
+	if namespace == "" {
+		namespace = policyNamespace
+	}
+	if namespace == "" {
+		return nil, fmt.Errorf("a namespace is required to load secret %s", name)
+	}
+	if !isSecretNamespaceAllowed(namespace.(string), policyNamespace, allowedNamespaces) {
+		return nil, fmt.Errorf("loading secrets from namespace %s is not allowed", namespace)
+	}
+	obj, err := resolver.Get(ctx, namespace.(string), name.(string))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s: %v", namespace, name, err)
+	}
+	data, ok := obj.Data[key.(string)]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in secret %s/%s", key, namespace, name)
+	}
+	return data, nil
+}
+
+// NewCredentialsSecretLoader returns a loader for the secrets referenced by service call credentials. Namespaced policies
+// can only load secrets from their own namespace, other policies from the namespaces allowed in the configuration.
+func NewCredentialsSecretLoader(resolver SecretResolver, policyNamespace string, configuration config.Configuration) apicall.SecretLoader {
+	return func(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+		if resolver == nil {
+			return nil, fmt.Errorf("secrets are not available")
+		}
+		if policyNamespace != "" {
+			if namespace != policyNamespace {
+				return nil, fmt.Errorf("loading secrets from namespace %s is not allowed, namespaced policies can only load secrets from namespace %s", namespace, policyNamespace)
+			}
+		} else {
+			var allowedNamespaces []string
+			if configuration != nil {
+				allowedNamespaces = configuration.GetSecretContextNamespaces()
+			}
+			if !isSecretNamespaceAllowed(namespace, "", allowedNamespaces) {
+				return nil, fmt.Errorf("loading secrets from namespace %s is not allowed", namespace)
+			}
+		}
+		return resolver.Get(ctx, namespace, name)
+	}
+}
+
+// isSecretNamespaceAllowed checks a secret can be loaded from a namespace, namespaced policies can always load
+// secrets from their own namespace, other namespaces must be allowed in the configuration
+func isSecretNamespaceAllowed(namespace string, policyNamespace string, allowedNamespaces []string) bool {
+	if policyNamespace != "" && namespace == policyNamespace {
+		return true
+	}
+	for _, allowed := range allowedNamespaces {
+		if wildcard.Match(allowed, namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSecretKind returns true if the api version and kind designate core Secrets
+func IsSecretKind(apiVersion, kind string) bool {
+	gvk := schema.FromAPIVersionAndKind(apiVersion, kind)
+	return gvk.Group == "" && gvk.Kind == "Secret"
+}
+
+// fetchResource looks up the resources of a resource context entry, it returns a single resource if the entry
+// has a name and the list of matching resources otherwise
+func fetchResource(ctx context.Context, logger logr.Logger, entry kyvernov1.ContextEntry, enginectx enginecontext.Interface, client dclient.Interface, resolver ResourceResolver, policyNamespace string) (interface{}, error) {
+	if client == nil {
+		return nil, fmt.Errorf("a client is required to look up resources")
+	}
+	lookup := entry.Resource
+	if IsSecretKind(lookup.APIVersion, lookup.Kind) {
+		return nil, fmt.Errorf("secrets can not be looked up with a resource context entry, use a secret context entry instead")
+	}
+	namespace, err := variables.SubstituteAll(logger, enginectx, lookup.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in context %s resource.namespace %s: %v", entry.Name, lookup.Namespace, err)
+	}
+	if policyNamespace != "" {
+		if namespace == "" {
+			namespace = policyNamespace
+		} else if namespace != policyNamespace {
+			return nil, fmt.Errorf("looking up resources in namespace %v is not allowed, namespaced policies can only look up resources in namespace %s", namespace, policyNamespace)
+		}
+	}
+	name, err := variables.SubstituteAll(logger, enginectx, lookup.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in context %s resource.name %s: %v", entry.Name, lookup.Name, err)
+	}
+	selector := labels.Everything()
+	if lookup.Selector != nil {
+		selector, err = metav1.LabelSelectorAsSelector(lookup.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector in context %s: %v", entry.Name, err)
+		}
+	}
+	// without resolver, resources are fetched from the API server
+	if resolver == nil {
+		if name != "" {
+			obj, err := client.GetResource(ctx, lookup.APIVersion, lookup.Kind, namespace.(string), name.(string))
+			if err != nil {
+				return nil, err
+			}
+			return obj.UnstructuredContent(), nil
+		}
+		list, err := client.ListResource(ctx, lookup.APIVersion, lookup.Kind, namespace.(string), lookup.Selector)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, 0, len(list.Items))
+		for _, item := range list.Items {
+			items = append(items, item.UnstructuredContent())
+		}
+		return items, nil
+	}
+	gvr, err := client.Discovery().GetGVRFromGVK(schema.FromAPIVersionAndKind(lookup.APIVersion, lookup.Kind))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s %s: %v", lookup.APIVersion, lookup.Kind, err)
+	}
+	if name != "" {
+		obj, err := resolver.Get(ctx, gvr, namespace.(string), name.(string))
+		if err != nil {
+			return nil, err
+		}
+		return obj.UnstructuredContent(), nil
+	}
+	list, err := resolver.List(ctx, gvr, namespace.(string), selector)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]interface{}, 0, len(list))
+	for _, item := range list {
+		items = append(items, item.UnstructuredContent())
+	}
+	return items, nil
+}
@@ -0,0 +1,402 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type dummyEntry struct {
+	data interface{}
+	err  error
+}
+
+func (e dummyEntry) Get() (interface{}, error) {
+	return e.data, e.err
+}
+
+func (e dummyEntry) Stop() {}
+
+func TestLoadGlobalContext(t *testing.T) {
+	gctxStore := store.New()
+	gctxStore.Set("deployments", dummyEntry{data: []interface{}{
+		map[string]interface{}{"metadata": map[string]interface{}{"name": "foo"}, "spec": map[string]interface{}{"replicas": int64(2)}},
+		map[string]interface{}{"metadata": map[string]interface{}{"name": "bar"}, "spec": map[string]interface{}{"replicas": int64(3)}},
+	}})
+	gctxStore.Set("failing", dummyEntry{err: errors.New("not synced")})
+	testCases := []struct {
+		name      string
+		reference kyvernov1.GlobalContextEntryReference
+		store     store.Store
+		query     string
+		expected  interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "whole entry",
+			reference: kyvernov1.GlobalContextEntryReference{Name: "deployments"},
+			store:     gctxStore,
+			query:     "length(data)",
+			expected:  2.0,
+		},
+		{
+			name:      "jmespath",
+			reference: kyvernov1.GlobalContextEntryReference{Name: "deployments", JMESPath: "[?spec.replicas > `2`].metadata.name"},
+			store:     gctxStore,
+			query:     "data",
+			expected:  []interface{}{"bar"},
+		},
+		{
+			name:      "not found",
+			reference: kyvernov1.GlobalContextEntryReference{Name: "unknown"},
+			store:     gctxStore,
+			wantErr:   true,
+		},
+		{
+			name:      "entry error",
+			reference: kyvernov1.GlobalContextEntryReference{Name: "failing"},
+			store:     gctxStore,
+			wantErr:   true,
+		},
+		{
+			name:      "no store",
+			reference: kyvernov1.GlobalContextEntryReference{Name: "deployments"},
+			wantErr:   true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jsonContext := enginecontext.NewContext()
+			reference := tc.reference
+			entry := kyvernov1.ContextEntry{Name: "data", GlobalReference: &reference}
+			err := LoadGlobalContext(logr.Discard(), entry, jsonContext, tc.store, nil)
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			result, err := jsonContext.Query(tc.query)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, result, tc.expected)
+		})
+	}
+}
+
+func TestHandleContextEntryError(t *testing.T) {
+	loadErr := errors.New("failed")
+	testCases := []struct {
+		name      string
+		onError   *kyvernov1.OnError
+		wantErr   bool
+		wantValue interface{}
+	}{
+		{
+			name:    "no onError",
+			wantErr: true,
+		},
+		{
+			name:    "fail",
+			onError: &kyvernov1.OnError{Action: kyvernov1.OnErrorFail},
+			wantErr: true,
+		},
+		{
+			name:    "ignore",
+			onError: &kyvernov1.OnError{Action: kyvernov1.OnErrorIgnore},
+		},
+		{
+			name:      "use default",
+			onError:   &kyvernov1.OnError{Action: kyvernov1.OnErrorUseDefault, Default: &apiextv1.JSON{Raw: []byte(`{"max": 3}`)}},
+			wantValue: map[string]interface{}{"max": 3.0},
+		},
+		{
+			name:    "use default without value",
+			onError: &kyvernov1.OnError{Action: kyvernov1.OnErrorUseDefault},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jsonContext := enginecontext.NewContext()
+			entry := kyvernov1.ContextEntry{Name: "data", OnError: tc.onError}
+			err := HandleContextEntryError(logr.Discard(), entry, jsonContext, loadErr)
+			if tc.wantErr {
+				assert.Equal(t, err, loadErr)
+				return
+			}
+			assert.NilError(t, err)
+			result, err := jsonContext.Query("data")
+			if tc.wantValue == nil {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, result, tc.wantValue)
+		})
+	}
+}
+
+type secretResolver map[string]*corev1.Secret
+
+func (r secretResolver) Get(_ context.Context, namespace, name string) (*corev1.Secret, error) {
+	if secret, ok := r[namespace+"/"+name]; ok {
+		return secret, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func TestLoadSecret(t *testing.T) {
+	resolver := secretResolver{
+		"team-a/creds": {Data: map[string][]byte{"password": []byte("s3cr3t")}},
+		"shared/creds": {Data: map[string][]byte{"password": []byte("sh4r3d")}},
+	}
+	testCases := []struct {
+		name              string
+		reference         kyvernov1.SecretKeyReference
+		policyNamespace   string
+		allowedNamespaces []string
+		expected          string
+		wantErr           string
+	}{
+		{
+			name:            "policy namespace",
+			reference:       kyvernov1.SecretKeyReference{Name: "creds", Key: "password"},
+			policyNamespace: "team-a",
+			expected:        "s3cr3t",
+		},
+		{
+			name:              "allowed namespace",
+			reference:         kyvernov1.SecretKeyReference{Name: "creds", Namespace: "shared", Key: "password"},
+			allowedNamespaces: []string{"share*"},
+			expected:          "sh4r3d",
+		},
+		{
+			name:            "namespace not allowed",
+			reference:       kyvernov1.SecretKeyReference{Name: "creds", Namespace: "shared", Key: "password"},
+			policyNamespace: "team-a",
+			wantErr:         "failed to retrieve secret for context entry data: loading secrets from namespace shared is not allowed",
+		},
+		{
+			name:      "no namespace",
+			reference: kyvernov1.SecretKeyReference{Name: "creds", Key: "password"},
+			wantErr:   "failed to retrieve secret for context entry data: a namespace is required to load secret creds",
+		},
+		{
+			name:            "key not found",
+			reference:       kyvernov1.SecretKeyReference{Name: "creds", Key: "token"},
+			policyNamespace: "team-a",
+			wantErr:         "failed to retrieve secret for context entry data: key token not found in secret team-a/creds",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jsonContext := enginecontext.NewContext()
+			reference := tc.reference
+			entry := kyvernov1.ContextEntry{Name: "data", Secret: &reference}
+			err := LoadSecret(context.TODO(), logr.Discard(), entry, jsonContext, resolver, tc.policyNamespace, tc.allowedNamespaces)
+			if tc.wantErr != "" {
+				assert.Error(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			result, err := jsonContext.Query("data")
+			assert.NilError(t, err)
+			assert.Equal(t, result, tc.expected)
+			assert.Equal(t, jsonContext.Redact("value is "+tc.expected), "value is **REDACTED**")
+		})
+	}
+}
+
+type resourceResolver []*unstructured.Unstructured
+
+func (r resourceResolver) Get(_ context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	for _, object := range r {
+		if object.GetNamespace() == namespace && object.GetName() == name {
+			return object, nil
+		}
+	}
+	return nil, kerrors.NewNotFound(gvr.GroupResource(), name)
+}
+
+func (r resourceResolver) List(_ context.Context, _ schema.GroupVersionResource, namespace string, selector labels.Selector) ([]*unstructured.Unstructured, error) {
+	var list []*unstructured.Unstructured
+	for _, object := range r {
+		if (namespace == "" || object.GetNamespace() == namespace) && selector.Matches(labels.Set(object.GetLabels())) {
+			list = append(list, object)
+		}
+	}
+	return list, nil
+}
+
+func TestLoadResource(t *testing.T) {
+	newDeployment := func(namespace, name, app string) *unstructured.Unstructured {
+		object := &unstructured.Unstructured{}
+		object.SetAPIVersion("apps/v1")
+		object.SetKind("Deployment")
+		object.SetNamespace(namespace)
+		object.SetName(name)
+		object.SetLabels(map[string]string{"app": app})
+		return object
+	}
+	resolver := resourceResolver{
+		newDeployment("team-a", "foo", "foo"),
+		newDeployment("team-a", "bar", "bar"),
+		newDeployment("team-b", "baz", "foo"),
+	}
+	client := dclient.NewEmptyFakeClient()
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+	testCases := []struct {
+		name            string
+		lookup          kyvernov1.ResourceLookup
+		policyNamespace string
+		query           string
+		expected        interface{}
+		wantErr         string
+	}{
+		{
+			name:     "by name",
+			lookup:   kyvernov1.ResourceLookup{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "{{ request.namespace }}", Name: "foo"},
+			query:    "data.metadata.name",
+			expected: "foo",
+		},
+		{
+			name:     "by selector",
+			lookup:   kyvernov1.ResourceLookup{APIVersion: "apps/v1", Kind: "Deployment", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}}},
+			query:    "data[].metadata.name",
+			expected: []interface{}{"foo", "baz"},
+		},
+		{
+			name:     "all in namespace",
+			lookup:   kyvernov1.ResourceLookup{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "team-a"},
+			query:    "length(data)",
+			expected: 2.0,
+		},
+		{
+			name:    "not found",
+			lookup:  kyvernov1.ResourceLookup{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "team-b", Name: "foo"},
+			wantErr: "failed to retrieve resource for context entry data: deployments.apps \"foo\" not found",
+		},
+		{
+			name:    "unknown kind",
+			lookup:  kyvernov1.ResourceLookup{APIVersion: "example.com/v1", Kind: "Unknown", Name: "foo"},
+			wantErr: "failed to retrieve resource for context entry data: failed to resolve example.com/v1 Unknown",
+		},
+		{
+			name:    "secrets are denied",
+			lookup:  kyvernov1.ResourceLookup{APIVersion: "v1", Kind: "Secret", Namespace: "team-a", Name: "foo"},
+			wantErr: "failed to retrieve resource for context entry data: secrets can not be looked up with a resource context entry",
+		},
+		{
+			name:            "namespaced policy defaults to policy namespace",
+			lookup:          kyvernov1.ResourceLookup{APIVersion: "apps/v1", Kind: "Deployment", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}}},
+			policyNamespace: "team-b",
+			query:           "data[].metadata.name",
+			expected:        []interface{}{"baz"},
+		},
+		{
+			name:            "namespaced policy in another namespace",
+			lookup:          kyvernov1.ResourceLookup{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "{{ request.namespace }}", Name: "foo"},
+			policyNamespace: "team-b",
+			wantErr:         "failed to retrieve resource for context entry data: looking up resources in namespace team-a is not allowed",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jsonContext := enginecontext.NewContext()
+			assert.NilError(t, jsonContext.AddContextEntry("request", []byte(`{"namespace": "team-a"}`)))
+			lookup := tc.lookup
+			entry := kyvernov1.ContextEntry{Name: "data", Resource: &lookup}
+			err := LoadResource(context.TODO(), logr.Discard(), entry, jsonContext, client, resolver, tc.policyNamespace)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			result, err := jsonContext.Query(tc.query)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, result, tc.expected)
+		})
+	}
+}
+
+func TestNewCredentialsSecretLoader(t *testing.T) {
+	resolver := secretResolver{
+		"team-a/creds": {Data: map[string][]byte{"token": []byte("a")}},
+		"shared/creds": {Data: map[string][]byte{"token": []byte("s")}},
+	}
+	configuration := config.NewDefaultConfiguration()
+	configuration.Load(&corev1.ConfigMap{Data: map[string]string{"secretContextNamespaces": "shared"}})
+	testCases := []struct {
+		name            string
+		policyNamespace string
+		namespace       string
+		wantErr         string
+	}{
+		{
+			name:            "policy namespace",
+			policyNamespace: "team-a",
+			namespace:       "team-a",
+		},
+		{
+			name:            "namespaced policy and allowed namespace",
+			policyNamespace: "team-a",
+			namespace:       "shared",
+			wantErr:         "loading secrets from namespace shared is not allowed, namespaced policies can only load secrets from namespace team-a",
+		},
+		{
+			name:      "cluster policy and allowed namespace",
+			namespace: "shared",
+		},
+		{
+			name:      "cluster policy and namespace not allowed",
+			namespace: "team-a",
+			wantErr:   "loading secrets from namespace team-a is not allowed",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			load := NewCredentialsSecretLoader(resolver, tc.policyNamespace, configuration)
+			secret, err := load(context.TODO(), tc.namespace, "creds")
+			if tc.wantErr != "" {
+				assert.Error(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Assert(t, secret != nil)
+		})
+	}
+	_, err := NewCredentialsSecretLoader(nil, "", configuration)(context.TODO(), "shared", "creds")
+	assert.Error(t, err, "secrets are not available")
+}
+
+func TestLoadVariableConfiguration(t *testing.T) {
+	configuration := config.NewDefaultConfiguration()
+	configuration.Load(&corev1.ConfigMap{Data: map[string]string{"defaultRegistry": "registry.example.com"}})
+	entry := kyvernov1.ContextEntry{
+		Name: "image",
+		Variable: &kyvernov1.Variable{
+			Value:    &apiextv1.JSON{Raw: []byte(`{"name":"nginx"}`)},
+			JMESPath: "image_normalize(name)",
+		},
+	}
+	jsonContext := enginecontext.NewContext()
+	err := LoadVariable(logr.Discard(), entry, jsonContext, configuration)
+	assert.NilError(t, err)
+	image, err := jsonContext.Query("image")
+	assert.NilError(t, err)
+	assert.Equal(t, image, "registry.example.com/nginx:latest")
+}
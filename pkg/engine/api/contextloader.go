@@ -6,39 +6,92 @@ import (
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/apicall"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 )
 
-// ContextLoaderFactory provides a ContextLoader given a policy context and rule name
+// ContextLoaderFactory provides a ContextLoader given a policy context and rule name,
+// the rule is empty when loading the policy level context entries
 type ContextLoaderFactory = func(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) ContextLoader
 
 // ContextLoader abstracts the mechanics to load context entries in the underlying json context
 type ContextLoader interface {
+	// Load loads context entries in the json context and returns the names of the
+	// entries that failed to load and fell back according to their onError behaviour
 	Load(
 		ctx context.Context,
 		client dclient.Interface,
 		rclient registryclient.Client,
 		contextEntries []kyvernov1.ContextEntry,
 		jsonContext enginecontext.Interface,
-	) error
+	) ([]string, error)
+}
+
+// ContextLoaderFactoryOptions configures the context loaders created by DefaultContextLoaderFactory
+type ContextLoaderFactoryOptions func(*contextLoader)
+
+// WithGlobalContext makes global context entries available to context loaders
+func WithGlobalContext(gctxStore store.Store) ContextLoaderFactoryOptions {
+	return func(cl *contextLoader) {
+		cl.gctxStore = gctxStore
+	}
+}
+
+// WithSecretResolver makes secrets available to context loaders and service call credentials, secrets are restricted
+// to the policy namespace and the namespaces allowed in the configuration
+func WithSecretResolver(resolver SecretResolver, configuration config.Configuration) ContextLoaderFactoryOptions {
+	return func(cl *contextLoader) {
+		cl.secretResolver = resolver
+		cl.configuration = configuration
+	}
+}
+
+// WithResourceResolver makes context loaders look up resources with the given resolver instead of the API server
+func WithResourceResolver(resolver ResourceResolver) ContextLoaderFactoryOptions {
+	return func(cl *contextLoader) {
+		cl.resourceResolver = resolver
+	}
+}
+
+// WithAPICallCache makes context loaders cache API call responses when requested by context entries
+func WithAPICallCache(cache apicall.Cache) ContextLoaderFactoryOptions {
+	return func(cl *contextLoader) {
+		cl.apiCallCache = cache
+	}
 }
 
 func DefaultContextLoaderFactory(
 	cmResolver ConfigmapResolver,
+	opts ...ContextLoaderFactoryOptions,
 ) ContextLoaderFactory {
 	return func(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) ContextLoader {
-		return &contextLoader{
+		cl := &contextLoader{
 			logger:     logging.WithName("DefaultContextLoaderFactory"),
 			cmResolver: cmResolver,
 		}
+		if policy != nil {
+			cl.policyNamespace = policy.GetNamespace()
+		}
+		for _, opt := range opts {
+			opt(cl)
+		}
+		return cl
 	}
 }
 
 type contextLoader struct {
-	logger     logr.Logger
-	cmResolver ConfigmapResolver
+	logger           logr.Logger
+	cmResolver       ConfigmapResolver
+	secretResolver   SecretResolver
+	resourceResolver ResourceResolver
+	configuration    config.Configuration
+	policyNamespace  string
+	gctxStore        store.Store
+	apiCallCache     apicall.Cache
 }
 
 func (l *contextLoader) Load(
@@ -47,25 +100,48 @@ func (l *contextLoader) Load(
 	rclient registryclient.Client,
 	contextEntries []kyvernov1.ContextEntry,
 	jsonContext enginecontext.Interface,
-) error {
+) ([]string, error) {
+	var fallbacks []string
 	for _, entry := range contextEntries {
-		if entry.ConfigMap != nil {
-			if err := LoadConfigMap(ctx, l.logger, entry, jsonContext, l.cmResolver); err != nil {
-				return err
-			}
-		} else if entry.APICall != nil {
-			if err := LoadAPIData(ctx, l.logger, entry, jsonContext, client); err != nil {
-				return err
-			}
-		} else if entry.ImageRegistry != nil {
-			if err := LoadImageData(ctx, rclient, l.logger, entry, jsonContext); err != nil {
-				return err
-			}
-		} else if entry.Variable != nil {
-			if err := LoadVariable(l.logger, entry, jsonContext); err != nil {
-				return err
+		if err := l.load(ctx, client, rclient, entry, jsonContext); err != nil {
+			if err := HandleContextEntryError(l.logger, entry, jsonContext, err); err != nil {
+				TraceContextEntry(ctx, entry, ContextEntryError, err)
+				return nil, err
 			}
+			TraceContextEntry(ctx, entry, ContextEntryFallback, err)
+			fallbacks = append(fallbacks, entry.Name)
+		} else {
+			TraceContextEntry(ctx, entry, ContextEntryLoaded, nil)
+		}
+	}
+	return fallbacks, nil
+}
+
+func (l *contextLoader) load(
+	ctx context.Context,
+	client dclient.Interface,
+	rclient registryclient.Client,
+	entry kyvernov1.ContextEntry,
+	jsonContext enginecontext.Interface,
+) error {
+	if entry.ConfigMap != nil {
+		return LoadConfigMap(ctx, l.logger, entry, jsonContext, l.cmResolver)
+	} else if entry.Secret != nil {
+		var allowedNamespaces []string
+		if l.configuration != nil {
+			allowedNamespaces = l.configuration.GetSecretContextNamespaces()
 		}
+		return LoadSecret(ctx, l.logger, entry, jsonContext, l.secretResolver, l.policyNamespace, allowedNamespaces)
+	} else if entry.Resource != nil {
+		return LoadResource(ctx, l.logger, entry, jsonContext, client, l.resourceResolver, l.policyNamespace)
+	} else if entry.APICall != nil {
+		return LoadAPIData(ctx, l.logger, entry, jsonContext, client, l.apiCallCache, NewCredentialsSecretLoader(l.secretResolver, l.policyNamespace, l.configuration), l.configuration)
+	} else if entry.ImageRegistry != nil {
+		return LoadImageData(ctx, rclient, l.logger, entry, jsonContext, l.configuration)
+	} else if entry.Variable != nil {
+		return LoadVariable(l.logger, entry, jsonContext, l.configuration)
+	} else if entry.GlobalReference != nil {
+		return LoadGlobalContext(l.logger, entry, jsonContext, l.gctxStore, l.configuration)
 	}
 	return nil
 }
@@ -7,8 +7,9 @@ import (
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 )
 
-// EngineContextLoader provides a function to load context entries from the various clients initialised with the engine ones
-type EngineContextLoader = func(ctx context.Context, contextEntries []kyvernov1.ContextEntry, jsonContext enginecontext.Interface) error
+// EngineContextLoader provides a function to load context entries from the various clients initialised with the engine ones,
+// it returns the names of the entries that failed to load and fell back according to their onError behaviour
+type EngineContextLoader = func(ctx context.Context, contextEntries []kyvernov1.ContextEntry, jsonContext enginecontext.Interface) ([]string, error)
 
 // EngineContextLoaderFactory provides an EngineContextLoader given a policy and rule name
 type EngineContextLoaderFactory = func(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) EngineContextLoader
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	utils "github.com/kyverno/kyverno/pkg/utils/match"
 	"github.com/kyverno/kyverno/pkg/utils/wildcard"
@@ -179,7 +180,40 @@ func (er EngineResponse) getRulesWithErrors(predicate func(RuleResponse) bool) [
 
 func (er EngineResponse) GetValidationFailureAction() kyvernov1.ValidationFailureAction {
 	spec := er.Policy.GetSpec()
-	for _, v := range spec.ValidationFailureActionOverrides {
+	return er.getValidationFailureAction(spec.ValidationFailureAction, spec.ValidationFailureActionOverrides)
+}
+
+// RuleValidationFailureActions contains the validation failure actions of the rules of a policy
+type RuleValidationFailureActions struct {
+	rules  map[string]kyvernov1.ValidationFailureAction
+	policy kyvernov1.ValidationFailureAction
+}
+
+// Get returns the validation failure action of a rule, the policy action is returned for unknown rules
+func (a RuleValidationFailureActions) Get(name string) kyvernov1.ValidationFailureAction {
+	if action, ok := a.rules[name]; ok {
+		return action
+	}
+	return a.policy
+}
+
+// GetRuleValidationFailureActions returns the validation failure actions of the policy rules,
+// taking rule level settings into account. The policy rules are computed once for all lookups.
+func (er EngineResponse) GetRuleValidationFailureActions() RuleValidationFailureActions {
+	spec := er.Policy.GetSpec()
+	rules := autogen.ComputeRules(er.Policy)
+	actions := RuleValidationFailureActions{
+		rules:  make(map[string]kyvernov1.ValidationFailureAction, len(rules)),
+		policy: er.GetValidationFailureAction(),
+	}
+	for _, rule := range rules {
+		actions.rules[rule.Name] = er.getValidationFailureAction(rule.GetValidationFailureAction(spec))
+	}
+	return actions
+}
+
+func (er EngineResponse) getValidationFailureAction(action kyvernov1.ValidationFailureAction, overrides []kyvernov1.ValidationFailureActionOverride) kyvernov1.ValidationFailureAction {
+	for _, v := range overrides {
 		if !v.Action.IsValid() {
 			continue
 		}
@@ -201,5 +235,5 @@ func (er EngineResponse) GetValidationFailureAction() kyvernov1.ValidationFailur
 			}
 		}
 	}
-	return spec.ValidationFailureAction
+	return action
 }
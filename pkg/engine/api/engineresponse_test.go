@@ -952,6 +952,46 @@ func TestEngineResponse_GetValidationFailureAction(t *testing.T) {
 	}
 }
 
+func TestEngineResponse_GetRuleValidationFailureActions(t *testing.T) {
+	enforce := kyvernov1.Enforce
+	resource := unstructured.Unstructured{}
+	resource.SetNamespace("foo")
+	er := EngineResponse{
+		PatchedResource: resource,
+		Policy: &kyvernov1.ClusterPolicy{
+			Spec: kyvernov1.Spec{
+				ValidationFailureAction: kyvernov1.Audit,
+				Rules: []kyvernov1.Rule{{
+					Name: "policy-action",
+				}, {
+					Name:       "rule-action",
+					Validation: kyvernov1.Validation{ValidationFailureAction: &enforce},
+				}, {
+					Name: "rule-override",
+					Validation: kyvernov1.Validation{
+						ValidationFailureActionOverrides: []kyvernov1.ValidationFailureActionOverride{{
+							Action:     kyvernov1.Warn,
+							Namespaces: []string{"foo"},
+						}},
+					},
+				}},
+			},
+		},
+	}
+	want := map[string]kyvernov1.ValidationFailureAction{
+		"policy-action": kyvernov1.Audit,
+		"rule-action":   kyvernov1.Enforce,
+		"rule-override": kyvernov1.Warn,
+		"unknown":       kyvernov1.Audit,
+	}
+	actions := er.GetRuleValidationFailureActions()
+	for name, action := range want {
+		if got := actions.Get(name); got != action {
+			t.Errorf("RuleValidationFailureActions.Get(%s) = %v, want %v", name, got, action)
+		}
+	}
+}
+
 func TestEngineResponse_GetPatches(t *testing.T) {
 	type fields struct {
 		PatchedResource unstructured.Unstructured
@@ -24,6 +24,7 @@ type PolicyContext interface {
 	AdmissionOperation() bool
 	Element() unstructured.Unstructured
 	SetElement(element unstructured.Unstructured)
+	Explain() bool
 
 	JSONContext() enginecontext.Interface
 	Copy() PolicyContext
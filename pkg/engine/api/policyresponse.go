@@ -6,6 +6,8 @@ type PolicyResponse struct {
 	Stats PolicyStats
 	// Rules contains policy rules responses
 	Rules []RuleResponse
+	// Traces contains the rules evaluation traces, they are only recorded in explain mode
+	Traces []RuleTrace
 }
 
 func (pr *PolicyResponse) Add(rr RuleResponse) {
@@ -17,6 +19,16 @@ func (pr *PolicyResponse) Add(rr RuleResponse) {
 	}
 }
 
+// Trace returns the evaluation trace of a rule, or nil if it was not recorded
+func (pr *PolicyResponse) Trace(rule string) *RuleTrace {
+	for i := range pr.Traces {
+		if pr.Traces[i].Name == rule {
+			return &pr.Traces[i]
+		}
+	}
+	return nil
+}
+
 func NewPolicyResponse() PolicyResponse {
 	return PolicyResponse{}
 }
@@ -5,6 +5,9 @@ import (
 	"errors"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // NamespacedResourceResolver is an abstract interface used to resolve namespaced resources
@@ -21,6 +24,9 @@ type NamespacedResourceResolver[T any] interface {
 // ConfigmapResolver is an abstract interface used to resolve configmaps
 type ConfigmapResolver = NamespacedResourceResolver[*corev1.ConfigMap]
 
+// SecretResolver is an abstract interface used to resolve secrets
+type SecretResolver = NamespacedResourceResolver[*corev1.Secret]
+
 // namespacedResourceResolverChain represents a chain of NamespacedResourceResolver
 type namespacedResourceResolverChain[T any] []NamespacedResourceResolver[T]
 
@@ -50,3 +56,62 @@ func (chain namespacedResourceResolverChain[T]) Get(ctx context.Context, namespa
 	var notFound T
 	return notFound, lastErr
 }
+
+// ResourceResolver is an abstract interface used to resolve resources of any kind
+type ResourceResolver interface {
+	// Get is used to resolve a resource given its group version resource, namespace and name
+	Get(
+		ctx context.Context,
+		gvr schema.GroupVersionResource,
+		namespace string,
+		name string,
+	) (*unstructured.Unstructured, error)
+	// List is used to resolve the resources of a group version resource matching a namespace and label selector
+	List(
+		ctx context.Context,
+		gvr schema.GroupVersionResource,
+		namespace string,
+		selector labels.Selector,
+	) ([]*unstructured.Unstructured, error)
+}
+
+// resourceResolverChain represents a chain of ResourceResolver
+type resourceResolverChain []ResourceResolver
+
+// NewResourceResolver creates a ResourceResolver from a ResourceResolver chain
+// It will try to resolve resources by iterating over individual resolvers until one succeeds
+func NewResourceResolver(resolvers ...ResourceResolver) (ResourceResolver, error) {
+	if len(resolvers) == 0 {
+		return nil, errors.New("no resolvers")
+	}
+	for _, resolver := range resolvers {
+		if resolver == nil {
+			return nil, errors.New("at least one resolver is nil")
+		}
+	}
+	return resourceResolverChain(resolvers), nil
+}
+
+func (chain resourceResolverChain) Get(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	var lastErr error
+	for _, resolver := range chain {
+		r, err := resolver.Get(ctx, gvr, namespace, name)
+		if err == nil {
+			return r, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func (chain resourceResolverChain) List(ctx context.Context, gvr schema.GroupVersionResource, namespace string, selector labels.Selector) ([]*unstructured.Unstructured, error) {
+	var lastErr error
+	for _, resolver := range chain {
+		r, err := resolver.List(ctx, gvr, namespace, selector)
+		if err == nil {
+			return r, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
@@ -20,6 +20,21 @@ type PodSecurityChecks struct {
 	Checks []pssutils.PSSCheckResult
 }
 
+// ViolationDetails contains structured details about a validation failure
+type ViolationDetails struct {
+	// Path is the JSON path of the resource value that failed validation,
+	// it is relative to the foreach element when the failure comes from a foreach declaration
+	Path string `json:"path,omitempty"`
+	// Expected is the value expected by the pattern
+	Expected string `json:"expected,omitempty"`
+	// Actual is the value found in the resource
+	Actual string `json:"actual,omitempty"`
+	// ElementIndex is the index of the foreach element that failed validation, if any
+	ElementIndex *int `json:"elementIndex,omitempty"`
+	// ElementKey identifies the foreach element that failed validation, it is the element name when it has one
+	ElementKey string `json:"elementKey,omitempty"`
+}
+
 // RuleResponse details for each rule application
 type RuleResponse struct {
 	// Name is the rule name specified in policy
@@ -46,6 +61,11 @@ type RuleResponse struct {
 	PodSecurityChecks *PodSecurityChecks
 	// Exception is the exception applied (if any)
 	Exception *kyvernov2alpha1.PolicyException
+	// ContextFallbacks contains the names of the context entries that failed to load
+	// and fell back according to their onError behaviour
+	ContextFallbacks []string
+	// Violation contains structured details about the validation failure (only if this is a failed validation rule)
+	Violation *ViolationDetails
 }
 
 // HasStatus checks if rule status is in a given list
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+)
+
+// RuleTrace records how a rule was evaluated against a resource, traces are only recorded in explain mode
+type RuleTrace struct {
+	// Name is the rule name
+	Name string `json:"name"`
+	// Match contains the match and exclude decisions
+	Match MatchTrace `json:"match"`
+	// Context contains the context entries loaded for the rule, including the policy context entries
+	Context []ContextEntryTrace `json:"context,omitempty"`
+	// Preconditions contains the preconditions evaluated with their resolved key and value
+	Preconditions []ConditionTrace `json:"preconditions,omitempty"`
+	// Patterns contains the validation patterns that failed or skipped the rule
+	Patterns []PatternTrace `json:"patterns,omitempty"`
+}
+
+// MatchTrace records the match and exclude decisions of a rule
+type MatchTrace struct {
+	// Matched is true when the resource matched the rule and was not excluded
+	Matched bool `json:"matched"`
+	// Clause is the match clause that selected the resource, e.g. match.any[1]
+	Clause string `json:"clause,omitempty"`
+	// ExcludeClause is the exclude clause that excluded the resource, e.g. exclude.any[0]
+	ExcludeClause string `json:"excludeClause,omitempty"`
+	// Reason explains why the resource did not match the rule
+	Reason string `json:"reason,omitempty"`
+}
+
+// ContextEntryTrace records the loading of a context entry
+type ContextEntryTrace struct {
+	// Name is the context entry name
+	Name string `json:"name"`
+	// Source is the context entry source, e.g. configMap or apiCall
+	Source string `json:"source"`
+	// Status is one of loaded, fallback or error
+	Status string `json:"status"`
+	// Error is the loading error, if any
+	Error string `json:"error,omitempty"`
+}
+
+// ConditionTrace records the evaluation of a condition
+type ConditionTrace struct {
+	// Path is the condition position, e.g. any[0] or all[2]
+	Path string `json:"path"`
+	// Key is the condition key after variables substitution
+	Key string `json:"key"`
+	// Operator is the condition operator
+	Operator string `json:"operator"`
+	// Value is the condition value after variables substitution
+	Value string `json:"value"`
+	// Result is the condition evaluation result
+	Result bool `json:"result"`
+}
+
+// PatternTrace records a validation pattern mismatch
+type PatternTrace struct {
+	// Pattern identifies the pattern, e.g. pattern or anyPattern[1]
+	Pattern string `json:"pattern"`
+	// Path is the JSON path of the element that did not match the pattern
+	Path string `json:"path,omitempty"`
+	// Anchor is the type of anchor that failed (conditional, global or negation), if any
+	Anchor string `json:"anchor,omitempty"`
+	// Message is the mismatch error message
+	Message string `json:"message"`
+}
+
+type ruleTraceKey struct{}
+
+// WithRuleTrace returns a copy of ctx recording the rule evaluation in trace
+func WithRuleTrace(ctx context.Context, trace *RuleTrace) context.Context {
+	return context.WithValue(ctx, ruleTraceKey{}, trace)
+}
+
+// RuleTraceFromContext returns the rule trace recorded in ctx, or nil when explain mode is disabled
+func RuleTraceFromContext(ctx context.Context) *RuleTrace {
+	if ctx == nil {
+		return nil
+	}
+	trace, _ := ctx.Value(ruleTraceKey{}).(*RuleTrace)
+	return trace
+}
+
+// context entry trace statuses
+const (
+	ContextEntryLoaded   = "loaded"
+	ContextEntryFallback = "fallback"
+	ContextEntryError    = "error"
+)
+
+// TraceContextEntry records the loading of a context entry in the rule trace of ctx, if any
+func TraceContextEntry(ctx context.Context, entry kyvernov1.ContextEntry, status string, err error) {
+	trace := RuleTraceFromContext(ctx)
+	if trace == nil {
+		return
+	}
+	entryTrace := ContextEntryTrace{
+		Name:   entry.Name,
+		Source: contextEntrySource(entry),
+		Status: status,
+	}
+	if err != nil {
+		entryTrace.Error = err.Error()
+	}
+	trace.Context = append(trace.Context, entryTrace)
+}
+
+func contextEntrySource(entry kyvernov1.ContextEntry) string {
+	switch {
+	case entry.ConfigMap != nil:
+		return "configMap"
+	case entry.Secret != nil:
+		return "secret"
+	case entry.Resource != nil:
+		return "resource"
+	case entry.APICall != nil:
+		return "apiCall"
+	case entry.ImageRegistry != nil:
+		return "imageRegistry"
+	case entry.Variable != nil:
+		return "variable"
+	case entry.GlobalReference != nil:
+		return "globalReference"
+	}
+	return ""
+}
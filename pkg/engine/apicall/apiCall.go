@@ -3,41 +3,75 @@ package apicall
 import (
 	"bytes"
 	goctx "context"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
 )
 
+const (
+	// maxTransports is the maximum number of HTTP transports kept for service calls using TLS
+	maxTransports = 100
+	// transportTTL is the duration an unused HTTP transport is kept for
+	transportTTL = time.Hour
+)
+
+// transports caches the HTTP transports of service calls by CA bundle and client certificate so that
+// connections are reused across calls
+var transports = cache.NewLRUExpireCache(maxTransports)
+
+// SecretLoader loads the Secret referenced by service call credentials
+type SecretLoader func(ctx goctx.Context, namespace, name string) (*corev1.Secret, error)
+
+// Cache stores API call responses for a limited time
+type Cache interface {
+	// Get returns the cached response for key, or invokes fetch and caches its result for ttl
+	// fetch is given a context of its own as its result may be shared with other callers
+	Get(ctx goctx.Context, key string, ttl time.Duration, fetch func(goctx.Context) ([]byte, error)) ([]byte, error)
+}
+
 type apiCall struct {
-	log     logr.Logger
-	entry   kyvernov1.ContextEntry
-	ctx     goctx.Context
-	jsonCtx context.Interface
-	client  dclient.Interface
+	log           logr.Logger
+	entry         kyvernov1.ContextEntry
+	ctx           goctx.Context
+	jsonCtx       context.Interface
+	client        dclient.Interface
+	cache         Cache
+	secrets       SecretLoader
+	configuration config.Configuration
 }
 
-func New(ctx goctx.Context, entry kyvernov1.ContextEntry, jsonCtx context.Interface, client dclient.Interface, log logr.Logger) (*apiCall, error) {
+// New creates an API call executor, the configuration is used by the JMESPath functions applied to the response
+func New(ctx goctx.Context, entry kyvernov1.ContextEntry, jsonCtx context.Interface, client dclient.Interface, cache Cache, secrets SecretLoader, configuration config.Configuration, log logr.Logger) (*apiCall, error) {
 	if entry.APICall == nil {
 		return nil, fmt.Errorf("missing APICall in context entry %v", entry)
 	}
 
 	return &apiCall{
-		ctx:     ctx,
-		entry:   entry,
-		jsonCtx: jsonCtx,
-		client:  client,
-		log:     log,
+		ctx:           ctx,
+		entry:         entry,
+		jsonCtx:       jsonCtx,
+		client:        client,
+		cache:         cache,
+		secrets:       secrets,
+		configuration: configuration,
+		log:           log,
 	}, nil
 }
 
@@ -47,7 +81,7 @@ func (a *apiCall) Execute() ([]byte, error) {
 		return nil, fmt.Errorf("failed to substitute variables in context entry %s %s: %v", a.entry.Name, a.entry.APICall.URLPath, err)
 	}
 
-	data, err := a.execute(call)
+	data, err := a.executeCached(call)
 	if err != nil {
 		return nil, err
 	}
@@ -60,6 +94,64 @@ func (a *apiCall) Execute() ([]byte, error) {
 	return result, nil
 }
 
+func (a *apiCall) executeCached(call *kyvernov1.APICall) ([]byte, error) {
+	if a.cache == nil || call.CacheTTL == nil || call.CacheTTL.Duration <= 0 {
+		return a.execute(call)
+	}
+
+	key, err := cacheKey(call)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build cache key for APICall %s: %w", a.entry.Name, err)
+	}
+
+	// credentials are loaded before the cache lookup, a cached response must only be returned
+	// to a policy allowed to load the secret used to fetch it
+	var secret *corev1.Secret
+	if call.URLPath == "" && call.Service != nil {
+		secret, err = a.getCredentialsSecret(call.Service.Credentials)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return a.cache.Get(a.ctx, key, call.CacheTTL.Duration, func(ctx goctx.Context) ([]byte, error) {
+		detached := *a
+		detached.ctx = ctx
+		if call.URLPath != "" {
+			return detached.executeK8sAPICall(call.URLPath)
+		}
+		return detached.callService(call.Service, secret)
+	})
+}
+
+// cacheKey identifies a call by its substituted URL, method, body, headers, CA bundle and credentials
+func cacheKey(call *kyvernov1.APICall) (string, error) {
+	key := struct {
+		URL         string                            `json:"url"`
+		Method      kyvernov1.Method                  `json:"method,omitempty"`
+		Data        []kyvernov1.RequestData           `json:"data,omitempty"`
+		Headers     map[string]string                 `json:"headers,omitempty"`
+		CABundle    string                            `json:"caBundle,omitempty"`
+		Credentials *kyvernov1.ServiceCallCredentials `json:"credentials,omitempty"`
+	}{
+		URL: call.URLPath,
+	}
+	if call.URLPath == "" && call.Service != nil {
+		key.URL = call.Service.URL
+		key.Method = call.Service.Method
+		key.Data = call.Service.Data
+		key.Headers = call.Service.Headers
+		key.CABundle = call.Service.CABundle
+		key.Credentials = call.Service.Credentials
+	}
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
 func (a *apiCall) execute(call *kyvernov1.APICall) ([]byte, error) {
 	if call.URLPath != "" {
 		return a.executeK8sAPICall(call.URLPath)
@@ -83,59 +175,123 @@ func (a *apiCall) executeServiceCall(service *kyvernov1.ServiceCall) ([]byte, er
 		return nil, fmt.Errorf("missing service for APICall %s", a.entry.Name)
 	}
 
-	client, err := a.buildHTTPClient(service)
+	secret, err := a.getCredentialsSecret(service.Credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.callService(service, secret)
+}
+
+// callService executes a service call with the credentials loaded from secret, retrying failed requests if configured
+func (a *apiCall) callService(service *kyvernov1.ServiceCall, secret *corev1.Secret) ([]byte, error) {
+	if service == nil {
+		return nil, fmt.Errorf("missing service for APICall %s", a.entry.Name)
+	}
+
+	client, err := a.buildHTTPClient(service, secret)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := a.buildHTTPRequest(service)
+	attempts, backoff := 1, time.Duration(0)
+	// only idempotent requests are retried
+	if service.Retry != nil && (service.Method == "GET" || service.Method == "PUT") {
+		attempts += service.Retry.GetAttempts()
+		backoff = service.Retry.GetBackoff()
+	}
+
+	for attempt := 1; ; attempt++ {
+		body, retry, err := a.doServiceCall(client, service, secret)
+		if err == nil {
+			a.log.Info("executed service APICall", "name", a.entry.Name, "len", len(body))
+			return body, nil
+		}
+		if !retry || attempt >= attempts {
+			return nil, err
+		}
+		a.log.V(3).Info("retrying service APICall", "name", a.entry.Name, "attempt", attempt, "backoff", backoff, "error", err.Error())
+		select {
+		case <-a.ctx.Done():
+			return nil, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// doServiceCall executes a single request and reports whether it can be retried in case of failure
+func (a *apiCall) doServiceCall(client *http.Client, service *kyvernov1.ServiceCall, secret *corev1.Secret) ([]byte, bool, error) {
+	ctx := a.ctx
+	if service.Timeout != nil {
+		var cancel goctx.CancelFunc
+		ctx, cancel = goctx.WithTimeout(ctx, service.Timeout.Duration)
+		defer cancel()
+	}
+
+	req, err := a.buildHTTPRequest(ctx, service, secret)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build HTTP request for APICall %s: %w", a.entry.Name, err)
+		return nil, false, fmt.Errorf("failed to build HTTP request for APICall %s: %w", a.entry.Name, err)
 	}
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute HTTP request for APICall %s: %w", a.entry.Name, err)
+		return nil, true, fmt.Errorf("failed to execute HTTP request for APICall %s: %w", a.entry.Name, err)
 	}
 
+	defer resp.Body.Close()
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return nil, retry, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
 	}
 
-	defer resp.Body.Close()
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read data from APICall %s: %w", a.entry.Name, err)
+		return nil, true, fmt.Errorf("failed to read data from APICall %s: %w", a.entry.Name, err)
 	}
 
-	a.log.Info("executed service APICall", "name", a.entry.Name, "len", len(body))
-	return body, nil
+	return body, false, nil
 }
 
-func (a *apiCall) buildHTTPRequest(service *kyvernov1.ServiceCall) (req *http.Request, err error) {
-	token := a.getToken()
-	defer func() {
-		if token != "" && req != nil {
-			req.Header.Add("Authorization", "Bearer "+token)
-		}
-	}()
-
-	if service.Method == "GET" {
-		req, err = http.NewRequest("GET", service.URL, nil)
-		return
-	}
-
-	if service.Method == "POST" {
+func (a *apiCall) buildHTTPRequest(ctx goctx.Context, service *kyvernov1.ServiceCall, secret *corev1.Secret) (req *http.Request, err error) {
+	switch service.Method {
+	case "GET":
+		req, err = http.NewRequestWithContext(ctx, "GET", service.URL, nil)
+	case "POST", "PUT", "PATCH":
 		data, dataErr := a.buildPostData(service.Data)
 		if dataErr != nil {
 			return nil, dataErr
 		}
 
-		req, err = http.NewRequest("POST", service.URL, data)
-		return
+		req, err = http.NewRequestWithContext(ctx, string(service.Method), service.URL, data)
+		if err == nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+	default:
+		return nil, fmt.Errorf("invalid request type %s for APICall %s", service.Method, a.entry.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if token := a.getToken(); token != "" {
+		req.Header.Add("Authorization", "Bearer "+token)
+	}
+
+	for name, value := range service.Headers {
+		req.Header.Set(name, value)
+	}
+
+	if service.Credentials != nil {
+		switch service.Credentials.Type {
+		case kyvernov1.BearerToken:
+			req.Header.Set("Authorization", "Bearer "+string(secret.Data[corev1.ServiceAccountTokenKey]))
+		case kyvernov1.BasicAuth:
+			req.SetBasicAuth(string(secret.Data[corev1.BasicAuthUsernameKey]), string(secret.Data[corev1.BasicAuthPasswordKey]))
+		}
 	}
 
-	return nil, fmt.Errorf("invalid request type %s for APICall %s", service.Method, a.entry.Name)
+	return req, nil
 }
 
 func (a *apiCall) getToken() string {
@@ -148,24 +304,89 @@ func (a *apiCall) getToken() string {
 	return string(b)
 }
 
-func (a *apiCall) buildHTTPClient(service *kyvernov1.ServiceCall) (*http.Client, error) {
-	if service.CABundle == "" {
+func (a *apiCall) getCredentialsSecret(credentials *kyvernov1.ServiceCallCredentials) (*corev1.Secret, error) {
+	if credentials == nil {
+		return nil, nil
+	}
+
+	if a.secrets == nil {
+		return nil, fmt.Errorf("failed to load credentials for APICall %s: secrets are not available", a.entry.Name)
+	}
+
+	ref := credentials.SecretRef
+	secret, err := a.secrets(a.ctx, ref.Namespace, ref.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load credentials for APICall %s from secret %s/%s: %w", a.entry.Name, ref.Namespace, ref.Name, err)
+	}
+
+	var keys []string
+	switch credentials.Type {
+	case kyvernov1.BearerToken:
+		keys = []string{corev1.ServiceAccountTokenKey}
+	case kyvernov1.BasicAuth:
+		keys = []string{corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey}
+	case kyvernov1.ClientCertificate:
+		keys = []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey}
+	default:
+		return nil, fmt.Errorf("invalid credentials type %s for APICall %s", credentials.Type, a.entry.Name)
+	}
+	for _, key := range keys {
+		if _, ok := secret.Data[key]; !ok {
+			return nil, fmt.Errorf("secret %s/%s for APICall %s is missing key %s", ref.Namespace, ref.Name, a.entry.Name, key)
+		}
+	}
+
+	return secret, nil
+}
+
+func (a *apiCall) buildHTTPClient(service *kyvernov1.ServiceCall, secret *corev1.Secret) (*http.Client, error) {
+	clientCert := service.Credentials != nil && service.Credentials.Type == kyvernov1.ClientCertificate
+	if service.CABundle == "" && !clientCert {
 		return http.DefaultClient, nil
 	}
 
-	caCertPool := x509.NewCertPool()
-	if ok := caCertPool.AppendCertsFromPEM([]byte(service.CABundle)); !ok {
-		return nil, fmt.Errorf("failed to parse PEM CA bundle for APICall %s", a.entry.Name)
+	key := transportKey(service.CABundle, secret, clientCert)
+	if transport, ok := transports.Get(key); ok {
+		return &http.Client{Transport: transport.(*http.Transport)}, nil
 	}
 
-	return &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				RootCAs:    caCertPool,
-				MinVersion: tls.VersionTLS12,
-			},
-		},
-	}, nil
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if service.CABundle != "" {
+		caCertPool := x509.NewCertPool()
+		if ok := caCertPool.AppendCertsFromPEM([]byte(service.CABundle)); !ok {
+			return nil, fmt.Errorf("failed to parse PEM CA bundle for APICall %s", a.entry.Name)
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	if clientCert {
+		cert, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate for APICall %s: %w", a.entry.Name, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transports.Add(key, transport, transportTTL)
+	return &http.Client{Transport: transport}, nil
+}
+
+// transportKey identifies the TLS configuration of a service call by its CA bundle and client certificate
+func transportKey(caBundle string, secret *corev1.Secret, clientCert bool) string {
+	hash := sha256.New()
+	hash.Write([]byte(caBundle))
+	if clientCert {
+		hash.Write([]byte{0})
+		hash.Write(secret.Data[corev1.TLSCertKey])
+		hash.Write([]byte{0})
+		hash.Write(secret.Data[corev1.TLSPrivateKeyKey])
+	}
+	return hex.EncodeToString(hash.Sum(nil))
 }
 
 func (a *apiCall) buildPostData(data []kyvernov1.RequestData) (io.Reader, error) {
@@ -197,7 +418,7 @@ func (a *apiCall) transformAndStore(jsonData []byte) ([]byte, error) {
 		return nil, fmt.Errorf("failed to substitute variables in context entry %s JMESPath %s: %w", a.entry.Name, a.entry.APICall.JMESPath, err)
 	}
 
-	results, err := applyJMESPathJSON(path.(string), jsonData)
+	results, err := applyJMESPathJSON(path.(string), jsonData, a.configuration)
 	if err != nil {
 		return nil, fmt.Errorf("failed to apply JMESPath %s for context entry %s: %w", path, a.entry.Name, err)
 	}
@@ -216,14 +437,14 @@ func (a *apiCall) transformAndStore(jsonData []byte) ([]byte, error) {
 	return contextData, nil
 }
 
-func applyJMESPathJSON(jmesPath string, jsonData []byte) (interface{}, error) {
+func applyJMESPathJSON(jmesPath string, jsonData []byte, configuration config.Configuration) (interface{}, error) {
 	var data interface{}
 	err := json.Unmarshal(jsonData, &data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal JSON: %s, error: %w", string(jsonData), err)
 	}
 
-	jp, err := jmespath.New(jmesPath)
+	jp, err := jmespath.New(jmesPath, jmespath.WithConfiguration(configuration))
 	if err != nil {
 		return nil, fmt.Errorf("failed to compile JMESPath: %s, error: %v", jmesPath, err)
 	}
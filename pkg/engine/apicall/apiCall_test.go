@@ -2,16 +2,22 @@ package apicall
 
 import (
 	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func buildTestServer(responseData []byte) *httptest.Server {
@@ -40,7 +46,7 @@ func Test_serviceGetRequest(t *testing.T) {
 	entry := kyvernov1.ContextEntry{}
 	ctx := enginecontext.NewContext()
 
-	_, err := New(context.TODO(), entry, ctx, nil, logr.Discard())
+	_, err := New(context.TODO(), entry, ctx, nil, nil, nil, nil, logr.Discard())
 	assert.ErrorContains(t, err, "missing APICall")
 
 	entry.Name = "test"
@@ -50,19 +56,19 @@ func Test_serviceGetRequest(t *testing.T) {
 		},
 	}
 
-	call, err := New(context.TODO(), entry, ctx, nil, logr.Discard())
+	call, err := New(context.TODO(), entry, ctx, nil, nil, nil, nil, logr.Discard())
 	assert.NilError(t, err)
 	_, err = call.Execute()
 	assert.ErrorContains(t, err, "invalid request type")
 
 	entry.APICall.Service.Method = "GET"
-	call, err = New(context.TODO(), entry, ctx, nil, logr.Discard())
+	call, err = New(context.TODO(), entry, ctx, nil, nil, nil, nil, logr.Discard())
 	assert.NilError(t, err)
 	_, err = call.Execute()
 	assert.ErrorContains(t, err, "HTTP 404")
 
 	entry.APICall.Service.URL = s.URL + "/resource"
-	call, err = New(context.TODO(), entry, ctx, nil, logr.Discard())
+	call, err = New(context.TODO(), entry, ctx, nil, nil, nil, nil, logr.Discard())
 	assert.NilError(t, err)
 
 	data, err := call.Execute()
@@ -87,7 +93,7 @@ func Test_servicePostRequest(t *testing.T) {
 	}
 
 	ctx := enginecontext.NewContext()
-	call, err := New(context.TODO(), entry, ctx, nil, logr.Discard())
+	call, err := New(context.TODO(), entry, ctx, nil, nil, nil, nil, logr.Discard())
 	assert.NilError(t, err)
 	data, err := call.Execute()
 	assert.NilError(t, err)
@@ -135,7 +141,7 @@ func Test_servicePostRequest(t *testing.T) {
 		},
 	}
 
-	call, err = New(context.TODO(), entry, ctx, nil, logr.Discard())
+	call, err = New(context.TODO(), entry, ctx, nil, nil, nil, nil, logr.Discard())
 	assert.NilError(t, err)
 	data, err = call.Execute()
 	assert.NilError(t, err)
@@ -143,3 +149,259 @@ func Test_servicePostRequest(t *testing.T) {
 	expectedResults := `{"images":["https://ghcr.io/tomcat/tomcat:9","https://ghcr.io/vault/vault:v3","https://ghcr.io/busybox/busybox:latest"]}`
 	assert.Equal(t, string(expectedResults)+"\n", string(data))
 }
+
+type testCache map[string][]byte
+
+func (c testCache) Get(ctx context.Context, key string, _ time.Duration, fetch func(context.Context) ([]byte, error)) ([]byte, error) {
+	if data, ok := c[key]; ok {
+		return data, nil
+	}
+	data, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c[key] = data
+	return data, nil
+}
+
+func Test_serviceCallCache(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/resource", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{ "day": "Tuesday" }`))
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	entry := kyvernov1.ContextEntry{
+		Name: "test",
+		APICall: &kyvernov1.APICall{
+			Service: &kyvernov1.ServiceCall{
+				URL:    s.URL + "/resource",
+				Method: "GET",
+			},
+		},
+	}
+	cache := testCache{}
+	execute := func(entry kyvernov1.ContextEntry) {
+		call, err := New(context.TODO(), entry, enginecontext.NewContext(), nil, cache, nil, nil, logr.Discard())
+		assert.NilError(t, err)
+		data, err := call.Execute()
+		assert.NilError(t, err)
+		assert.Equal(t, `{ "day": "Tuesday" }`, string(data))
+	}
+
+	// without a TTL the cache is not used
+	execute(entry)
+	execute(entry)
+	assert.Equal(t, calls, 2)
+	assert.Equal(t, len(cache), 0)
+
+	entry.APICall.CacheTTL = &metav1.Duration{Duration: time.Minute}
+	execute(entry)
+	execute(entry)
+	assert.Equal(t, calls, 3)
+	assert.Equal(t, len(cache), 1)
+
+	// a different method or body is a different cache key
+	entry.APICall.Service.Method = "POST"
+	execute(entry)
+	entry.APICall.Service.Data = []kyvernov1.RequestData{{Key: "day", Value: &apiextensionsv1.JSON{Raw: []byte(`"today"`)}}}
+	execute(entry)
+	execute(entry)
+	assert.Equal(t, calls, 5)
+	assert.Equal(t, len(cache), 3)
+
+	// so is a different CA bundle
+	key, err := cacheKey(entry.APICall)
+	assert.NilError(t, err)
+	entry.APICall.Service.CABundle = "bundle"
+	otherKey, err := cacheKey(entry.APICall)
+	assert.NilError(t, err)
+	assert.Assert(t, key != otherKey)
+}
+
+func Test_serviceCallCacheCredentials(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/resource", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{ "day": "Tuesday" }`))
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "kyverno"},
+		Data:       map[string][]byte{corev1.ServiceAccountTokenKey: []byte("token")},
+	}
+	// the cluster policy is allowed to load the secret, the namespaced policy is not
+	clusterSecrets := func(_ context.Context, namespace, name string) (*corev1.Secret, error) {
+		return secret, nil
+	}
+	namespacedSecrets := func(_ context.Context, namespace, name string) (*corev1.Secret, error) {
+		return nil, errors.New("loading secrets from namespace kyverno is not allowed")
+	}
+
+	entry := kyvernov1.ContextEntry{
+		Name: "test",
+		APICall: &kyvernov1.APICall{
+			Service: &kyvernov1.ServiceCall{
+				URL:    s.URL + "/resource",
+				Method: "GET",
+				Credentials: &kyvernov1.ServiceCallCredentials{
+					Type:      kyvernov1.BearerToken,
+					SecretRef: corev1.SecretReference{Name: "creds", Namespace: "kyverno"},
+				},
+			},
+			CacheTTL: &metav1.Duration{Duration: time.Minute},
+		},
+	}
+	cache := testCache{}
+
+	call, err := New(context.TODO(), entry, enginecontext.NewContext(), nil, cache, clusterSecrets, nil, logr.Discard())
+	assert.NilError(t, err)
+	data, err := call.Execute()
+	assert.NilError(t, err)
+	assert.Equal(t, `{ "day": "Tuesday" }`, string(data))
+	assert.Equal(t, calls, 1)
+	assert.Equal(t, len(cache), 1)
+
+	// the response cached for the cluster policy is not returned to the namespaced policy
+	call, err = New(context.TODO(), entry, enginecontext.NewContext(), nil, cache, namespacedSecrets, nil, logr.Discard())
+	assert.NilError(t, err)
+	_, err = call.Execute()
+	assert.ErrorContains(t, err, "loading secrets from namespace kyverno is not allowed")
+	assert.Equal(t, calls, 1)
+}
+
+func Test_serviceCallOptions(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/resource", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		username, password, _ := r.BasicAuth()
+		response := map[string]string{
+			"method":   r.Method,
+			"header":   r.Header.Get("X-Request-Id"),
+			"username": username,
+			"password": password,
+		}
+		data, _ := json.Marshal(response)
+		w.Write(data)
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "kyverno"},
+		Data: map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte("admin"),
+			corev1.BasicAuthPasswordKey: []byte("secret"),
+		},
+	}
+	secrets := func(_ context.Context, namespace, name string) (*corev1.Secret, error) {
+		if namespace == secret.Namespace && name == secret.Name {
+			return secret, nil
+		}
+		return nil, errors.New("not found")
+	}
+
+	ctx := enginecontext.NewContext()
+	assert.NilError(t, ctx.AddContextEntry("id", []byte(`"abc"`)))
+
+	entry := kyvernov1.ContextEntry{
+		Name: "test",
+		APICall: &kyvernov1.APICall{
+			Service: &kyvernov1.ServiceCall{
+				URL:     s.URL + "/resource",
+				Method:  "PUT",
+				Headers: map[string]string{"X-Request-Id": "{{ id }}"},
+				Credentials: &kyvernov1.ServiceCallCredentials{
+					Type:      kyvernov1.BasicAuth,
+					SecretRef: corev1.SecretReference{Name: "creds", Namespace: "kyverno"},
+				},
+				Timeout: &metav1.Duration{Duration: time.Second},
+				Retry:   &kyvernov1.ServiceCallRetry{Attempts: 1, Backoff: &metav1.Duration{Duration: time.Millisecond}},
+			},
+		},
+	}
+
+	// not enough retries
+	call, err := New(context.TODO(), entry, ctx, nil, nil, secrets, nil, logr.Discard())
+	assert.NilError(t, err)
+	_, err = call.Execute()
+	assert.ErrorContains(t, err, "HTTP 503")
+	assert.Equal(t, calls, 2)
+
+	calls = 0
+	entry.APICall.Service.Retry.Attempts = 2
+	call, err = New(context.TODO(), entry, ctx, nil, nil, secrets, nil, logr.Discard())
+	assert.NilError(t, err)
+	data, err := call.Execute()
+	assert.NilError(t, err)
+	assert.Equal(t, calls, 3)
+	assert.Equal(t, string(data), `{"header":"abc","method":"PUT","password":"secret","username":"admin"}`)
+
+	entry.APICall.Service.Credentials.SecretRef.Name = "missing"
+	call, err = New(context.TODO(), entry, ctx, nil, nil, secrets, nil, logr.Discard())
+	assert.NilError(t, err)
+	_, err = call.Execute()
+	assert.ErrorContains(t, err, "failed to load credentials for APICall test from secret kyverno/missing")
+}
+
+func Test_serviceCallRetries(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/resource", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	entry := kyvernov1.ContextEntry{
+		Name: "test",
+		APICall: &kyvernov1.APICall{
+			Service: &kyvernov1.ServiceCall{
+				URL:    s.URL + "/resource",
+				Method: "POST",
+				Retry:  &kyvernov1.ServiceCallRetry{Attempts: 10, Backoff: &metav1.Duration{Duration: time.Millisecond}},
+			},
+		},
+	}
+
+	// non idempotent requests are not retried
+	call, err := New(context.TODO(), entry, enginecontext.NewContext(), nil, nil, nil, nil, logr.Discard())
+	assert.NilError(t, err)
+	_, err = call.Execute()
+	assert.ErrorContains(t, err, "HTTP 503")
+	assert.Equal(t, calls, 1)
+
+	// retries are capped
+	calls = 0
+	entry.APICall.Service.Method = "GET"
+	call, err = New(context.TODO(), entry, enginecontext.NewContext(), nil, nil, nil, nil, logr.Discard())
+	assert.NilError(t, err)
+	_, err = call.Execute()
+	assert.ErrorContains(t, err, "HTTP 503")
+	assert.Equal(t, calls, 1+kyvernov1.MaxServiceCallRetryAttempts)
+}
+
+func Test_buildHTTPClientReusesTransports(t *testing.T) {
+	s := httptest.NewTLSServer(http.NewServeMux())
+	defer s.Close()
+	caBundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw}))
+
+	call := &apiCall{entry: kyvernov1.ContextEntry{Name: "test"}, log: logr.Discard()}
+	first, err := call.buildHTTPClient(&kyvernov1.ServiceCall{CABundle: caBundle}, nil)
+	assert.NilError(t, err)
+	second, err := call.buildHTTPClient(&kyvernov1.ServiceCall{CABundle: caBundle}, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, first.Transport == second.Transport)
+}
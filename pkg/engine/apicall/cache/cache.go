@@ -0,0 +1,163 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// DefaultMaxEntries is the default maximum number of responses kept in the cache
+	DefaultMaxEntries = 1000
+	// DefaultMaxEntrySize is the default maximum size (in bytes) of a cached response
+	DefaultMaxEntrySize = 1024 * 1024
+	// DefaultFetchTimeout is the default maximum duration of a shared fetch
+	DefaultFetchTimeout = 30 * time.Second
+)
+
+type cacheItem struct {
+	data    []byte
+	expires time.Time
+}
+
+type cache struct {
+	lock         sync.Mutex
+	items        map[string]cacheItem
+	group        singleflight.Group
+	maxEntries   int
+	maxEntrySize int
+	fetchTimeout time.Duration
+	now          func() time.Time
+	metrics      cacheMetrics
+}
+
+type cacheMetrics struct {
+	hits   instrument.Int64Counter
+	misses instrument.Int64Counter
+}
+
+func newCacheMetrics(logger logr.Logger) cacheMetrics {
+	meter := global.MeterProvider().Meter(metrics.MeterName)
+	hits, err := meter.Int64Counter(
+		"kyverno_api_call_cache_hits",
+		instrument.WithDescription("can be used to track the number of API calls served from the cache"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_api_call_cache_hits")
+	}
+	misses, err := meter.Int64Counter(
+		"kyverno_api_call_cache_misses",
+		instrument.WithDescription("can be used to track the number of API calls not found in the cache"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_api_call_cache_misses")
+	}
+	return cacheMetrics{
+		hits:   hits,
+		misses: misses,
+	}
+}
+
+// New creates an API call cache holding at most maxEntries responses of at most maxEntrySize bytes each.
+// Responses larger than maxEntrySize are returned but not cached.
+// Concurrent calls sharing the same key are deduplicated, the shared fetch runs under its own context
+// so that it is not cancelled when the caller that started it goes away.
+func New(logger logr.Logger, maxEntries int, maxEntrySize int) apicall.Cache {
+	return &cache{
+		items:        map[string]cacheItem{},
+		maxEntries:   maxEntries,
+		maxEntrySize: maxEntrySize,
+		fetchTimeout: DefaultFetchTimeout,
+		now:          time.Now,
+		metrics:      newCacheMetrics(logger),
+	}
+}
+
+func (c *cache) Get(ctx context.Context, key string, ttl time.Duration, fetch func(context.Context) ([]byte, error)) ([]byte, error) {
+	if data, ok := c.lookup(key); ok {
+		c.record(ctx, c.metrics.hits)
+		return data, nil
+	}
+	c.record(ctx, c.metrics.misses)
+	results := c.group.DoChan(key, func() (interface{}, error) {
+		// another caller may have filled the cache while we were waiting
+		if data, ok := c.lookup(key); ok {
+			return data, nil
+		}
+		fetchCtx, cancel := context.WithTimeout(context.Background(), c.fetchTimeout)
+		defer cancel()
+		data, err := fetch(fetchCtx)
+		if err != nil {
+			return nil, err
+		}
+		c.store(key, data, ttl)
+		return data, nil
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-results:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.([]byte), nil
+	}
+}
+
+func (c *cache) lookup(key string) ([]byte, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	item, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(item.expires) {
+		delete(c.items, key)
+		return nil, false
+	}
+	return item.data, true
+}
+
+func (c *cache) store(key string, data []byte, ttl time.Duration) {
+	if ttl <= 0 || c.maxEntries <= 0 || (c.maxEntrySize > 0 && len(data) > c.maxEntrySize) {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.now()
+	if _, ok := c.items[key]; !ok && len(c.items) >= c.maxEntries {
+		c.evict(now)
+	}
+	c.items[key] = cacheItem{
+		data:    data,
+		expires: now.Add(ttl),
+	}
+}
+
+// evict drops expired items, or the item closest to expiry if none has expired
+func (c *cache) evict(now time.Time) {
+	var oldest string
+	for key, item := range c.items {
+		if !now.Before(item.expires) {
+			delete(c.items, key)
+		} else if oldest == "" || item.expires.Before(c.items[oldest].expires) {
+			oldest = key
+		}
+	}
+	if len(c.items) >= c.maxEntries && oldest != "" {
+		delete(c.items, oldest)
+	}
+}
+
+func (c *cache) record(ctx context.Context, counter instrument.Int64Counter) {
+	if counter != nil {
+		counter.Add(ctx, 1)
+	}
+}
@@ -0,0 +1,134 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"gotest.tools/assert"
+)
+
+func newTestCache(maxEntries int, maxEntrySize int) (*cache, *time.Time) {
+	now := time.Now()
+	c := New(logr.Discard(), maxEntries, maxEntrySize).(*cache)
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func fetcher(calls *int32, data string) func(context.Context) ([]byte, error) {
+	return func(context.Context) ([]byte, error) {
+		atomic.AddInt32(calls, 1)
+		return []byte(data), nil
+	}
+}
+
+func TestCache_TTL(t *testing.T) {
+	c, now := newTestCache(DefaultMaxEntries, DefaultMaxEntrySize)
+	var calls int32
+	data, err := c.Get(context.TODO(), "key", time.Minute, fetcher(&calls, "foo"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "foo")
+	data, err = c.Get(context.TODO(), "key", time.Minute, fetcher(&calls, "bar"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "foo")
+	assert.Equal(t, calls, int32(1))
+	*now = now.Add(time.Minute)
+	data, err = c.Get(context.TODO(), "key", time.Minute, fetcher(&calls, "bar"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "bar")
+	assert.Equal(t, calls, int32(2))
+}
+
+func TestCache_Errors(t *testing.T) {
+	c, _ := newTestCache(DefaultMaxEntries, DefaultMaxEntrySize)
+	_, err := c.Get(context.TODO(), "key", time.Minute, func(context.Context) ([]byte, error) {
+		return nil, errors.New("failed")
+	})
+	assert.Error(t, err, "failed")
+	assert.Equal(t, len(c.items), 0)
+}
+
+func TestCache_SizeLimits(t *testing.T) {
+	c, now := newTestCache(2, 3)
+	var calls int32
+	// responses larger than the limit are not cached
+	_, err := c.Get(context.TODO(), "large", time.Minute, fetcher(&calls, "large"))
+	assert.NilError(t, err)
+	assert.Equal(t, len(c.items), 0)
+	// the entry closest to expiry is evicted when the cache is full
+	_, err = c.Get(context.TODO(), "a", time.Minute, fetcher(&calls, "a"))
+	assert.NilError(t, err)
+	_, err = c.Get(context.TODO(), "b", 2*time.Minute, fetcher(&calls, "b"))
+	assert.NilError(t, err)
+	_, err = c.Get(context.TODO(), "c", 2*time.Minute, fetcher(&calls, "c"))
+	assert.NilError(t, err)
+	assert.Equal(t, len(c.items), 2)
+	_, ok := c.items["a"]
+	assert.Assert(t, !ok)
+	// expired entries are evicted first
+	*now = now.Add(2 * time.Minute)
+	_, err = c.Get(context.TODO(), "d", time.Minute, fetcher(&calls, "d"))
+	assert.NilError(t, err)
+	assert.Equal(t, len(c.items), 1)
+}
+
+func TestCache_Singleflight(t *testing.T) {
+	c, _ := newTestCache(DefaultMaxEntries, DefaultMaxEntrySize)
+	var calls int32
+	release := make(chan struct{})
+	fetch := func(context.Context) ([]byte, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return []byte("foo"), nil
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := c.Get(context.TODO(), "key", time.Minute, fetch)
+			assert.NilError(t, err)
+			assert.Equal(t, string(data), "foo")
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, atomic.LoadInt32(&calls), int32(1))
+}
+
+func TestCache_DetachedFetch(t *testing.T) {
+	c, _ := newTestCache(DefaultMaxEntries, DefaultMaxEntrySize)
+	ctx, cancel := context.WithCancel(context.TODO())
+	started, release := make(chan struct{}), make(chan struct{})
+	fetchErr := make(chan error, 1)
+	go func() {
+		_, err := c.Get(ctx, "key", time.Minute, func(fetchCtx context.Context) ([]byte, error) {
+			close(started)
+			<-release
+			fetchErr <- fetchCtx.Err()
+			return []byte("foo"), nil
+		})
+		assert.Error(t, err, context.Canceled.Error())
+	}()
+	<-started
+	// the caller that started the fetch goes away, the fetch goes on for the other callers
+	cancel()
+	var calls int32
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		data, err := c.Get(context.TODO(), "key", time.Minute, fetcher(&calls, "bar"))
+		assert.NilError(t, err)
+		assert.Equal(t, string(data), "foo")
+	}()
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	<-done
+	assert.NilError(t, <-fetchErr)
+	assert.Equal(t, atomic.LoadInt32(&calls), int32(0))
+}
@@ -35,9 +35,12 @@ func (e *engine) filterRules(
 	policy := policyContext.Policy()
 	resp := engineapi.NewPolicyResponse()
 	applyRules := policy.GetSpec().GetApplyRules()
+	policyContext.JSONContext().Checkpoint()
+	defer policyContext.JSONContext().Restore()
+	loadPolicyContext := e.policyContextLoader(policyContext)
 	for _, rule := range autogen.ComputeRules(policy) {
 		logger := internal.LoggerWithRule(logger, rule)
-		if ruleResp := e.filterRule(rule, logger, policyContext); ruleResp != nil {
+		if ruleResp := e.filterRule(rule, logger, policyContext, loadPolicyContext); ruleResp != nil {
 			resp.Rules = append(resp.Rules, *ruleResp)
 			if applyRules == kyvernov1.ApplyOne && ruleResp.Status != engineapi.RuleStatusSkip {
 				break
@@ -51,6 +54,7 @@ func (e *engine) filterRule(
 	rule kyvernov1.Rule,
 	logger logr.Logger,
 	policyContext engineapi.PolicyContext,
+	loadPolicyContext func(context.Context) ([]string, error),
 ) *engineapi.RuleResponse {
 	if !rule.HasGenerate() && !rule.IsMutateExisting() {
 		return nil
@@ -96,10 +100,17 @@ func (e *engine) filterRule(
 		return nil
 	}
 
+	policyFallbacks, err := loadPolicyContext(context.TODO())
+	if err != nil {
+		logger.V(4).Info("cannot add policy external data to the context", "reason", err.Error())
+		return nil
+	}
+
 	policyContext.JSONContext().Checkpoint()
 	defer policyContext.JSONContext().Restore()
 
-	if err := internal.LoadContext(context.TODO(), e, policyContext, rule); err != nil {
+	ruleFallbacks, err := internal.LoadContext(context.TODO(), e, policyContext, rule)
+	if err != nil {
 		logger.V(4).Info("cannot add external data to the context", "reason", err.Error())
 		return nil
 	}
@@ -134,5 +145,6 @@ func (e *engine) filterRule(
 			ProcessingTime: time.Since(startTime),
 			Timestamp:      startTime.Unix(),
 		},
+		ContextFallbacks: internal.MergeContextFallbacks(policyFallbacks, ruleFallbacks...),
 	}
 }
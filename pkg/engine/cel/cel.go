@@ -0,0 +1,163 @@
+package cel
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	ObjectKey    = "object"
+	OldObjectKey = "oldObject"
+	RequestKey   = "request"
+	ParamsKey    = "params"
+)
+
+var identifier = regexp.MustCompile(`^[_a-zA-Z][_a-zA-Z0-9]*$`)
+
+// IsReservedVariable returns true if the name is a variable provided by kyverno to CEL expressions.
+func IsReservedVariable(name string) bool {
+	return name == ObjectKey || name == OldObjectKey || name == RequestKey || name == ParamsKey
+}
+
+// ContextVariables returns the names of the context entries that can be referenced from CEL expressions.
+// Entries that are not valid CEL identifiers or that conflict with a reserved variable are ignored,
+// names declared more than once (policy and rule context) are returned once.
+func ContextVariables(entries ...[]kyvernov1.ContextEntry) []string {
+	var names []string
+	seen := sets.New[string]()
+	for _, entries := range entries {
+		for _, entry := range entries {
+			if identifier.MatchString(entry.Name) && !IsReservedVariable(entry.Name) && !seen.Has(entry.Name) {
+				seen.Insert(entry.Name)
+				names = append(names, entry.Name)
+			}
+		}
+	}
+	return names
+}
+
+// UnsupportedContextEntries returns the names of the context entries that can't be referenced from CEL expressions
+// because they are not valid CEL identifiers or conflict with a reserved variable.
+func UnsupportedContextEntries(entries ...[]kyvernov1.ContextEntry) []string {
+	var names []string
+	seen := sets.New[string]()
+	for _, entries := range entries {
+		for _, entry := range entries {
+			if (!identifier.MatchString(entry.Name) || IsReservedVariable(entry.Name)) && !seen.Has(entry.Name) {
+				seen.Insert(entry.Name)
+				names = append(names, entry.Name)
+			}
+		}
+	}
+	return names
+}
+
+// CompileError adds the context entries that are not declared in the CEL environment to a compilation error,
+// an expression referencing one of them fails with an undeclared reference.
+func CompileError(err error, entries ...[]kyvernov1.ContextEntry) error {
+	if names := UnsupportedContextEntries(entries...); len(names) != 0 {
+		return fmt.Errorf("%w (context entries %s are not valid CEL identifiers or are reserved and can't be referenced)", err, strings.Join(names, ", "))
+	}
+	return err
+}
+
+// NewEnv creates a CEL environment declaring the kyverno variables and the given context variables.
+func NewEnv(contextVariables ...string) (*cel.Env, error) {
+	options := []cel.EnvOption{
+		cel.HomogeneousAggregateLiterals(),
+		cel.CrossTypeNumericComparisons(true),
+		cel.Variable(ObjectKey, cel.DynType),
+		cel.Variable(OldObjectKey, cel.DynType),
+		cel.Variable(RequestKey, cel.DynType),
+		cel.Variable(ParamsKey, cel.DynType),
+		ext.Strings(),
+		ext.Encoders(),
+	}
+	for _, name := range contextVariables {
+		options = append(options, cel.Variable(name, cel.DynType))
+	}
+	return cel.NewEnv(options...)
+}
+
+// Compile parses and checks an expression, it must evaluate to a boolean.
+func Compile(env *cel.Env, expression string) (cel.Program, error) {
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if outputType := ast.OutputType(); outputType != cel.BoolType && outputType != cel.DynType {
+		return nil, fmt.Errorf("expression must evaluate to a bool, found %s", outputType)
+	}
+	return env.Program(ast)
+}
+
+// Validate compiles all expressions of a validate.cel declaration against the policy and rule context entries.
+func Validate(celValidation *kyvernov1.CEL, contextEntries ...[]kyvernov1.ContextEntry) (string, error) {
+	if len(celValidation.Expressions) == 0 {
+		return "expressions", fmt.Errorf("at least one expression is required")
+	}
+	env, err := NewEnv(ContextVariables(contextEntries...)...)
+	if err != nil {
+		return "", err
+	}
+	for i, expression := range celValidation.Expressions {
+		if _, err := Compile(env, expression.Expression); err != nil {
+			return fmt.Sprintf("expressions[%d].expression", i), CompileError(err, contextEntries...)
+		}
+	}
+	return "", nil
+}
+
+// Evaluate runs a compiled program and returns its boolean result.
+func Evaluate(program cel.Program, vars map[string]interface{}) (bool, error) {
+	activation := make(map[string]interface{}, len(vars))
+	for name, value := range vars {
+		activation[name] = normalize(value)
+	}
+	out, _, err := program.Eval(activation)
+	if err != nil {
+		return false, err
+	}
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression must evaluate to a bool, found %s", out.Type().TypeName())
+	}
+	return result, nil
+}
+
+// normalize converts integral float values (as produced by JSON decoding) to integers,
+// so that they compare naturally with integer literals in expressions.
+func normalize(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(typed))
+		for k, v := range typed {
+			out[k] = normalize(v)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(typed))
+		for i, v := range typed {
+			out[i] = normalize(v)
+		}
+		return out
+	case float64:
+		if typed == math.Trunc(typed) && math.Abs(typed) < math.MaxInt64 {
+			return int64(typed)
+		}
+		return typed
+	case int:
+		return int64(typed)
+	case int32:
+		return int64(typed)
+	default:
+		return value
+	}
+}
@@ -0,0 +1,87 @@
+package cel
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+)
+
+func TestContextVariables(t *testing.T) {
+	entries := []kyvernov1.ContextEntry{
+		{Name: "deployments"},
+		{Name: "object"},
+		{Name: "my-config"},
+		{Name: "config.data"},
+		{Name: "_limits"},
+	}
+	assert.DeepEqual(t, ContextVariables(entries), []string{"deployments", "_limits"})
+	assert.DeepEqual(t, ContextVariables([]kyvernov1.ContextEntry{{Name: "limits"}}, entries), []string{"limits", "deployments", "_limits"})
+	assert.DeepEqual(t, ContextVariables(entries, []kyvernov1.ContextEntry{{Name: "deployments"}}), []string{"deployments", "_limits"})
+}
+
+func TestEvaluate(t *testing.T) {
+	env, err := NewEnv("limits")
+	assert.NilError(t, err)
+	testCases := []struct {
+		expression string
+		vars       map[string]interface{}
+		expected   bool
+		wantErr    bool
+	}{
+		{
+			expression: "object.spec.replicas == limits.replicas",
+			vars: map[string]interface{}{
+				"object": map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(3)}},
+				"limits": map[string]interface{}{"replicas": 3.0},
+			},
+			expected: true,
+		},
+		{
+			expression: "oldObject == null || object.metadata.name == oldObject.metadata.name",
+			vars: map[string]interface{}{
+				"object":    map[string]interface{}{"metadata": map[string]interface{}{"name": "foo"}},
+				"oldObject": nil,
+			},
+			expected: true,
+		},
+		{
+			expression: "object.metadata.name.startsWith('kube-')",
+			vars: map[string]interface{}{
+				"object": map[string]interface{}{"metadata": map[string]interface{}{"name": "foo"}},
+			},
+			expected: false,
+		},
+		{
+			expression: "object.spec.replicas > 1",
+			vars: map[string]interface{}{
+				"object": map[string]interface{}{"spec": map[string]interface{}{}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			program, err := Compile(env, tc.expression)
+			assert.NilError(t, err)
+			result, err := Evaluate(program, tc.vars)
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+				assert.Equal(t, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestValidateUnsupportedContextEntries(t *testing.T) {
+	celValidation := &kyvernov1.CEL{
+		Expressions: []kyvernov1.CELExpression{{Expression: "size(my_config) > 0"}},
+	}
+	path, err := Validate(celValidation, []kyvernov1.ContextEntry{{Name: "my-config"}, {Name: "object"}})
+	assert.Equal(t, path, "expressions[0].expression")
+	assert.ErrorContains(t, err, "context entries my-config, object are not valid CEL identifiers or are reserved")
+	_, err = Validate(celValidation, []kyvernov1.ContextEntry{{Name: "my_config"}})
+	assert.NilError(t, err)
+}
@@ -3,6 +3,7 @@ package context
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 
@@ -10,6 +11,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/logging"
 	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -18,6 +20,14 @@ import (
 
 var logger = logging.WithName("context")
 
+const (
+	// RedactedValue replaces sensitive values when they are redacted
+	RedactedValue = "**REDACTED**"
+	// minRedactedLength is the minimum length of sensitive values redacted inside a string,
+	// shorter values are only redacted when they are the whole string so that they don't scramble every message
+	minRedactedLength = 6
+)
+
 // EvalInterface is used to query and inspect context data
 type EvalInterface interface {
 	// Query accepts a JMESPath expression and returns matching data
@@ -28,6 +38,9 @@ type EvalInterface interface {
 	// it return `true`. If the data has not changed it returns false. If either
 	// request.object or request.oldObject are not found, an error is returned.
 	HasChanged(jmespath string) (bool, error)
+
+	// Redact replaces the sensitive values registered in the context with a placeholder
+	Redact(s string) string
 }
 
 // Interface to manage context operations
@@ -90,6 +103,9 @@ type Interface interface {
 	// Reset sets the internal state to the last checkpoint, but does not remove the checkpoint.
 	Reset()
 
+	// AddSensitiveValue registers a value that must not be disclosed, it is kept across checkpoints
+	AddSensitiveValue(value string)
+
 	EvalInterface
 
 	// AddJSON  merges the json with context
@@ -102,18 +118,21 @@ type context struct {
 	jsonRaw            []byte
 	jsonRawCheckpoints [][]byte
 	images             map[string]map[string]apiutils.ImageInfo
+	sensitiveValues    []string
+	jmespathOptions    []jmespath.Option
 }
 
-// NewContext returns a new context
-func NewContext() Interface {
-	return NewContextFromRaw([]byte(`{}`))
+// NewContext returns a new context, the options configure the JMESPath functions available to queries
+func NewContext(opts ...jmespath.Option) Interface {
+	return NewContextFromRaw([]byte(`{}`), opts...)
 }
 
 // NewContextFromRaw returns a new context initialized with raw data
-func NewContextFromRaw(raw []byte) Interface {
+func NewContextFromRaw(raw []byte, opts ...jmespath.Option) Interface {
 	ctx := context{
 		jsonRaw:            raw,
 		jsonRawCheckpoints: make([][]byte, 0),
+		jmespathOptions:    opts,
 	}
 	return &ctx
 }
@@ -331,3 +350,44 @@ func (ctx *context) reset(remove bool) {
 		ctx.jsonRawCheckpoints = ctx.jsonRawCheckpoints[:n]
 	}
 }
+
+// AddSensitiveValue registers a value that must not be disclosed
+func (ctx *context) AddSensitiveValue(value string) {
+	if value == "" {
+		return
+	}
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+	for _, v := range ctx.sensitiveValues {
+		if v == value {
+			return
+		}
+	}
+	ctx.sensitiveValues = append(ctx.sensitiveValues, value)
+	// longest values first so that a value containing another one is fully redacted
+	sort.SliceStable(ctx.sensitiveValues, func(i, j int) bool {
+		return len(ctx.sensitiveValues[i]) > len(ctx.sensitiveValues[j])
+	})
+}
+
+// Redact replaces the sensitive values registered in the context with a placeholder
+func (ctx *context) Redact(s string) string {
+	ctx.mutex.RLock()
+	defer ctx.mutex.RUnlock()
+	if len(ctx.sensitiveValues) == 0 || s == "" {
+		return s
+	}
+	oldnew := make([]string, 0, 2*len(ctx.sensitiveValues))
+	for _, v := range ctx.sensitiveValues {
+		if v == s {
+			return RedactedValue
+		}
+		if len(v) >= minRedactedLength {
+			oldnew = append(oldnew, v, RedactedValue)
+		}
+	}
+	if len(oldnew) == 0 {
+		return s
+	}
+	return strings.NewReplacer(oldnew...).Replace(s)
+}
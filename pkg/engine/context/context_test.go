@@ -119,3 +119,27 @@ func Test_addResourceAndUserContext(t *testing.T) {
 		t.Error("expected result does not match")
 	}
 }
+
+func Test_Redact(t *testing.T) {
+	ctx := NewContext()
+	if got := ctx.Redact("password is s3cr3t"); got != "password is s3cr3t" {
+		t.Errorf("unexpected redaction: %s", got)
+	}
+	ctx.AddSensitiveValue("s3cr3t")
+	ctx.AddSensitiveValue("s3cr3t-token")
+	ctx.AddSensitiveValue("")
+	ctx.Checkpoint()
+	ctx.Restore()
+	expected := "password is **REDACTED**, token is **REDACTED**"
+	if got := ctx.Redact("password is s3cr3t, token is s3cr3t-token"); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+	// short values are only redacted when they are the whole string
+	ctx.AddSensitiveValue("1")
+	if got := ctx.Redact("1 of 10 replicas"); got != "1 of 10 replicas" {
+		t.Errorf("unexpected redaction: %s", got)
+	}
+	if got := ctx.Redact("1"); got != RedactedValue {
+		t.Errorf("expected %s, got %s", RedactedValue, got)
+	}
+}
@@ -16,7 +16,7 @@ func (ctx *context) Query(query string) (interface{}, error) {
 		return nil, fmt.Errorf("invalid query (nil)")
 	}
 	// compile the query
-	queryPath, err := jmespath.New(query)
+	queryPath, err := jmespath.New(query, ctx.jmespathOptions...)
 	if err != nil {
 		logger.Error(err, "incorrect query", "query", query)
 		return nil, fmt.Errorf("incorrect query %s: %v", query, err)
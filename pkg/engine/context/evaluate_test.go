@@ -3,6 +3,8 @@ package context
 import (
 	"testing"
 
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/stretchr/testify/assert"
 	admissionv1 "k8s.io/api/admission/v1"
 )
@@ -65,3 +67,23 @@ func createTestContext(obj, oldObj string) Interface {
 	ctx.AddRequest(request)
 	return ctx
 }
+
+type defaultRegistryConfiguration struct {
+	config.Configuration
+}
+
+func (defaultRegistryConfiguration) GetDefaultRegistry() string {
+	return "registry.example.com"
+}
+
+func TestQueryConfiguration(t *testing.T) {
+	ctx := NewContext()
+	val, err := ctx.Query("image_normalize('nginx')")
+	assert.NoError(t, err)
+	assert.Equal(t, "docker.io/library/nginx:latest", val)
+
+	ctx = NewContext(jmespath.WithConfiguration(defaultRegistryConfiguration{config.NewDefaultConfiguration()}))
+	val, err = ctx.Query("image_normalize('nginx')")
+	assert.NoError(t, err)
+	assert.Equal(t, "registry.example.com/nginx:latest", val)
+}
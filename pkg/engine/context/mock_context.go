@@ -98,3 +98,7 @@ func (i InvalidVariableError) Error() string {
 func (ctx *MockContext) HasChanged(_ string) (bool, error) {
 	return false, nil
 }
+
+func (ctx *MockContext) Redact(s string) string {
+	return s
+}
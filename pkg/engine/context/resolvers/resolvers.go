@@ -40,3 +40,33 @@ func NewClientBasedResolver(client kubernetes.Interface) (engineapi.ConfigmapRes
 func (c *clientBasedResolver) Get(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error) {
 	return c.kubeClient.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
 }
+
+type informerBasedSecretResolver struct {
+	lister corev1listers.SecretLister
+}
+
+func NewInformerBasedSecretResolver(lister corev1listers.SecretLister) (engineapi.SecretResolver, error) {
+	if lister == nil {
+		return nil, errors.New("lister must not be nil")
+	}
+	return &informerBasedSecretResolver{lister}, nil
+}
+
+func (i *informerBasedSecretResolver) Get(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	return i.lister.Secrets(namespace).Get(name)
+}
+
+type clientBasedSecretResolver struct {
+	kubeClient kubernetes.Interface
+}
+
+func NewClientBasedSecretResolver(client kubernetes.Interface) (engineapi.SecretResolver, error) {
+	if client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+	return &clientBasedSecretResolver{client}, nil
+}
+
+func (c *clientBasedSecretResolver) Get(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	return c.kubeClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+}
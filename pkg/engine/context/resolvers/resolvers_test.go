@@ -10,6 +10,11 @@ import (
 	"gotest.tools/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	kubefake "k8s.io/client-go/kubernetes/fake"
@@ -189,3 +194,113 @@ func TestNewClientBasedResolver(t *testing.T) {
 		})
 	}
 }
+
+func createSecret(ctx context.Context, client *kubefake.Clientset, name string, addLabel bool) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{"password": []byte("s3cr3t")},
+	}
+	if addLabel {
+		secret.ObjectMeta.Labels = map[string]string{LabelCacheKey: "true"}
+	}
+	_, err := client.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
+	return err
+}
+
+func Test_SecretResolverChain(t *testing.T) {
+	client := newEmptyFakeClient()
+	ctx := context.TODO()
+	assert.NilError(t, createSecret(ctx, client, "cached", true))
+	assert.NilError(t, createSecret(ctx, client, "uncached", false))
+	informer := initialiseInformer(client)
+	informerBasedResolver, err := NewInformerBasedSecretResolver(informer.Core().V1().Secrets().Lister())
+	assert.NilError(t, err)
+	clientBasedResolver, err := NewClientBasedSecretResolver(client)
+	assert.NilError(t, err)
+	stop := make(chan struct{})
+	defer close(stop)
+	informer.Start(stop)
+	informer.WaitForCacheSync(stop)
+	_, err = informerBasedResolver.Get(ctx, namespace, "cached")
+	assert.NilError(t, err)
+	_, err = informerBasedResolver.Get(ctx, namespace, "uncached")
+	assert.Error(t, err, "secret \"uncached\" not found")
+	resolvers, err := api.NewNamespacedResourceResolver(informerBasedResolver, clientBasedResolver)
+	assert.NilError(t, err)
+	secret, err := resolvers.Get(ctx, namespace, "uncached")
+	assert.NilError(t, err)
+	assert.Equal(t, string(secret.Data["password"]), "s3cr3t")
+	_, err = resolvers.Get(ctx, namespace, "unknown")
+	assert.Error(t, err, "secrets \"unknown\" not found")
+}
+
+func Test_ResourceResolverChain(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	newConfigMap := func(name string, labels map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+				"labels":    labels,
+			},
+		}}
+	}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "ConfigMapList"},
+		newConfigMap("foo", map[string]interface{}{"app": "foo"}),
+		newConfigMap("bar", map[string]interface{}{"app": "bar"}),
+	)
+	informerBasedResolver, err := NewInformerBasedResourceResolver(ctx, client, 15*time.Minute, DefaultInformerIdleTimeout, gvr)
+	assert.NilError(t, err)
+	clientBasedResolver, err := NewClientBasedResourceResolver(client)
+	assert.NilError(t, err)
+	resolvers, err := api.NewResourceResolver(informerBasedResolver, clientBasedResolver)
+	assert.NilError(t, err)
+	// the first lookup starts the informer and falls back to the client
+	object, err := resolvers.Get(ctx, gvr, namespace, "foo")
+	assert.NilError(t, err)
+	assert.Equal(t, object.GetName(), "foo")
+	// wait for the informer cache to be populated
+	var list []*unstructured.Unstructured
+	for i := 0; i < 50; i++ {
+		if list, err = informerBasedResolver.List(ctx, gvr, namespace, labels.Everything()); err == nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	assert.NilError(t, err)
+	assert.Equal(t, len(list), 2)
+	list, err = resolvers.List(ctx, gvr, namespace, labels.SelectorFromSet(labels.Set{"app": "bar"}))
+	assert.NilError(t, err)
+	assert.Equal(t, len(list), 1)
+	assert.Equal(t, list[0].GetName(), "bar")
+	_, err = resolvers.Get(ctx, gvr, namespace, "unknown")
+	assert.Error(t, err, "configmaps \"unknown\" not found")
+	// resources without a configured informer are not served by the informer based resolver
+	_, err = informerBasedResolver.Get(ctx, schema.GroupVersionResource{Version: "v1", Resource: "secrets"}, namespace, "foo")
+	assert.Error(t, err, "no informer configured for /v1, Resource=secrets")
+	// idle informers are stopped
+	resolver := informerBasedResolver.(*informerBasedResourceResolver)
+	resolver.informers[gvr].lastUsed = time.Now().Add(-DefaultInformerIdleTimeout)
+	resolver.stopIdleInformers()
+	assert.Equal(t, len(resolver.informers), 0)
+}
+
+func Test_ParseGroupVersionResources(t *testing.T) {
+	gvrs, err := ParseGroupVersionResources("apps/v1/deployments, v1/configmaps,")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, gvrs, []schema.GroupVersionResource{
+		{Group: "apps", Version: "v1", Resource: "deployments"},
+		{Version: "v1", Resource: "configmaps"},
+	})
+	_, err = ParseGroupVersionResources("deployments")
+	assert.Error(t, err, "invalid group version resource deployments, expected [group/]version/resource")
+}
@@ -0,0 +1,200 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// DefaultInformerIdleTimeout is the duration after which an unused resource informer is stopped
+const DefaultInformerIdleTimeout = 30 * time.Minute
+
+type resourceInformer struct {
+	informer informers.GenericInformer
+	stop     context.CancelFunc
+	lastUsed time.Time
+}
+
+type informerBasedResourceResolver struct {
+	ctx          context.Context
+	client       dynamic.Interface
+	resyncPeriod time.Duration
+	idleTimeout  time.Duration
+	gvrs         sets.Set[schema.GroupVersionResource]
+	lock         sync.Mutex
+	informers    map[schema.GroupVersionResource]*resourceInformer
+}
+
+// NewInformerBasedResourceResolver creates a resolver serving the given group version resources from informers,
+// requests for other resources fail so that the next resolver in the chain is used.
+// An informer is started the first time its group version resource is requested and stopped once it has not
+// been used for idleTimeout. Requests fail until the informer cache is synced.
+func NewInformerBasedResourceResolver(ctx context.Context, client dynamic.Interface, resyncPeriod time.Duration, idleTimeout time.Duration, gvrs ...schema.GroupVersionResource) (engineapi.ResourceResolver, error) {
+	if client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+	if idleTimeout <= 0 {
+		return nil, errors.New("idle timeout must be positive")
+	}
+	resolver := &informerBasedResourceResolver{
+		ctx:          ctx,
+		client:       client,
+		resyncPeriod: resyncPeriod,
+		idleTimeout:  idleTimeout,
+		gvrs:         sets.New(gvrs...),
+		informers:    map[schema.GroupVersionResource]*resourceInformer{},
+	}
+	go wait.Until(resolver.stopIdleInformers, idleTimeout/2, ctx.Done())
+	return resolver, nil
+}
+
+// ParseGroupVersionResources parses a comma separated list of group version resources,
+// e.g. "apps/v1/deployments,v1/configmaps"
+func ParseGroupVersionResources(value string) ([]schema.GroupVersionResource, error) {
+	var gvrs []schema.GroupVersionResource
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		index := strings.LastIndex(item, "/")
+		if index <= 0 || index == len(item)-1 {
+			return nil, fmt.Errorf("invalid group version resource %s, expected [group/]version/resource", item)
+		}
+		gv, err := schema.ParseGroupVersion(item[:index])
+		if err != nil {
+			return nil, fmt.Errorf("invalid group version resource %s: %v", item, err)
+		}
+		gvrs = append(gvrs, gv.WithResource(item[index+1:]))
+	}
+	return gvrs, nil
+}
+
+func (i *informerBasedResourceResolver) informer(gvr schema.GroupVersionResource) (informers.GenericInformer, error) {
+	if !i.gvrs.Has(gvr) {
+		return nil, fmt.Errorf("no informer configured for %s", gvr)
+	}
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	entry, ok := i.informers[gvr]
+	if !ok {
+		ctx, stop := context.WithCancel(i.ctx)
+		entry = &resourceInformer{
+			informer: dynamicinformer.NewFilteredDynamicInformer(i.client, gvr, metav1.NamespaceAll, i.resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, nil),
+			stop:     stop,
+		}
+		go entry.informer.Informer().Run(ctx.Done())
+		i.informers[gvr] = entry
+	}
+	entry.lastUsed = time.Now()
+	if !entry.informer.Informer().HasSynced() {
+		return nil, fmt.Errorf("informer for %s is not synced", gvr)
+	}
+	return entry.informer, nil
+}
+
+// stopIdleInformers stops the informers that have not been used for the idle timeout
+func (i *informerBasedResourceResolver) stopIdleInformers() {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	for gvr, entry := range i.informers {
+		if time.Since(entry.lastUsed) >= i.idleTimeout {
+			entry.stop()
+			delete(i.informers, gvr)
+		}
+	}
+}
+
+func (i *informerBasedResourceResolver) Get(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	informer, err := i.informer(gvr)
+	if err != nil {
+		return nil, err
+	}
+	var object runtime.Object
+	if namespace == "" {
+		object, err = informer.Lister().Get(name)
+	} else {
+		object, err = informer.Lister().ByNamespace(namespace).Get(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toUnstructured(object)
+}
+
+func (i *informerBasedResourceResolver) List(ctx context.Context, gvr schema.GroupVersionResource, namespace string, selector labels.Selector) ([]*unstructured.Unstructured, error) {
+	informer, err := i.informer(gvr)
+	if err != nil {
+		return nil, err
+	}
+	var objects []runtime.Object
+	if namespace == "" {
+		objects, err = informer.Lister().List(selector)
+	} else {
+		objects, err = informer.Lister().ByNamespace(namespace).List(selector)
+	}
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*unstructured.Unstructured, 0, len(objects))
+	for _, object := range objects {
+		u, err := toUnstructured(object)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, u)
+	}
+	return list, nil
+}
+
+type clientBasedResourceResolver struct {
+	client dynamic.Interface
+}
+
+// NewClientBasedResourceResolver creates a resolver fetching resources from the API server
+func NewClientBasedResourceResolver(client dynamic.Interface) (engineapi.ResourceResolver, error) {
+	if client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+	return &clientBasedResourceResolver{client}, nil
+}
+
+func (c *clientBasedResourceResolver) Get(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	return c.client.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (c *clientBasedResourceResolver) List(ctx context.Context, gvr schema.GroupVersionResource, namespace string, selector labels.Selector) ([]*unstructured.Unstructured, error) {
+	list, err := c.client.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*unstructured.Unstructured, 0, len(list.Items))
+	for i := range list.Items {
+		items = append(items, &list.Items[i])
+	}
+	return items, nil
+}
+
+func toUnstructured(object runtime.Object) (*unstructured.Unstructured, error) {
+	u, ok := object.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type %T", object)
+	}
+	return u, nil
+}
@@ -120,16 +120,102 @@ func (e *engine) ContextLoader(
 	policy kyvernov1.PolicyInterface,
 	rule kyvernov1.Rule,
 ) engineapi.EngineContextLoader {
+	return func(ctx context.Context, contextEntries []kyvernov1.ContextEntry, jsonContext enginecontext.Interface) ([]string, error) {
+		return e.loadContext(ctx, policy, rule, contextEntries, jsonContext)
+	}
+}
+
+// loadContext loads context entries in the JSON context and returns the names of the entries that fell back
+// according to their onError behaviour.
+func (e *engine) loadContext(
+	ctx context.Context,
+	policy kyvernov1.PolicyInterface,
+	rule kyvernov1.Rule,
+	contextEntries []kyvernov1.ContextEntry,
+	jsonContext enginecontext.Interface,
+) ([]string, error) {
 	loader := e.contextLoader(policy, rule)
-	return func(ctx context.Context, contextEntries []kyvernov1.ContextEntry, jsonContext enginecontext.Interface) error {
-		return loader.Load(
-			ctx,
-			e.client,
-			e.rclient,
-			contextEntries,
-			jsonContext,
+	return loader.Load(
+		ctx,
+		e.client,
+		e.rclient,
+		contextEntries,
+		jsonContext,
+	)
+}
+
+// policyContextLoader returns a function loading the policy level context entries in the JSON context,
+// entries are loaded at most once per policy evaluation and the result is shared by all rules.
+func (e *engine) policyContextLoader(policyContext engineapi.PolicyContext) func(context.Context) ([]string, error) {
+	var loaded bool
+	var fallbacks []string
+	var err error
+	// policy context entries are traced once and reported in the trace of every rule
+	var policyTrace engineapi.RuleTrace
+	return func(ctx context.Context) ([]string, error) {
+		ruleTrace := engineapi.RuleTraceFromContext(ctx)
+		if !loaded {
+			loaded = true
+			policy := policyContext.Policy()
+			if contextEntries := policy.GetSpec().Context; len(contextEntries) != 0 {
+				if ruleTrace != nil {
+					ctx = engineapi.WithRuleTrace(ctx, &policyTrace)
+				}
+				fallbacks, err = e.loadContext(ctx, policy, kyvernov1.Rule{}, contextEntries, policyContext.JSONContext())
+			}
+		}
+		if ruleTrace != nil {
+			ruleTrace.Context = append(ruleTrace.Context, policyTrace.Context...)
+		}
+		return fallbacks, err
+	}
+}
+
+// newRuleTrace returns a context recording the evaluation trace of a rule when explain mode is enabled
+// and the rule is processed by the current engine operation
+func newRuleTrace(
+	ctx context.Context,
+	policyContext engineapi.PolicyContext,
+	rule kyvernov1.Rule,
+	processed bool,
+) (context.Context, *engineapi.RuleTrace) {
+	if !policyContext.Explain() || !processed {
+		return ctx, nil
+	}
+	ruleTrace := &engineapi.RuleTrace{Name: rule.Name}
+	return engineapi.WithRuleTrace(ctx, ruleTrace), ruleTrace
+}
+
+// explainMatch returns the match and exclude decisions of a rule for either the new or old resource
+func explainMatch(
+	rule kyvernov1.Rule,
+	policyContext engineapi.PolicyContext,
+	resource unstructured.Unstructured,
+	err error,
+) engineapi.MatchTrace {
+	gvk, subresource := policyContext.ResourceKind()
+	matchTrace := engineapi.MatchTrace{Matched: err == nil}
+	for _, resource := range []unstructured.Unstructured{resource, policyContext.OldResource()} {
+		clause, excludeClause := engineutils.ExplainResourceDescription(
+			resource,
+			rule,
+			policyContext.AdmissionInfo(),
+			policyContext.NamespaceLabels(),
+			gvk,
+			subresource,
+			policyContext.Operation(),
 		)
+		if clause != "" {
+			matchTrace.Clause, matchTrace.ExcludeClause = clause, excludeClause
+			if excludeClause == "" {
+				break
+			}
+		}
 	}
+	if err != nil {
+		matchTrace.Reason = err.Error()
+	}
+	return matchTrace
 }
 
 // matches checks if either the new or old resource satisfies the filter conditions defined in the rule
@@ -179,14 +265,20 @@ func (e *engine) invokeRuleHandler(
 	resource unstructured.Unstructured,
 	rule kyvernov1.Rule,
 	ruleType engineapi.RuleType,
+	loadPolicyContext func(context.Context) ([]string, error),
 ) (unstructured.Unstructured, []engineapi.RuleResponse) {
 	return tracing.ChildSpan2(
 		ctx,
 		"pkg/engine",
 		fmt.Sprintf("RULE %s", rule.Name),
 		func(ctx context.Context, span trace.Span) (unstructured.Unstructured, []engineapi.RuleResponse) {
+			ruleTrace := engineapi.RuleTraceFromContext(ctx)
 			// check if resource and rule match
-			if err := matches(rule, policyContext, resource); err != nil {
+			err := matches(rule, policyContext, resource)
+			if ruleTrace != nil {
+				ruleTrace.Match = explainMatch(rule, policyContext, resource, err)
+			}
+			if err != nil {
 				logger.V(4).Info("rule not matched", "reason", err.Error())
 				return resource, nil
 			}
@@ -199,9 +291,20 @@ func (e *engine) invokeRuleHandler(
 				if ruleResp := e.hasPolicyExceptions(logger, ruleType, policyContext, rule); ruleResp != nil {
 					return resource, handlers.RuleResponses(ruleResp)
 				}
+				// load policy context, it is kept for the next rules
+				policyFallbacks, err := loadPolicyContext(ctx)
+				if err != nil {
+					if _, ok := err.(gojmespath.NotFoundError); ok {
+						logger.V(3).Info("failed to load policy context", "reason", err.Error())
+					} else {
+						logger.Error(err, "failed to load policy context")
+					}
+					return resource, handlers.RuleResponses(internal.RuleError(rule, ruleType, "failed to load policy context", err))
+				}
+				defer redactRuleTrace(policyContext.JSONContext(), ruleTrace)
 				// load rule context
-				contextLoader := e.ContextLoader(policyContext.Policy(), rule)
-				if err := contextLoader(ctx, rule.Context, policyContext.JSONContext()); err != nil {
+				ruleFallbacks, err := e.loadContext(ctx, policyContext.Policy(), rule, rule.Context, policyContext.JSONContext())
+				if err != nil {
 					if _, ok := err.(gojmespath.NotFoundError); ok {
 						logger.V(3).Info("failed to load context", "reason", err.Error())
 					} else {
@@ -209,18 +312,69 @@ func (e *engine) invokeRuleHandler(
 					}
 					return resource, handlers.RuleResponses(internal.RuleError(rule, ruleType, "failed to load context", err))
 				}
-				// check preconditions
-				preconditionsPassed, err := internal.CheckPreconditions(logger, policyContext.JSONContext(), rule.GetAnyAllConditions())
+				fallbacks := internal.MergeContextFallbacks(policyFallbacks, ruleFallbacks...)
+				// check preconditions, they are evaluated one by one when they are traced
+				var preconditionsPassed bool
+				if ruleTrace != nil {
+					preconditionsPassed, ruleTrace.Preconditions, err = internal.ExplainPreconditions(logger, policyContext.JSONContext(), rule.GetAnyAllConditions())
+				} else {
+					preconditionsPassed, err = internal.CheckPreconditions(logger, policyContext.JSONContext(), rule.GetAnyAllConditions())
+				}
 				if err != nil {
-					return resource, handlers.RuleResponses(internal.RuleError(rule, ruleType, "failed to evaluate preconditions", err))
+					return resource, withContextFallbacks(fallbacks, redactRuleResponses(policyContext.JSONContext(), handlers.RuleResponses(internal.RuleError(rule, ruleType, "failed to evaluate preconditions", err))))
 				}
 				if !preconditionsPassed {
-					return resource, handlers.RuleResponses(internal.RuleSkip(rule, ruleType, "preconditions not met"))
+					return resource, withContextFallbacks(fallbacks, handlers.RuleResponses(internal.RuleSkip(rule, ruleType, "preconditions not met")))
 				}
 				// process handler
-				return handler.Process(ctx, logger, policyContext, resource, rule, contextLoader)
+				resource, responses := handler.Process(ctx, logger, policyContext, resource, rule, e.ContextLoader(policyContext.Policy(), rule))
+				return resource, withContextFallbacks(fallbacks, redactRuleResponses(policyContext.JSONContext(), responses))
 			}
 			return resource, nil
 		},
 	)
 }
+
+// withContextFallbacks records the policy and rule context entries that fell back in the rule responses,
+// along with the foreach or target context entries already recorded by the rule handler
+func withContextFallbacks(fallbacks []string, responses []engineapi.RuleResponse) []engineapi.RuleResponse {
+	if len(fallbacks) == 0 {
+		return responses
+	}
+	for i := range responses {
+		responses[i].ContextFallbacks = internal.MergeContextFallbacks(fallbacks, responses[i].ContextFallbacks...)
+	}
+	return responses
+}
+
+// redactRuleResponses removes sensitive context data (e.g. loaded from secrets) from the rule messages
+// and violation details, they end up in admission responses, events and policy reports
+func redactRuleResponses(jsonContext enginecontext.Interface, responses []engineapi.RuleResponse) []engineapi.RuleResponse {
+	for i := range responses {
+		responses[i].Message = jsonContext.Redact(responses[i].Message)
+		if violation := responses[i].Violation; violation != nil {
+			violation.Path = jsonContext.Redact(violation.Path)
+			violation.Expected = jsonContext.Redact(violation.Expected)
+			violation.Actual = jsonContext.Redact(violation.Actual)
+			violation.ElementKey = jsonContext.Redact(violation.ElementKey)
+		}
+	}
+	return responses
+}
+
+// redactRuleTrace removes sensitive context data from the rule ruleTrace
+func redactRuleTrace(jsonContext enginecontext.Interface, ruleTrace *engineapi.RuleTrace) {
+	if ruleTrace == nil {
+		return
+	}
+	for i := range ruleTrace.Context {
+		ruleTrace.Context[i].Error = jsonContext.Redact(ruleTrace.Context[i].Error)
+	}
+	for i := range ruleTrace.Preconditions {
+		ruleTrace.Preconditions[i].Key = jsonContext.Redact(ruleTrace.Preconditions[i].Key)
+		ruleTrace.Preconditions[i].Value = jsonContext.Redact(ruleTrace.Preconditions[i].Value)
+	}
+	for i := range ruleTrace.Patterns {
+		ruleTrace.Patterns[i].Message = jsonContext.Redact(ruleTrace.Patterns[i].Message)
+	}
+}
@@ -52,7 +52,7 @@ func ForceMutate(
 			}
 		} else {
 			m := r.Mutation
-			patchedResource, err = applyPatches(r.Name, m.GetPatchStrategicMerge(), m.PatchesJSON6902, patchedResource, logger)
+			patchedResource, err = applyPatches(r.Name, m.GetPatchStrategicMerge(), m.GetPatchMerge(), m.PatchesJSON6902, patchedResource, logger)
 			if err != nil {
 				return patchedResource, err
 			}
@@ -74,7 +74,7 @@ func applyForEachMutate(name string, foreach []kyvernov1.ForEachMutation, resour
 			return applyForEachMutate(name, nestedForEach, patchedResource, logger)
 		}
 
-		patchedResource, err = applyPatches(name, fe.GetPatchStrategicMerge(), fe.PatchesJSON6902, patchedResource, logger)
+		patchedResource, err = applyPatches(name, fe.GetPatchStrategicMerge(), fe.GetPatchMerge(), fe.PatchesJSON6902, patchedResource, logger)
 		if err != nil {
 			return resource, err
 		}
@@ -83,8 +83,8 @@ func applyForEachMutate(name string, foreach []kyvernov1.ForEachMutation, resour
 	return patchedResource, nil
 }
 
-func applyPatches(name string, mergePatch apiextensions.JSON, jsonPatch string, resource unstructured.Unstructured, logger logr.Logger) (unstructured.Unstructured, error) {
-	patcher := mutate.NewPatcher(name, mergePatch, jsonPatch, resource, logger)
+func applyPatches(name string, strategicMergePatch, mergePatch apiextensions.JSON, jsonPatch string, resource unstructured.Unstructured, logger logr.Logger) (unstructured.Unstructured, error) {
+	patcher := mutate.NewPatcher(name, strategicMergePatch, mergePatch, jsonPatch, resource, logger)
 	resp, mutatedResource := patcher.Patch()
 	if resp.Status != engineapi.RuleStatusPass {
 		return mutatedResource, fmt.Errorf("mutate status %q: %s", resp.Status, resp.Message)
@@ -16,9 +16,12 @@ func (e *engine) generateResponse(
 	policyContext engineapi.PolicyContext,
 ) engineapi.PolicyResponse {
 	resp := engineapi.NewPolicyResponse()
+	policyContext.JSONContext().Checkpoint()
+	defer policyContext.JSONContext().Restore()
+	loadPolicyContext := e.policyContextLoader(policyContext)
 	for _, rule := range autogen.ComputeRules(policyContext.Policy()) {
 		logger := internal.LoggerWithRule(logger, rule)
-		if ruleResp := e.filterRule(rule, logger, policyContext); ruleResp != nil {
+		if ruleResp := e.filterRule(rule, logger, policyContext, loadPolicyContext); ruleResp != nil {
 			resp.Rules = append(resp.Rules, *ruleResp)
 		}
 	}
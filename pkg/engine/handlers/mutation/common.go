@@ -22,6 +22,8 @@ type forEachMutator struct {
 	resource      resourceInfo
 	nesting       int
 	contextLoader engineapi.EngineContextLoader
+	// fallbacks contains the foreach context entries that fell back according to their onError behaviour
+	fallbacks []string
 }
 
 func (f *forEachMutator) mutateForEach(ctx context.Context) *mutate.Response {
@@ -84,9 +86,11 @@ func (f *forEachMutator) mutateElements(ctx context.Context, foreach kyvernov1.F
 			return mutate.NewErrorResponse(fmt.Sprintf("failed to add element to mutate.foreach[%d].context", index), err)
 		}
 
-		if err := f.contextLoader(ctx, foreach.Context, policyContext.JSONContext()); err != nil {
+		fallbacks, err := f.contextLoader(ctx, foreach.Context, policyContext.JSONContext())
+		if err != nil {
 			return mutate.NewErrorResponse(fmt.Sprintf("failed to load to mutate.foreach[%d].context", index), err)
 		}
+		f.fallbacks = internal.MergeContextFallbacks(f.fallbacks, fallbacks...)
 
 		preconditionsPassed, err := internal.CheckPreconditions(f.logger, policyContext.JSONContext(), foreach.AnyAllConditions)
 		if err != nil {
@@ -116,6 +120,7 @@ func (f *forEachMutator) mutateElements(ctx context.Context, foreach kyvernov1.F
 			}
 
 			mutateResp = m.mutateForEach(ctx)
+			f.fallbacks = internal.MergeContextFallbacks(f.fallbacks, m.fallbacks...)
 		} else {
 			mutateResp = mutate.ForEach(f.rule.Name, foreach, policyContext, patchedResource.unstructured, element, f.logger)
 		}
@@ -8,13 +8,17 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"github.com/kyverno/kyverno/pkg/utils/wildcard"
 	"go.uber.org/multierr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // resourceInfo contains the Unstructured resource, and if the resource is a subresource, it contains its name and its
@@ -41,7 +45,17 @@ func loadTargets(client dclient.Interface, targets []kyvernov1.TargetResourceSpe
 			errors = append(errors, err)
 			continue
 		}
-		objs, err := getTargets(client, spec, ctx)
+		selector, err := resolveSelector(logger, ctx.JSONContext(), targets[i].Selector)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("failed to resolve target[%d].Selector: %v", i, err))
+			continue
+		}
+		namespaceSelector, err := resolveSelector(logger, ctx.JSONContext(), targets[i].NamespaceSelector)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("failed to resolve target[%d].NamespaceSelector: %v", i, err))
+			continue
+		}
+		objs, err := getTargets(client, spec, selector, namespaceSelector, ctx)
 		if err != nil {
 			errors = append(errors, err)
 			continue
@@ -82,7 +96,38 @@ func resolveSpec(i int, target kyvernov1.TargetResourceSpec, ctx engineapi.Polic
 	}, nil
 }
 
-func getTargets(client dclient.Interface, target kyvernov1.ResourceSpec, ctx engineapi.PolicyContext) ([]resourceInfo, error) {
+// resolveSelector substitutes the variables in a target label selector, a nil selector resolves to nil
+func resolveSelector(logger logr.Logger, jsonContext enginecontext.EvalInterface, selector *metav1.LabelSelector) (labels.Selector, error) {
+	if selector == nil {
+		return nil, nil
+	}
+	selector, err := variables.SubstituteAllInType(logger, jsonContext, selector)
+	if err != nil {
+		return nil, err
+	}
+	return metav1.LabelSelectorAsSelector(selector)
+}
+
+// selectNamespaces returns the names of the namespaces matching a namespace selector
+func selectNamespaces(client dclient.Interface, selector labels.Selector) (sets.Set[string], error) {
+	list, err := client.GetDynamicInterface().Resource(corev1.SchemeGroupVersion.WithResource("namespaces")).List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %v", err)
+	}
+	namespaces := sets.New[string]()
+	for _, namespace := range list.Items {
+		namespaces.Insert(namespace.GetName())
+	}
+	return namespaces, nil
+}
+
+// inNamespaces checks a target resource is in one of the namespaces selected by the namespace selector,
+// a nil set means the target has no namespace selector
+func inNamespaces(obj unstructured.Unstructured, namespaces sets.Set[string]) bool {
+	return namespaces == nil || namespaces.Has(obj.GetNamespace())
+}
+
+func getTargets(client dclient.Interface, target kyvernov1.ResourceSpec, selector labels.Selector, namespaceSelector labels.Selector, ctx engineapi.PolicyContext) ([]resourceInfo, error) {
 	var targetObjects []resourceInfo
 	namespace := target.Namespace
 	name := target.Name
@@ -91,6 +136,18 @@ func getTargets(client dclient.Interface, target kyvernov1.ResourceSpec, ctx eng
 	if policy.IsNamespaced() {
 		namespace = policy.GetNamespace()
 	}
+	var namespaces sets.Set[string]
+	if namespaceSelector != nil {
+		selected, err := selectNamespaces(client, namespaceSelector)
+		if err != nil {
+			return nil, err
+		}
+		namespaces = selected
+	}
+	listOptions := metav1.ListOptions{}
+	if selector != nil {
+		listOptions.LabelSelector = selector.String()
+	}
 	group, version, kind, subresource := kubeutils.ParseKindSelector(target.APIVersion + "/" + target.Kind)
 	gvrss, err := client.Discovery().FindResources(group, version, kind, subresource)
 	if err != nil {
@@ -102,8 +159,8 @@ func getTargets(client dclient.Interface, target kyvernov1.ResourceSpec, ctx eng
 		if gvrs.SubResource != "" {
 			sub = []string{gvrs.SubResource}
 		}
-		// we can use `GET` directly
-		if namespace != "" && name != "" && !wildcard.ContainsWildcard(namespace) && !wildcard.ContainsWildcard(name) {
+		// we can use `GET` directly, selectors are applied on the listed (parent) resources
+		if namespace != "" && name != "" && !wildcard.ContainsWildcard(namespace) && !wildcard.ContainsWildcard(name) && selector == nil && namespaces == nil {
 			var obj *unstructured.Unstructured
 			var err error
 			obj, err = dyn.Namespace(namespace).Get(context.TODO(), name, metav1.GetOptions{}, sub...)
@@ -118,24 +175,24 @@ func getTargets(client dclient.Interface, target kyvernov1.ResourceSpec, ctx eng
 		} else {
 			// we can use `LIST`
 			if gvrs.SubResource == "" {
-				list, err := dyn.List(context.TODO(), metav1.ListOptions{})
+				list, err := dyn.List(context.TODO(), listOptions)
 				if err != nil {
 					return nil, err
 				}
 				for _, obj := range list.Items {
-					if match(namespace, name, obj.GetNamespace(), obj.GetName()) {
+					if match(namespace, name, obj.GetNamespace(), obj.GetName()) && inNamespaces(obj, namespaces) {
 						targetObjects = append(targetObjects, resourceInfo{unstructured: obj})
 					}
 				}
 			} else {
 				// we need to use `LIST` / `GET`
-				list, err := dyn.List(context.TODO(), metav1.ListOptions{})
+				list, err := dyn.List(context.TODO(), listOptions)
 				if err != nil {
 					return nil, err
 				}
 				var parentObjects []unstructured.Unstructured
 				for _, obj := range list.Items {
-					if match(namespace, name, obj.GetNamespace(), obj.GetName()) {
+					if match(namespace, name, obj.GetNamespace(), obj.GetName()) && inNamespaces(obj, namespaces) {
 						parentObjects = append(parentObjects, obj)
 					}
 				}
@@ -4,7 +4,13 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/go-logr/logr"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 func Test_match(t *testing.T) {
@@ -215,3 +221,83 @@ func Test_match(t *testing.T) {
 		assert.Equal(t, test.expectedResult, res, fmt.Sprintf("test %s failed", test.testName))
 	}
 }
+
+func Test_resolveSelector(t *testing.T) {
+	jsonContext := enginecontext.NewContext()
+	assert.NoError(t, jsonContext.AddResource(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{
+				"team": "x",
+			},
+		},
+	}))
+	tests := []struct {
+		testName       string
+		selector       *metav1.LabelSelector
+		labels         map[string]string
+		expectedResult bool
+		expectedErr    bool
+	}{
+		{
+			testName:       "nil-selector",
+			selector:       nil,
+			labels:         map[string]string{"team": "y"},
+			expectedResult: true,
+		},
+		{
+			testName:       "match-labels",
+			selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"team": "x"}},
+			labels:         map[string]string{"team": "x", "app": "nginx"},
+			expectedResult: true,
+		},
+		{
+			testName:       "match-labels-variable",
+			selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"team": "{{ request.object.metadata.labels.team }}"}},
+			labels:         map[string]string{"team": "x"},
+			expectedResult: true,
+		},
+		{
+			testName:       "match-labels-variable-no-match",
+			selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"team": "{{ request.object.metadata.labels.team }}"}},
+			labels:         map[string]string{"team": "y"},
+			expectedResult: false,
+		},
+		{
+			testName: "match-expressions-variable",
+			selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      "team",
+				Operator: metav1.LabelSelectorOpNotIn,
+				Values:   []string{"{{ request.object.metadata.labels.team }}"},
+			}}},
+			labels:         map[string]string{"team": "y"},
+			expectedResult: true,
+		},
+		{
+			testName:    "invalid-selector",
+			selector:    &metav1.LabelSelector{MatchLabels: map[string]string{"team": "not a label value"}},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		selector, err := resolveSelector(logr.Discard(), jsonContext, test.selector)
+		if test.expectedErr {
+			assert.Error(t, err, fmt.Sprintf("test %s failed", test.testName))
+			continue
+		}
+		assert.NoError(t, err, fmt.Sprintf("test %s failed", test.testName))
+		res := selector == nil || selector.Matches(labels.Set(test.labels))
+		assert.Equal(t, test.expectedResult, res, fmt.Sprintf("test %s failed", test.testName))
+	}
+}
+
+func Test_inNamespaces(t *testing.T) {
+	var obj unstructured.Unstructured
+	obj.SetNamespace("prod")
+	assert.True(t, inNamespaces(obj, nil))
+	assert.True(t, inNamespaces(obj, sets.New("prod", "staging")))
+	assert.False(t, inNamespaces(obj, sets.New("staging")))
+	assert.False(t, inNamespaces(obj, sets.New[string]()))
+	obj.SetNamespace("")
+	assert.False(t, inNamespaces(obj, sets.New("prod")))
+}
@@ -51,7 +51,8 @@ func (h mutateExistingHandler) Process(
 			continue
 		}
 		// load target specific context
-		if err := contextLoader(ctx, target.context, policyContext.JSONContext()); err != nil {
+		fallbacks, err := contextLoader(ctx, target.context, policyContext.JSONContext())
+		if err != nil {
 			rr := internal.RuleError(rule, engineapi.Mutation, "failed to load context", err)
 			responses = append(responses, *rr)
 			continue
@@ -60,11 +61,13 @@ func (h mutateExistingHandler) Process(
 		preconditionsPassed, err := internal.CheckPreconditions(logger, policyContext.JSONContext(), target.preconditions)
 		if err != nil {
 			rr := internal.RuleError(rule, engineapi.Mutation, "failed to evaluate preconditions", err)
+			rr.ContextFallbacks = fallbacks
 			responses = append(responses, *rr)
 			continue
 		}
 		if !preconditionsPassed {
 			rr := internal.RuleSkip(rule, engineapi.Mutation, "preconditions not met")
+			rr.ContextFallbacks = fallbacks
 			responses = append(responses, *rr)
 			continue
 		}
@@ -82,10 +85,12 @@ func (h mutateExistingHandler) Process(
 				nesting:       0,
 			}
 			mutateResp = m.mutateForEach(ctx)
+			fallbacks = internal.MergeContextFallbacks(fallbacks, m.fallbacks...)
 		} else {
 			mutateResp = mutate.Mutate(&rule, policyContext.JSONContext(), target.unstructured, logger)
 		}
 		if ruleResponse := buildRuleResponse(&rule, mutateResp, target.resourceInfo); ruleResponse != nil {
+			ruleResponse.ContextFallbacks = fallbacks
 			responses = append(responses, *ruleResponse)
 		}
 	}
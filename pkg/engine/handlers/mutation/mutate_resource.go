@@ -39,6 +39,7 @@ func (h mutateResourceHandler) Process(
 	}
 	// logger.V(4).Info("apply rule to resource", "resource namespace", patchedResource.unstructured.GetNamespace(), "resource name", patchedResource.unstructured.GetName())
 	var mutateResp *mutate.Response
+	var fallbacks []string
 	if rule.Mutation.ForEachMutation != nil {
 		m := &forEachMutator{
 			rule:          rule,
@@ -50,11 +51,16 @@ func (h mutateResourceHandler) Process(
 			nesting:       0,
 		}
 		mutateResp = m.mutateForEach(ctx)
+		fallbacks = m.fallbacks
 	} else {
 		mutateResp = mutate.Mutate(&rule, policyContext.JSONContext(), resource, logger)
 	}
 	if mutateResp == nil {
 		return resource, nil
 	}
-	return mutateResp.PatchedResource, handlers.RuleResponses(buildRuleResponse(&rule, mutateResp, resourceInfo))
+	ruleResponse := buildRuleResponse(&rule, mutateResp, resourceInfo)
+	if ruleResponse != nil {
+		ruleResponse.ContextFallbacks = fallbacks
+	}
+	return mutateResp.PatchedResource, handlers.RuleResponses(ruleResponse)
 }
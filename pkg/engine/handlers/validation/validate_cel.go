@@ -0,0 +1,150 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	celgo "github.com/google/cel-go/cel"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/cel"
+	"github.com/kyverno/kyverno/pkg/engine/handlers"
+	"github.com/kyverno/kyverno/pkg/engine/internal"
+	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+const (
+	// maxCompiledRules is the maximum number of rules whose compiled CEL programs are kept
+	maxCompiledRules = 1000
+	// compiledRuleTTL is the duration compiled CEL programs of an unused rule are kept for
+	compiledRuleTTL = time.Hour
+)
+
+// compiledRules caches the compiled CEL programs of rules by policy UID, resource version and rule name,
+// an updated policy has a new resource version and its expressions are compiled again
+var compiledRules = cache.NewLRUExpireCache(maxCompiledRules)
+
+type compiledRule struct {
+	contextVariables []string
+	programs         []celgo.Program
+}
+
+type validateCELHandler struct{}
+
+func NewValidateCELHandler() (handlers.Handler, error) {
+	return validateCELHandler{}, nil
+}
+
+func (h validateCELHandler) Process(
+	ctx context.Context,
+	logger logr.Logger,
+	policyContext engineapi.PolicyContext,
+	resource unstructured.Unstructured,
+	rule kyvernov1.Rule,
+	_ engineapi.EngineContextLoader,
+) (unstructured.Unstructured, []engineapi.RuleResponse) {
+	if engineutils.IsDeleteRequest(policyContext) {
+		logger.V(3).Info("skipping CEL validation on deleted resource")
+		return resource, nil
+	}
+	compiled, err := compileRule(policyContext.Policy(), rule)
+	if err != nil {
+		return resource, handlers.RuleResponses(internal.RuleError(rule, engineapi.Validation, "failed to compile CEL expressions", err))
+	}
+	vars, err := celVariables(policyContext, resource, rule, compiled.contextVariables)
+	if err != nil {
+		return resource, handlers.RuleResponses(internal.RuleError(rule, engineapi.Validation, "failed to build CEL variables", err))
+	}
+	for i, expression := range rule.Validation.CEL.Expressions {
+		passed, err := cel.Evaluate(compiled.programs[i], vars)
+		if err != nil {
+			return resource, handlers.RuleResponses(internal.RuleError(rule, engineapi.Validation, fmt.Sprintf("failed to evaluate expression[%d]", i), err))
+		}
+		if !passed {
+			logger.V(3).Info("CEL expression evaluated to false", "expression", expression.Expression)
+			msg := buildCELErrorMessage(logger, policyContext, rule, expression)
+			return resource, handlers.RuleResponses(internal.RuleResponse(rule, engineapi.Validation, msg, engineapi.RuleStatusFail))
+		}
+	}
+	msg := fmt.Sprintf("validation rule '%s' passed.", rule.Name)
+	return resource, handlers.RuleResponses(internal.RulePass(rule, engineapi.Validation, msg))
+}
+
+func compileRule(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) (*compiledRule, error) {
+	// policies without a UID (e.g. in the CLI) can not be told apart and are not cached
+	key := ""
+	if policy.GetUID() != "" {
+		key = string(policy.GetUID()) + "/" + policy.GetResourceVersion() + "/" + rule.Name
+		if compiled, ok := compiledRules.Get(key); ok {
+			return compiled.(*compiledRule), nil
+		}
+	}
+	contextVariables := cel.ContextVariables(policy.GetSpec().Context, rule.Context)
+	env, err := cel.NewEnv(contextVariables...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+	compiled := &compiledRule{contextVariables: contextVariables}
+	for i, expression := range rule.Validation.CEL.Expressions {
+		program, err := cel.Compile(env, expression.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("expression[%d]: %w", i, cel.CompileError(err, policy.GetSpec().Context, rule.Context))
+		}
+		compiled.programs = append(compiled.programs, program)
+	}
+	if key != "" {
+		compiledRules.Add(key, compiled, compiledRuleTTL)
+	}
+	return compiled, nil
+}
+
+func celVariables(policyContext engineapi.PolicyContext, resource unstructured.Unstructured, rule kyvernov1.Rule, contextVariables []string) (map[string]interface{}, error) {
+	vars := map[string]interface{}{
+		cel.ObjectKey:    resource.Object,
+		cel.OldObjectKey: nil,
+		cel.ParamsKey:    rule.Validation.CEL.GetParams(),
+	}
+	if oldResource := policyContext.OldResource(); !engineutils.IsEmptyUnstructured(&oldResource) {
+		vars[cel.OldObjectKey] = oldResource.Object
+	}
+	jsonContext := policyContext.JSONContext()
+	request, err := jsonContext.Query(cel.RequestKey)
+	if err != nil {
+		return nil, err
+	}
+	vars[cel.RequestKey] = request
+	for _, name := range contextVariables {
+		value, err := jsonContext.Query(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query context entry %s: %w", name, err)
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+func buildCELErrorMessage(logger logr.Logger, policyContext engineapi.PolicyContext, rule kyvernov1.Rule, expression kyvernov1.CELExpression) string {
+	msg := expression.Message
+	if msg == "" {
+		msg = rule.Validation.Message
+	}
+	if msg == "" {
+		return fmt.Sprintf("validation error: rule %s failed, expression '%s' evaluated to false", rule.Name, expression.Expression)
+	}
+	raw, err := variables.SubstituteAll(logger, policyContext.JSONContext(), msg)
+	if err != nil {
+		logger.V(2).Info("failed to substitute variables in message", "error", err)
+		return msg
+	}
+	switch typed := raw.(type) {
+	case string:
+		return typed
+	default:
+		return "the produced message didn't resolve to a string, check your policy definition."
+	}
+}
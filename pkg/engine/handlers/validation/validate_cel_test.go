@@ -0,0 +1,50 @@
+package validation
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+)
+
+func Test_compileRule(t *testing.T) {
+	rule := kyvernov1.Rule{
+		Name: "replicas",
+		Validation: kyvernov1.Validation{
+			CEL: &kyvernov1.CEL{
+				Expressions: []kyvernov1.CELExpression{{Expression: "object.spec.replicas <= 5"}},
+			},
+		},
+	}
+	policy := &kyvernov1.ClusterPolicy{}
+	policy.Name = "check-replicas"
+	policy.UID = "uid"
+	policy.ResourceVersion = "1"
+	policy.Spec.Rules = []kyvernov1.Rule{rule}
+
+	compiled, err := compileRule(policy, rule)
+	assert.NilError(t, err)
+	assert.Equal(t, len(compiled.programs), 1)
+	cached, err := compileRule(policy, rule)
+	assert.NilError(t, err)
+	assert.Assert(t, compiled == cached)
+
+	// a new generation of the policy is compiled again
+	updated := policy.DeepCopy()
+	updated.ResourceVersion = "2"
+	recompiled, err := compileRule(updated, rule)
+	assert.NilError(t, err)
+	assert.Assert(t, compiled != recompiled)
+
+	// policies without a UID are not cached
+	policy.UID = ""
+	uncached, err := compileRule(policy, rule)
+	assert.NilError(t, err)
+	again, err := compileRule(policy, rule)
+	assert.NilError(t, err)
+	assert.Assert(t, uncached != again)
+
+	rule.Validation.CEL.Expressions = append(rule.Validation.CEL.Expressions, kyvernov1.CELExpression{Expression: "object.spec.replicas <="})
+	_, err = compileRule(policy, rule)
+	assert.ErrorContains(t, err, "expression[1]")
+}
@@ -3,12 +3,14 @@ package validation
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/go-logr/logr"
 	gojmespath "github.com/jmespath/go-jmespath"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/anchor"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/handlers"
 	"github.com/kyverno/kyverno/pkg/engine/internal"
@@ -36,7 +38,11 @@ func (h validateResourceHandler) Process(
 	contextLoader engineapi.EngineContextLoader,
 ) (unstructured.Unstructured, []engineapi.RuleResponse) {
 	v := newValidator(logger, contextLoader, policyContext, rule)
-	return resource, handlers.RuleResponses(v.validate(ctx))
+	ruleResponse := v.validate(ctx)
+	if ruleResponse != nil {
+		ruleResponse.ContextFallbacks = v.fallbacks
+	}
+	return resource, handlers.RuleResponses(ruleResponse)
 }
 
 type validator struct {
@@ -51,6 +57,11 @@ type validator struct {
 	forEach          []kyvernov1.ForEachValidation
 	contextLoader    engineapi.EngineContextLoader
 	nesting          int
+	elementIndex     *int
+	elementKey       string
+	trace            *engineapi.RuleTrace
+	// fallbacks contains the foreach context entries that fell back according to their onError behaviour
+	fallbacks []string
 }
 
 func newValidator(log logr.Logger, contextLoader engineapi.EngineContextLoader, ctx engineapi.PolicyContext, rule kyvernov1.Rule) *validator {
@@ -98,6 +109,7 @@ func newForEachValidator(
 }
 
 func (v *validator) validate(ctx context.Context) *engineapi.RuleResponse {
+	v.trace = engineapi.RuleTraceFromContext(ctx)
 	if err := v.loadContext(ctx); err != nil {
 		return internal.RuleError(v.rule, engineapi.Validation, "failed to load context", err)
 	}
@@ -176,8 +188,12 @@ func (v *validator) validateElements(ctx context.Context, foreach kyvernov1.ForE
 			v.log.Error(err, "failed to create foreach validator")
 			return internal.RuleError(v.rule, engineapi.Validation, "failed to create foreach validator", err), applyCount
 		}
+		elementIndex := index
+		foreachValidator.elementIndex = &elementIndex
+		foreachValidator.elementKey = elementKey(element)
 
 		r := foreachValidator.validate(ctx)
+		v.fallbacks = internal.MergeContextFallbacks(v.fallbacks, foreachValidator.fallbacks...)
 		if r == nil {
 			v.log.V(2).Info("skip rule due to empty result")
 			continue
@@ -193,7 +209,9 @@ func (v *validator) validateElements(ctx context.Context, foreach kyvernov1.ForE
 				return internal.RuleResponse(v.rule, engineapi.Validation, msg, r.Status), applyCount
 			}
 			msg := fmt.Sprintf("validation failure: %v", r.Message)
-			return internal.RuleResponse(v.rule, engineapi.Validation, msg, r.Status), applyCount
+			resp := internal.RuleResponse(v.rule, engineapi.Validation, msg, r.Status)
+			resp.Violation = r.Violation
+			return resp, applyCount
 		}
 
 		applyCount++
@@ -203,7 +221,8 @@ func (v *validator) validateElements(ctx context.Context, foreach kyvernov1.ForE
 }
 
 func (v *validator) loadContext(ctx context.Context) error {
-	if err := v.contextLoader(ctx, v.contextEntries, v.policyContext.JSONContext()); err != nil {
+	fallbacks, err := v.contextLoader(ctx, v.contextEntries, v.policyContext.JSONContext())
+	if err != nil {
 		if _, ok := err.(gojmespath.NotFoundError); ok {
 			v.log.V(3).Info("failed to load context", "reason", err.Error())
 		} else {
@@ -211,6 +230,7 @@ func (v *validator) loadContext(ctx context.Context) error {
 		}
 		return err
 	}
+	v.fallbacks = internal.MergeContextFallbacks(v.fallbacks, fallbacks...)
 	return nil
 }
 
@@ -219,7 +239,10 @@ func (v *validator) validateDeny() *engineapi.RuleResponse {
 		return internal.RuleError(v.rule, engineapi.Validation, "failed to check deny preconditions", err)
 	} else {
 		if deny {
-			return internal.RuleResponse(v.rule, engineapi.Validation, v.getDenyMessage(deny), engineapi.RuleStatusFail)
+			violation := v.addViolation("", nil)
+			resp := internal.RuleResponse(v.rule, engineapi.Validation, v.getDenyMessage(deny), engineapi.RuleStatusFail)
+			resp.Violation = violation
+			return resp
 		}
 		return internal.RulePass(v.rule, engineapi.Validation, v.getDenyMessage(deny))
 	}
@@ -262,6 +285,7 @@ func (v *validator) validateResourceWithRule() *engineapi.RuleResponse {
 func (v *validator) validatePatterns(resource unstructured.Unstructured) *engineapi.RuleResponse {
 	if v.pattern != nil {
 		if err := validate.MatchPattern(v.log, resource.Object, v.pattern); err != nil {
+			v.tracePattern("pattern", err)
 			pe, ok := err.(*validate.PatternError)
 			if ok {
 				v.log.V(3).Info("validation error", "path", pe.Path, "error", err.Error())
@@ -274,7 +298,10 @@ func (v *validator) validatePatterns(resource unstructured.Unstructured) *engine
 					return internal.RuleResponse(v.rule, engineapi.Validation, v.buildErrorMessage(err, ""), engineapi.RuleStatusError)
 				}
 
-				return internal.RuleResponse(v.rule, engineapi.Validation, v.buildErrorMessage(err, pe.Path), engineapi.RuleStatusFail)
+				violation := v.addViolation(pe.Path, pe.Err)
+				resp := internal.RuleResponse(v.rule, engineapi.Validation, v.buildErrorMessage(err, pe.Path), engineapi.RuleStatusFail)
+				resp.Violation = violation
+				return resp
 			}
 
 			return internal.RuleResponse(v.rule, engineapi.Validation, v.buildErrorMessage(err, pe.Path), engineapi.RuleStatusError)
@@ -288,6 +315,7 @@ func (v *validator) validatePatterns(resource unstructured.Unstructured) *engine
 	if v.anyPattern != nil {
 		var failedAnyPatternsErrors []error
 		var skippedAnyPatternErrors []error
+		var violation *engineapi.ViolationDetails
 		var err error
 
 		anyPatterns, err := deserializeAnyPattern(v.anyPattern)
@@ -302,6 +330,7 @@ func (v *validator) validatePatterns(resource unstructured.Unstructured) *engine
 				return internal.RulePass(v.rule, engineapi.Validation, msg)
 			}
 
+			v.tracePattern(fmt.Sprintf("anyPattern[%d]", idx), err)
 			if pe, ok := err.(*validate.PatternError); ok {
 				var patternErr error
 				v.log.V(3).Info("validation rule failed", "anyPattern[%d]", idx, "path", pe.Path)
@@ -314,6 +343,9 @@ func (v *validator) validatePatterns(resource unstructured.Unstructured) *engine
 						patternErr = fmt.Errorf("rule %s[%d] failed: %s", v.rule.Name, idx, err.Error())
 					} else {
 						patternErr = fmt.Errorf("rule %s[%d] failed at path %s", v.rule.Name, idx, pe.Path)
+						if violation == nil {
+							violation = v.addViolation(pe.Path, pe.Err)
+						}
 					}
 					failedAnyPatternsErrors = append(failedAnyPatternsErrors, patternErr)
 				}
@@ -336,13 +368,69 @@ func (v *validator) validatePatterns(resource unstructured.Unstructured) *engine
 
 			v.log.V(4).Info(fmt.Sprintf("Validation rule '%s' failed. %s", v.rule.Name, errorStr))
 			msg := buildAnyPatternErrorMessage(v.rule, errorStr)
-			return internal.RuleResponse(v.rule, engineapi.Validation, msg, engineapi.RuleStatusFail)
+			resp := internal.RuleResponse(v.rule, engineapi.Validation, msg, engineapi.RuleStatusFail)
+			resp.Violation = violation
+			return resp
 		}
 	}
 
 	return internal.RulePass(v.rule, engineapi.Validation, v.rule.Validation.Message)
 }
 
+// tracePattern records a pattern mismatch in the rule trace, if any
+func (v *validator) tracePattern(pattern string, err error) {
+	if v.trace == nil {
+		return
+	}
+	patternTrace := engineapi.PatternTrace{
+		Pattern: pattern,
+		Message: err.Error(),
+	}
+	if pe, ok := err.(*validate.PatternError); ok {
+		patternTrace.Path = pe.Path
+	}
+	if anchor.IsConditionalAnchorError(err) {
+		patternTrace.Anchor = "conditional"
+	} else if anchor.IsGlobalAnchorError(err) {
+		patternTrace.Anchor = "global"
+	} else if anchor.IsNegationAnchorError(err) {
+		patternTrace.Anchor = "negation"
+	}
+	v.trace.Patterns = append(v.trace.Patterns, patternTrace)
+}
+
+// addViolation builds the structured details of a validation failure and adds them to the context,
+// they are available in the failure message through the violation variable
+func (v *validator) addViolation(path string, err error) *engineapi.ViolationDetails {
+	violation := &engineapi.ViolationDetails{
+		Path:         path,
+		ElementIndex: v.elementIndex,
+		ElementKey:   v.elementKey,
+	}
+	var valueErr *validate.ValueError
+	if errors.As(err, &valueErr) {
+		violation.Expected = fmt.Sprint(valueErr.Expected)
+		violation.Actual = fmt.Sprint(valueErr.Actual)
+	}
+	if err := v.policyContext.JSONContext().AddVariable("violation", violation); err != nil {
+		v.log.V(2).Info("failed to add violation to context", "error", err)
+	}
+	return violation
+}
+
+// elementKey identifies a foreach element, it is the element name when it has one or the element itself when it is a scalar value
+func elementKey(element interface{}) string {
+	switch typed := element.(type) {
+	case map[string]interface{}:
+		if name, ok := typed["name"].(string); ok {
+			return name
+		}
+	case string, bool, int, int64, float64:
+		return fmt.Sprint(typed)
+	}
+	return ""
+}
+
 func deserializeAnyPattern(anyPattern apiextensions.JSON) ([]interface{}, error) {
 	if anyPattern == nil {
 		return nil, nil
@@ -27,9 +27,11 @@ func (e *engine) verifyAndPatchImages(
 	policyContext.JSONContext().Checkpoint()
 	defer policyContext.JSONContext().Restore()
 
+	loadPolicyContext := e.policyContextLoader(policyContext)
 	for _, rule := range autogen.ComputeRules(policy) {
 		startTime := time.Now()
 		logger := internal.LoggerWithRule(logger, rule)
+		ctx, trace := newRuleTrace(ctx, policyContext, rule, rule.HasVerifyImages())
 		handlerFactory := func() (handlers.Handler, error) {
 			if !rule.HasVerifyImages() {
 				return nil, nil
@@ -51,8 +53,12 @@ func (e *engine) verifyAndPatchImages(
 			matchedResource,
 			rule,
 			engineapi.ImageVerify,
+			loadPolicyContext,
 		)
 		matchedResource = resource
+		if trace != nil {
+			resp.Traces = append(resp.Traces, *trace)
+		}
 		for _, ruleResp := range ruleResp {
 			ruleResp := ruleResp
 			internal.AddRuleResponse(&resp, &ruleResp, startTime)
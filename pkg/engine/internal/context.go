@@ -5,6 +5,7 @@ import (
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"golang.org/x/exp/slices"
 )
 
 func LoadContext(
@@ -12,7 +13,20 @@ func LoadContext(
 	engine engineapi.Engine,
 	pContext engineapi.PolicyContext,
 	rule kyvernov1.Rule,
-) error {
+) ([]string, error) {
 	loader := engine.ContextLoader(pContext.Policy(), rule)
 	return loader(ctx, rule.Context, pContext.JSONContext())
 }
+
+// MergeContextFallbacks returns the names of the context entries that fell back, each name is listed once
+func MergeContextFallbacks(fallbacks []string, names ...string) []string {
+	var merged []string
+	for _, list := range [][]string{fallbacks, names} {
+		for _, name := range list {
+			if !slices.Contains(merged, name) {
+				merged = append(merged, name)
+			}
+		}
+	}
+	return merged
+}
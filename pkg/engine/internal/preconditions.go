@@ -1,9 +1,12 @@
 package internal
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
@@ -33,3 +36,63 @@ func CheckDenyPreconditions(logger logr.Logger, jsonContext enginecontext.Interf
 	}
 	return variables.EvaluateConditions(logger, jsonContext, typeConditions), nil
 }
+
+// ExplainPreconditions evaluates the preconditions one by one and returns their result along with their traces,
+// keys and values are reported after variables substitution
+func ExplainPreconditions(logger logr.Logger, jsonContext enginecontext.Interface, anyAllConditions apiextensions.JSON) (bool, []engineapi.ConditionTrace, error) {
+	preconditions, err := variables.SubstituteAllInPreconditions(logger, jsonContext, anyAllConditions)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to substitute variables in preconditions: %w", err)
+	}
+	typeConditions, err := utils.TransformConditions(preconditions)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to parse preconditions: %w", err)
+	}
+	var traces []engineapi.ConditionTrace
+	switch typedConditions := typeConditions.(type) {
+	case kyvernov1.AnyAllConditions:
+		// same semantics as variables.EvaluateConditions, a non nil empty any block never passes
+		anyPassed, allPassed := typedConditions.AnyConditions == nil, true
+		for i, condition := range typedConditions.AnyConditions {
+			trace := explainCondition(logger, jsonContext, fmt.Sprintf("any[%d]", i), condition)
+			anyPassed = anyPassed || trace.Result
+			traces = append(traces, trace)
+		}
+		for i, condition := range typedConditions.AllConditions {
+			trace := explainCondition(logger, jsonContext, fmt.Sprintf("all[%d]", i), condition)
+			allPassed = allPassed && trace.Result
+			traces = append(traces, trace)
+		}
+		return anyPassed && allPassed, traces, nil
+	case []kyvernov1.Condition:
+		passed := true
+		for i, condition := range typedConditions {
+			trace := explainCondition(logger, jsonContext, fmt.Sprintf("[%d]", i), condition)
+			passed = passed && trace.Result
+			traces = append(traces, trace)
+		}
+		return passed, traces, nil
+	}
+	return false, nil, nil
+}
+
+func explainCondition(logger logr.Logger, jsonContext enginecontext.Interface, path string, condition kyvernov1.Condition) engineapi.ConditionTrace {
+	return engineapi.ConditionTrace{
+		Path:     path,
+		Key:      toTraceString(condition.GetKey()),
+		Operator: string(condition.Operator),
+		Value:    toTraceString(condition.GetValue()),
+		Result:   variables.Evaluate(logger, jsonContext, condition),
+	}
+}
+
+func toTraceString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
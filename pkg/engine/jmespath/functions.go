@@ -2,14 +2,21 @@ package jmespath
 
 import (
 	"bytes"
+	"crypto/md5" //nolint:gosec
 	"crypto/rand"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
 	"crypto/x509"
 	"encoding/asn1"
+	"encoding/base32"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"net/netip"
+	"net/url"
 	"path/filepath"
 	"reflect"
 	"regexp"
@@ -66,9 +73,27 @@ var (
 	objectFromLists        = "object_from_lists"
 	random                 = "random"
 	x509_decode            = "x509_decode"
+	ipInCidr               = "ip_in_cidr"
+	cidrOverlaps           = "cidr_overlaps"
+	ipIsPrivate            = "ip_is_private"
+	ipIsLoopback           = "ip_is_loopback"
+	ipFamily               = "ip_family"
+	ipNormalize            = "ip_normalize"
+	hashSha256             = "sha256"
+	hashSha1               = "sha1"
+	hashMd5                = "md5"
+	hexEncode              = "hex_encode"
+	hexDecode              = "hex_decode"
+	base32Encode           = "base32_encode"
+	base32Decode           = "base32_decode"
+	urlEncode              = "url_encode"
+	urlDecode              = "url_decode"
+	jwtDecode              = "jwt_decode"
+	x509_decode_csr        = "x509_decode_csr"
 )
 
-func GetFunctions() []FunctionEntry {
+func GetFunctions(opts ...Option) []FunctionEntry {
+	o := newOptions(opts...)
 	return []FunctionEntry{{
 		FunctionEntry: gojmespath.FunctionEntry{
 			Name: compare,
@@ -322,6 +347,96 @@ func GetFunctions() []FunctionEntry {
 		},
 		ReturnType: []jpType{jpString},
 		Note:       "encodes a regular, plaintext and unencoded string to base64",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: hashSha256,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpSha256,
+		},
+		ReturnType: []jpType{jpString},
+		Note:       "computes the SHA-256 digest of a string, encoded in hexadecimal",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: hashSha1,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpSha1,
+		},
+		ReturnType: []jpType{jpString},
+		Note:       "computes the SHA-1 digest of a string, encoded in hexadecimal",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: hashMd5,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpMd5,
+		},
+		ReturnType: []jpType{jpString},
+		Note:       "computes the MD5 digest of a string, encoded in hexadecimal",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: hexEncode,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpHexEncode,
+		},
+		ReturnType: []jpType{jpString},
+		Note:       "encodes a string to hexadecimal",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: hexDecode,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpHexDecode,
+		},
+		ReturnType: []jpType{jpString},
+		Note:       "decodes a hexadecimal string",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: base32Encode,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpBase32Encode,
+		},
+		ReturnType: []jpType{jpString},
+		Note:       "encodes a string to base32",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: base32Decode,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpBase32Decode,
+		},
+		ReturnType: []jpType{jpString},
+		Note:       "decodes a base32 string",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: urlEncode,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpURLEncode,
+		},
+		ReturnType: []jpType{jpString},
+		Note:       "escapes a string so it can be safely placed inside a URL query",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: urlDecode,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpURLDecode,
+		},
+		ReturnType: []jpType{jpString},
+		Note:       "unescapes a URL query encoded string",
 	}, {
 		FunctionEntry: gojmespath.FunctionEntry{
 			Name: timeSince,
@@ -443,6 +558,26 @@ func GetFunctions() []FunctionEntry {
 		},
 		ReturnType: []jpType{jpObject},
 		Note:       "decodes an x.509 certificate to an object. you may also use this in conjunction with `base64_decode` jmespath function to decode a base64-encoded certificate",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: jwtDecode,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpJwtDecode,
+		},
+		ReturnType: []jpType{jpObject},
+		Note:       "decodes a JSON Web Token to an object with header, payload and signature fields. the signature is NOT verified",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: x509_decode_csr,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpX509DecodeCSR,
+		},
+		ReturnType: []jpType{jpObject},
+		Note:       "decodes a PEM encoded x.509 certificate signing request to an object. you may also use this in conjunction with `base64_decode` jmespath function to decode the request of a CertificateSigningRequest",
 	}, {
 		FunctionEntry: gojmespath.FunctionEntry{
 			Name: timeToCron,
@@ -541,6 +676,163 @@ func GetFunctions() []FunctionEntry {
 		},
 		ReturnType: []jpType{jpString},
 		Note:       "returns the result of rounding time down to a multiple of duration",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: ipInCidr,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+				{Types: []jpType{jpString, jpArrayString}},
+			},
+			Handler: jpIPInCidr,
+		},
+		ReturnType: []jpType{jpBool},
+		Note:       "checks if an IP address (first string) is contained in a CIDR range or in any of an array of CIDR ranges, IPv4-mapped IPv6 addresses are matched against IPv4 ranges",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: cidrOverlaps,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpCidrOverlaps,
+		},
+		ReturnType: []jpType{jpBool},
+		Note:       "checks if two CIDR ranges have at least one IP address in common",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: ipIsPrivate,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpIPIsPrivate,
+		},
+		ReturnType: []jpType{jpBool},
+		Note:       "checks if an IP address is in a private range (RFC 1918 for IPv4, RFC 4193 for IPv6)",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: ipIsLoopback,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpIPIsLoopback,
+		},
+		ReturnType: []jpType{jpBool},
+		Note:       "checks if an IP address is a loopback address",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: ipFamily,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpIPFamily,
+		},
+		ReturnType: []jpType{jpString},
+		Note:       "returns the family ('IPv4' or 'IPv6') of an IP address or CIDR range",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: ipNormalize,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpIPNormalize,
+		},
+		ReturnType: []jpType{jpString},
+		Note:       "converts an IP address or CIDR range to its canonical form, IPv6 addresses are lower-cased and zeros are compressed (RFC 5952)",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: quantityParse,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString, jpNumber}},
+			},
+			Handler: jpQuantityParse,
+		},
+		ReturnType: []jpType{jpNumber},
+		Note:       "converts a resource quantity to a number in base units; ex. quantity_parse('512Mi') returns 536870912",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: quantityAdd,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString, jpNumber}},
+				{Types: []jpType{jpString, jpNumber}},
+			},
+			Handler: jpQuantityAdd,
+		},
+		ReturnType: []jpType{jpString},
+		Note:       "adds two resource quantities, units may differ and the result uses the format of the first quantity",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: quantitySubtract,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString, jpNumber}},
+				{Types: []jpType{jpString, jpNumber}},
+			},
+			Handler: jpQuantitySubtract,
+		},
+		ReturnType: []jpType{jpString},
+		Note:       "subtracts the second resource quantity from the first one, units may differ and the result uses the format of the first quantity",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: quantityMultiply,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString, jpNumber}},
+				{Types: []jpType{jpNumber}},
+			},
+			Handler: jpQuantityMultiply,
+		},
+		ReturnType: []jpType{jpString},
+		Note:       "multiplies a resource quantity by a number",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: quantitySum,
+			Arguments: []argSpec{
+				{Types: []jpType{jpArray}},
+			},
+			Handler: jpQuantitySum,
+		},
+		ReturnType: []jpType{jpString},
+		Note:       "adds an array of resource quantities; ex. quantity_sum(request.object.spec.containers[].resources.limits.memory)",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: quantityCompare,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString, jpNumber}},
+				{Types: []jpType{jpString, jpNumber}},
+			},
+			Handler: jpQuantityCompare,
+		},
+		ReturnType: []jpType{jpNumber},
+		Note:       "compares two resource quantities, returns -1 if the first one is lower, 0 if they are equal and 1 if the first one is greater",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: quantityConvert,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString, jpNumber}},
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpQuantityConvert,
+		},
+		ReturnType: []jpType{jpNumber},
+		Note:       "converts a resource quantity to a number in the unit given by the second string (ex. 'Mi', 'G', 'm' or '' for base units)",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: imageParse,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpImageParse(o.configuration),
+		},
+		ReturnType: []jpType{jpObject},
+		Note:       "splits an image reference into registry, name, path, tag and digest fields, the configured default registry is used when the image doesn't specify one and official docker hub images are in the library path",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: imageNormalize,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpImageNormalize(o.configuration),
+		},
+		ReturnType: []jpType{jpString},
+		Note:       "converts an image reference to its fully qualified form, ex. 'nginx' becomes 'docker.io/library/nginx:latest'",
 	}}
 }
 
@@ -864,6 +1156,120 @@ func jpBase64Encode(arguments []interface{}) (interface{}, error) {
 	return base64.StdEncoding.EncodeToString([]byte(str.String())), nil
 }
 
+func jpSha256(arguments []interface{}) (interface{}, error) {
+	str, err := validateArg(hashSha256, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256([]byte(str.String()))
+	return hex.EncodeToString(digest[:]), nil
+}
+
+func jpSha1(arguments []interface{}) (interface{}, error) {
+	str, err := validateArg(hashSha1, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha1.Sum([]byte(str.String())) //nolint:gosec
+	return hex.EncodeToString(digest[:]), nil
+}
+
+func jpMd5(arguments []interface{}) (interface{}, error) {
+	str, err := validateArg(hashMd5, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	digest := md5.Sum([]byte(str.String())) //nolint:gosec
+	return hex.EncodeToString(digest[:]), nil
+}
+
+func jpHexEncode(arguments []interface{}) (interface{}, error) {
+	str, err := validateArg(hexEncode, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	return hex.EncodeToString([]byte(str.String())), nil
+}
+
+func jpHexDecode(arguments []interface{}) (interface{}, error) {
+	str, err := validateArg(hexDecode, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := hex.DecodeString(str.String())
+	if err != nil {
+		return nil, formatError(genericError, hexDecode, err.Error())
+	}
+	return string(decoded), nil
+}
+
+func jpBase32Encode(arguments []interface{}) (interface{}, error) {
+	str, err := validateArg(base32Encode, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	return base32.StdEncoding.EncodeToString([]byte(str.String())), nil
+}
+
+func jpBase32Decode(arguments []interface{}) (interface{}, error) {
+	str, err := validateArg(base32Decode, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := base32.StdEncoding.DecodeString(str.String())
+	if err != nil {
+		return nil, formatError(genericError, base32Decode, err.Error())
+	}
+	return string(decoded), nil
+}
+
+func jpURLEncode(arguments []interface{}) (interface{}, error) {
+	str, err := validateArg(urlEncode, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	return url.QueryEscape(str.String()), nil
+}
+
+func jpURLDecode(arguments []interface{}) (interface{}, error) {
+	str, err := validateArg(urlDecode, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := url.QueryUnescape(str.String())
+	if err != nil {
+		return nil, formatError(genericError, urlDecode, err.Error())
+	}
+	return decoded, nil
+}
+
+// jpJwtDecode decodes the segments of a JWT without verifying its signature
+func jpJwtDecode(arguments []interface{}) (interface{}, error) {
+	token, err := validateArg(jwtDecode, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.Split(strings.TrimSpace(token.String()), ".")
+	if len(parts) != 3 {
+		return nil, formatError(genericError, jwtDecode, "a token must have three segments")
+	}
+	result := map[string]interface{}{
+		"signature": parts[2],
+	}
+	for i, key := range []string{"header", "payload"} {
+		data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[i], "="))
+		if err != nil {
+			return nil, formatError(genericError, jwtDecode, fmt.Sprintf("failed to decode %s: %v", key, err))
+		}
+		var value map[string]interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, formatError(genericError, jwtDecode, fmt.Sprintf("failed to unmarshal %s: %v", key, err))
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
 func jpPathCanonicalize(arguments []interface{}) (interface{}, error) {
 	var err error
 	str, err := validateArg(pathCanonicalize, arguments, 0, reflect.String)
@@ -1064,27 +1470,12 @@ func jpX509Decode(arguments []interface{}) (interface{}, error) {
 
 	buf := new(bytes.Buffer)
 	if fmt.Sprint(cert.PublicKeyAlgorithm) == "RSA" {
-		spki := cryptobyte.String(cert.RawSubjectPublicKeyInfo)
-		if !spki.ReadASN1(&spki, cryptobyte_asn1.SEQUENCE) {
-			return res, errors.New("writing asn.1 element to 'spki' failed")
-		}
-		var pkAISeq cryptobyte.String
-		if !spki.ReadASN1(&pkAISeq, cryptobyte_asn1.SEQUENCE) {
-			return res, errors.New("writing asn.1 element to 'pkAISeq' failed")
-		}
-		var spk asn1.BitString
-		if !spki.ReadASN1BitString(&spk) {
-			return res, errors.New("writing asn.1 bit string to 'spk' failed")
-		}
-		kk, err := x509.ParsePKCS1PublicKey(spk.Bytes)
+		publicKey, err := decodeRSAPublicKey(cert.RawSubjectPublicKeyInfo)
 		if err != nil {
 			return res, err
 		}
 
-		cert.PublicKey = PublicKey{
-			N: kk.N.String(),
-			E: kk.E,
-		}
+		cert.PublicKey = publicKey
 
 		enc := json.NewEncoder(buf)
 		err = enc.Encode(cert)
@@ -1099,3 +1490,197 @@ func jpX509Decode(arguments []interface{}) (interface{}, error) {
 
 	return res, nil
 }
+
+func jpX509DecodeCSR(arguments []interface{}) (interface{}, error) {
+	res := make(map[string]interface{})
+	input, err := validateArg(x509_decode_csr, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	p, _ := pem.Decode([]byte(input.String()))
+	if p == nil {
+		return res, errors.New("invalid certificate signing request")
+	}
+
+	csr, err := x509.ParseCertificateRequest(p.Bytes)
+	if err != nil {
+		return res, err
+	}
+
+	// only RSA keys are decoded, other keys are available in RawSubjectPublicKeyInfo
+	if fmt.Sprint(csr.PublicKeyAlgorithm) == "RSA" {
+		publicKey, err := decodeRSAPublicKey(csr.RawSubjectPublicKeyInfo)
+		if err != nil {
+			return res, err
+		}
+		csr.PublicKey = publicKey
+	} else {
+		csr.PublicKey = nil
+	}
+
+	data, err := json.Marshal(csr)
+	if err != nil {
+		return res, err
+	}
+
+	if err := json.Unmarshal(data, &res); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func decodeRSAPublicKey(rawSubjectPublicKeyInfo []byte) (PublicKey, error) {
+	spki := cryptobyte.String(rawSubjectPublicKeyInfo)
+	if !spki.ReadASN1(&spki, cryptobyte_asn1.SEQUENCE) {
+		return PublicKey{}, errors.New("writing asn.1 element to 'spki' failed")
+	}
+	var pkAISeq cryptobyte.String
+	if !spki.ReadASN1(&pkAISeq, cryptobyte_asn1.SEQUENCE) {
+		return PublicKey{}, errors.New("writing asn.1 element to 'pkAISeq' failed")
+	}
+	var spk asn1.BitString
+	if !spki.ReadASN1BitString(&spk) {
+		return PublicKey{}, errors.New("writing asn.1 bit string to 'spk' failed")
+	}
+	kk, err := x509.ParsePKCS1PublicKey(spk.Bytes)
+	if err != nil {
+		return PublicKey{}, err
+	}
+	return PublicKey{
+		N: kk.N.String(),
+		E: kk.E,
+	}, nil
+}
+
+func parseAddr(f string, arguments []interface{}, index int) (netip.Addr, error) {
+	arg, err := validateArg(f, arguments, index, reflect.String)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	addr, err := netip.ParseAddr(arg.String())
+	if err != nil {
+		return netip.Addr{}, formatError(genericError, f, err.Error())
+	}
+	return addr, nil
+}
+
+func parsePrefix(f string, value string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		return netip.Prefix{}, formatError(genericError, f, err.Error())
+	}
+	return prefix, nil
+}
+
+func jpIPInCidr(arguments []interface{}) (interface{}, error) {
+	addr, err := parseAddr(ipInCidr, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	addr = addr.Unmap()
+	var cidrs []string
+	switch arg := arguments[1].(type) {
+	case string:
+		cidrs = append(cidrs, arg)
+	case []interface{}:
+		for _, item := range arg {
+			cidr, ok := item.(string)
+			if !ok {
+				return nil, formatError(invalidArgumentTypeError, ipInCidr, 2, "String or Array of String")
+			}
+			cidrs = append(cidrs, cidr)
+		}
+	default:
+		return nil, formatError(invalidArgumentTypeError, ipInCidr, 2, "String or Array of String")
+	}
+	for _, cidr := range cidrs {
+		prefix, err := parsePrefix(ipInCidr, cidr)
+		if err != nil {
+			return nil, err
+		}
+		if prefix.Contains(addr) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func jpCidrOverlaps(arguments []interface{}) (interface{}, error) {
+	a, err := validateArg(cidrOverlaps, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	b, err := validateArg(cidrOverlaps, arguments, 1, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	prefixA, err := parsePrefix(cidrOverlaps, a.String())
+	if err != nil {
+		return nil, err
+	}
+	prefixB, err := parsePrefix(cidrOverlaps, b.String())
+	if err != nil {
+		return nil, err
+	}
+	return prefixA.Overlaps(prefixB), nil
+}
+
+func jpIPIsPrivate(arguments []interface{}) (interface{}, error) {
+	addr, err := parseAddr(ipIsPrivate, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	return addr.Unmap().IsPrivate(), nil
+}
+
+func jpIPIsLoopback(arguments []interface{}) (interface{}, error) {
+	addr, err := parseAddr(ipIsLoopback, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	return addr.Unmap().IsLoopback(), nil
+}
+
+func jpIPFamily(arguments []interface{}) (interface{}, error) {
+	arg, err := validateArg(ipFamily, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	var addr netip.Addr
+	if strings.Contains(arg.String(), "/") {
+		prefix, err := parsePrefix(ipFamily, arg.String())
+		if err != nil {
+			return nil, err
+		}
+		addr = prefix.Addr()
+	} else {
+		addr, err = parseAddr(ipFamily, arguments, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if addr.Is4() {
+		return "IPv4", nil
+	}
+	return "IPv6", nil
+}
+
+func jpIPNormalize(arguments []interface{}) (interface{}, error) {
+	arg, err := validateArg(ipNormalize, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	if strings.Contains(arg.String(), "/") {
+		prefix, err := parsePrefix(ipNormalize, arg.String())
+		if err != nil {
+			return nil, err
+		}
+		return prefix.String(), nil
+	}
+	addr, err := parseAddr(ipNormalize, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	return addr.String(), nil
+}
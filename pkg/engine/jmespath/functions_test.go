@@ -1,7 +1,13 @@
 package jmespath
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"reflect"
 	"runtime"
@@ -1396,3 +1402,227 @@ func Test_jpfToLower(t *testing.T) {
 		})
 	}
 }
+
+func Test_IPFunctions(t *testing.T) {
+	testCases := []struct {
+		jmesPath       string
+		expectedResult interface{}
+	}{
+		{
+			jmesPath:       "ip_in_cidr('10.1.2.3', '10.0.0.0/8')",
+			expectedResult: true,
+		},
+		{
+			jmesPath:       "ip_in_cidr('11.1.2.3', '10.0.0.0/8')",
+			expectedResult: false,
+		},
+		{
+			jmesPath:       "ip_in_cidr('::ffff:10.1.2.3', '10.0.0.0/8')",
+			expectedResult: true,
+		},
+		{
+			jmesPath:       "ip_in_cidr('2001:db8::1', ['10.0.0.0/8', '2001:db8::/32'])",
+			expectedResult: true,
+		},
+		{
+			jmesPath:       "ip_in_cidr('2001:db9::1', ['10.0.0.0/8', '2001:db8::/32'])",
+			expectedResult: false,
+		},
+		{
+			jmesPath:       "cidr_overlaps('10.0.0.0/8', '10.20.0.0/16')",
+			expectedResult: true,
+		},
+		{
+			jmesPath:       "cidr_overlaps('10.0.0.0/16', '10.1.0.0/16')",
+			expectedResult: false,
+		},
+		{
+			jmesPath:       "cidr_overlaps('10.0.0.0/8', '::/0')",
+			expectedResult: false,
+		},
+		{
+			jmesPath:       "ip_is_private('192.168.1.1')",
+			expectedResult: true,
+		},
+		{
+			jmesPath:       "ip_is_private('fd00::1')",
+			expectedResult: true,
+		},
+		{
+			jmesPath:       "ip_is_private('8.8.8.8')",
+			expectedResult: false,
+		},
+		{
+			jmesPath:       "ip_is_loopback('127.0.0.53')",
+			expectedResult: true,
+		},
+		{
+			jmesPath:       "ip_is_loopback('::1')",
+			expectedResult: true,
+		},
+		{
+			jmesPath:       "ip_is_loopback('10.0.0.1')",
+			expectedResult: false,
+		},
+		{
+			jmesPath:       "ip_family('10.0.0.1')",
+			expectedResult: "IPv4",
+		},
+		{
+			jmesPath:       "ip_family('2001:db8::/32')",
+			expectedResult: "IPv6",
+		},
+		{
+			jmesPath:       "ip_normalize('2001:0DB8:0000:0000:0000:0000:0000:0001')",
+			expectedResult: "2001:db8::1",
+		},
+		{
+			jmesPath:       "ip_normalize('2001:DB8:0::/32')",
+			expectedResult: "2001:db8::/32",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.jmesPath, func(t *testing.T) {
+			jp, err := New(tc.jmesPath)
+			assert.NilError(t, err)
+
+			result, err := jp.Search("")
+			assert.NilError(t, err)
+			assert.Equal(t, result, tc.expectedResult)
+		})
+	}
+}
+
+func Test_IPFunctionsErrors(t *testing.T) {
+	testCases := []struct {
+		jmesPath string
+		err      string
+	}{
+		{
+			jmesPath: "ip_in_cidr('10.0.0.300', '10.0.0.0/8')",
+			err:      `JMESPath function 'ip_in_cidr': ParseAddr("10.0.0.300")`,
+		},
+		{
+			jmesPath: "ip_in_cidr('10.0.0.1', '10.0.0.0')",
+			err:      `JMESPath function 'ip_in_cidr': netip.ParsePrefix("10.0.0.0")`,
+		},
+		{
+			jmesPath: "cidr_overlaps('10.0.0.0/8', '10.0.0.0/33')",
+			err:      `JMESPath function 'cidr_overlaps': netip.ParsePrefix("10.0.0.0/33")`,
+		},
+		{
+			jmesPath: "ip_family('foo')",
+			err:      `JMESPath function 'ip_family': ParseAddr("foo")`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.jmesPath, func(t *testing.T) {
+			jp, err := New(tc.jmesPath)
+			assert.NilError(t, err)
+
+			_, err = jp.Search("")
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func Test_HashAndEncodingFunctions(t *testing.T) {
+	testCases := []struct {
+		jmesPath       string
+		expectedResult interface{}
+	}{
+		{
+			jmesPath:       "sha256('kyverno')",
+			expectedResult: "6900ead2739f4d80767db3f097b8c7e352c395dac245945161cb7d5e3f8438b3",
+		},
+		{
+			jmesPath:       "sha1('kyverno')",
+			expectedResult: "6fec57cb57bfc38106901b9a0c4f3155ebb386f5",
+		},
+		{
+			jmesPath:       "md5('kyverno')",
+			expectedResult: "9638dd54a7735b1d741a6e202444d186",
+		},
+		{
+			jmesPath:       "hex_encode('kyverno')",
+			expectedResult: "6b797665726e6f",
+		},
+		{
+			jmesPath:       "hex_decode('6b797665726e6f')",
+			expectedResult: "kyverno",
+		},
+		{
+			jmesPath:       "base32_encode('kyverno')",
+			expectedResult: "NN4XMZLSNZXQ====",
+		},
+		{
+			jmesPath:       "base32_decode('NN4XMZLSNZXQ====')",
+			expectedResult: "kyverno",
+		},
+		{
+			jmesPath:       "url_encode('a b&c=d/e')",
+			expectedResult: "a+b%26c%3Dd%2Fe",
+		},
+		{
+			jmesPath:       "url_decode('a+b%26c%3Dd%2Fe')",
+			expectedResult: "a b&c=d/e",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.jmesPath, func(t *testing.T) {
+			jp, err := New(tc.jmesPath)
+			assert.NilError(t, err)
+
+			result, err := jp.Search("")
+			assert.NilError(t, err)
+			assert.Equal(t, result, tc.expectedResult)
+		})
+	}
+}
+
+func Test_JwtDecode(t *testing.T) {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","kid":"foo"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"aud":["https://kubernetes.default.svc"],"sub":"system:serviceaccount:default:app"}`))
+	token := header + "." + payload + ".c2lnbmF0dXJl"
+	jp, err := New("jwt_decode('" + token + "').payload.sub")
+	assert.NilError(t, err)
+	result, err := jp.Search("")
+	assert.NilError(t, err)
+	assert.Equal(t, result, "system:serviceaccount:default:app")
+
+	jp, err = New("jwt_decode('" + token + "').header.kid")
+	assert.NilError(t, err)
+	result, err = jp.Search("")
+	assert.NilError(t, err)
+	assert.Equal(t, result, "foo")
+
+	jp, err = New("jwt_decode('" + header + "." + payload + "')")
+	assert.NilError(t, err)
+	_, err = jp.Search("")
+	assert.Error(t, err, "JMESPath function 'jwt_decode': a token must have three segments")
+}
+
+func Test_x509DecodeCSR(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NilError(t, err)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "system:node:worker", Organization: []string{"system:nodes"}},
+		DNSNames: []string{"worker.example.com"},
+	}, key)
+	assert.NilError(t, err)
+	csr := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+
+	jp, err := New("x509_decode_csr(base64_decode(@))")
+	assert.NilError(t, err)
+	result, err := jp.Search(base64.StdEncoding.EncodeToString([]byte(csr)))
+	assert.NilError(t, err)
+	res := result.(map[string]interface{})
+	assert.Equal(t, res["Subject"].(map[string]interface{})["CommonName"], "system:node:worker")
+	assert.DeepEqual(t, res["DNSNames"], []interface{}{"worker.example.com"})
+	assert.Equal(t, res["PublicKey"].(map[string]interface{})["N"], key.N.String())
+
+	jp, err = New("x509_decode_csr('foo')")
+	assert.NilError(t, err)
+	_, err = jp.Search("")
+	assert.Error(t, err, "invalid certificate signing request")
+}
@@ -0,0 +1,86 @@
+package jmespath
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	gojmespath "github.com/jmespath/go-jmespath"
+	"github.com/kyverno/kyverno/pkg/config"
+	imageutils "github.com/kyverno/kyverno/pkg/utils/image"
+)
+
+// function names
+var (
+	imageParse     = "image_parse"
+	imageNormalize = "image_normalize"
+)
+
+// registryConfiguration always adds the default registry, regardless of the registry mutation setting
+type registryConfiguration struct {
+	config.Configuration
+}
+
+func (registryConfiguration) GetEnableDefaultRegistryMutation() bool {
+	return true
+}
+
+func getImageInfo(f string, arguments []interface{}, configuration config.Configuration) (*imageutils.ImageInfo, error) {
+	image, err := validateArg(f, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	info, err := imageutils.GetImageInfo(image.String(), registryConfiguration{configuration})
+	if err != nil {
+		return nil, formatError(genericError, f, err.Error())
+	}
+	normalizeImageInfo(info)
+	return info, nil
+}
+
+// normalizeImageInfo converts the docker hub registry and the path of its official images to their canonical form
+func normalizeImageInfo(info *imageutils.ImageInfo) {
+	if info.Registry == "index.docker.io" {
+		info.Registry = "docker.io"
+	}
+	// official images of docker hub live in the library repository
+	if info.Registry == "docker.io" && !strings.Contains(info.Path, "/") {
+		info.Path = "library/" + info.Path
+	}
+}
+
+func jpImageParse(configuration config.Configuration) gojmespath.JpFunction {
+	return func(arguments []interface{}) (interface{}, error) {
+		info, err := getImageInfo(imageParse, arguments, configuration)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(info)
+		if err != nil {
+			return nil, err
+		}
+		var res map[string]interface{}
+		if err := json.Unmarshal(data, &res); err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+}
+
+func jpImageNormalize(configuration config.Configuration) gojmespath.JpFunction {
+	return func(arguments []interface{}) (interface{}, error) {
+		info, err := getImageInfo(imageNormalize, arguments, configuration)
+		if err != nil {
+			return nil, err
+		}
+		image := fmt.Sprintf("%s/%s", info.Registry, info.Path)
+		if info.Tag != "" {
+			image = fmt.Sprintf("%s:%s", image, info.Tag)
+		}
+		if info.Digest != "" {
+			image = fmt.Sprintf("%s@%s", image, info.Digest)
+		}
+		return image, nil
+	}
+}
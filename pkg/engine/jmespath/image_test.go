@@ -0,0 +1,118 @@
+package jmespath
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+)
+
+type registryOverride struct {
+	config.Configuration
+	registry string
+}
+
+func (c registryOverride) GetDefaultRegistry() string {
+	return c.registry
+}
+
+func Test_ImageParse(t *testing.T) {
+	testCases := []struct {
+		test           string
+		expectedResult map[string]interface{}
+	}{
+		{
+			test: "image_parse('nginx')",
+			expectedResult: map[string]interface{}{
+				"registry": "docker.io",
+				"name":     "nginx",
+				"path":     "library/nginx",
+				"tag":      "latest",
+			},
+		},
+		{
+			test: "image_parse('ghcr.io/kyverno/kyverno:v1.10.0')",
+			expectedResult: map[string]interface{}{
+				"registry": "ghcr.io",
+				"name":     "kyverno",
+				"path":     "kyverno/kyverno",
+				"tag":      "v1.10.0",
+			},
+		},
+		{
+			test: "image_parse('localhost:5000/app@sha256:128c6e3534b842a2eec139999b8ce8aa9a2af9907e2b9269550809d18cd832a3')",
+			expectedResult: map[string]interface{}{
+				"registry": "localhost:5000",
+				"name":     "app",
+				"path":     "app",
+				"digest":   "sha256:128c6e3534b842a2eec139999b8ce8aa9a2af9907e2b9269550809d18cd832a3",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			query, err := New(tc.test)
+			assert.NilError(t, err)
+
+			res, err := query.Search("")
+			assert.NilError(t, err)
+			assert.DeepEqual(t, res, tc.expectedResult)
+		})
+	}
+}
+
+func Test_ImageNormalize(t *testing.T) {
+	testCases := []struct {
+		test           string
+		expectedResult string
+	}{
+		{
+			test:           "image_normalize('nginx')",
+			expectedResult: "docker.io/library/nginx:latest",
+		},
+		{
+			test:           "image_normalize('docker.io/library/nginx')",
+			expectedResult: "docker.io/library/nginx:latest",
+		},
+		{
+			test:           "image_normalize('index.docker.io/nginx:1.25')",
+			expectedResult: "docker.io/library/nginx:1.25",
+		},
+		{
+			test:           "image_normalize('bitnami/nginx')",
+			expectedResult: "docker.io/bitnami/nginx:latest",
+		},
+		{
+			test:           "image_normalize('ghcr.io/kyverno/kyverno:v1.10.0@sha256:128c6e3534b842a2eec139999b8ce8aa9a2af9907e2b9269550809d18cd832a3')",
+			expectedResult: "ghcr.io/kyverno/kyverno:v1.10.0@sha256:128c6e3534b842a2eec139999b8ce8aa9a2af9907e2b9269550809d18cd832a3",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			query, err := New(tc.test)
+			assert.NilError(t, err)
+
+			res, err := query.Search("")
+			assert.NilError(t, err)
+			assert.Equal(t, res, tc.expectedResult)
+		})
+	}
+}
+
+func Test_ImageNormalizeDefaultRegistry(t *testing.T) {
+	configuration := registryOverride{Configuration: config.NewDefaultConfiguration(), registry: "registry.example.com"}
+	query, err := New("image_normalize('nginx')", WithConfiguration(configuration))
+	assert.NilError(t, err)
+
+	res, err := query.Search("")
+	assert.NilError(t, err)
+	assert.Equal(t, res, "registry.example.com/nginx:latest")
+}
+
+func Test_ImageParseError(t *testing.T) {
+	query, err := New("image_parse('Nginx:')")
+	assert.NilError(t, err)
+
+	_, err = query.Search("")
+	assert.ErrorContains(t, err, "JMESPath function 'image_parse': bad image")
+}
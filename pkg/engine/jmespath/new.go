@@ -2,14 +2,43 @@ package jmespath
 
 import (
 	gojmespath "github.com/jmespath/go-jmespath"
+	"github.com/kyverno/kyverno/pkg/config"
 )
 
-func New(query string) (*gojmespath.JMESPath, error) {
+var defaultConfiguration = config.NewDefaultConfiguration()
+
+// Option configures the functions registered with a query
+type Option func(*options)
+
+type options struct {
+	configuration config.Configuration
+}
+
+// WithConfiguration sets the configuration used by functions depending on Kyverno settings, like the default registry
+func WithConfiguration(configuration config.Configuration) Option {
+	return func(o *options) {
+		if configuration != nil {
+			o.configuration = configuration
+		}
+	}
+}
+
+func newOptions(opts ...Option) options {
+	o := options{
+		configuration: defaultConfiguration,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func New(query string, opts ...Option) (*gojmespath.JMESPath, error) {
 	jp, err := gojmespath.Compile(query)
 	if err != nil {
 		return nil, err
 	}
-	for _, function := range GetFunctions() {
+	for _, function := range GetFunctions(opts...) {
 		jp.Register(function.FunctionEntry)
 	}
 	return jp, nil
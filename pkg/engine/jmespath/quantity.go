@@ -0,0 +1,128 @@
+package jmespath
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// function names
+var (
+	quantityParse    = "quantity_parse"
+	quantityAdd      = "quantity_add"
+	quantitySubtract = "quantity_subtract"
+	quantityMultiply = "quantity_multiply"
+	quantitySum      = "quantity_sum"
+	quantityCompare  = "quantity_compare"
+	quantityConvert  = "quantity_convert"
+)
+
+func toQuantity(value interface{}) (resource.Quantity, error) {
+	switch v := value.(type) {
+	case string:
+		return resource.ParseQuantity(v)
+	case float64:
+		return resource.ParseQuantity(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		return resource.Quantity{}, fmt.Errorf("unexpected type %T", value)
+	}
+}
+
+func getQuantityArg(f string, arguments []interface{}, index int) (resource.Quantity, error) {
+	if index >= len(arguments) {
+		return resource.Quantity{}, formatError(argOutOfBoundsError, f, index+1, len(arguments))
+	}
+	q, err := toQuantity(arguments[index])
+	if err != nil {
+		return resource.Quantity{}, formatError(invalidArgumentTypeError, f, index+1, "Quantity")
+	}
+	return q, nil
+}
+
+func jpQuantityParse(arguments []interface{}) (interface{}, error) {
+	if q, err := getQuantityArg(quantityParse, arguments, 0); err != nil {
+		return nil, err
+	} else {
+		return q.AsApproximateFloat64(), nil
+	}
+}
+
+func jpQuantityAdd(arguments []interface{}) (interface{}, error) {
+	if q1, err := getQuantityArg(quantityAdd, arguments, 0); err != nil {
+		return nil, err
+	} else if q2, err := getQuantityArg(quantityAdd, arguments, 1); err != nil {
+		return nil, err
+	} else {
+		return Quantity{Quantity: q1}.Add(Quantity{Quantity: q2}, quantityAdd)
+	}
+}
+
+func jpQuantitySubtract(arguments []interface{}) (interface{}, error) {
+	if q1, err := getQuantityArg(quantitySubtract, arguments, 0); err != nil {
+		return nil, err
+	} else if q2, err := getQuantityArg(quantitySubtract, arguments, 1); err != nil {
+		return nil, err
+	} else {
+		return Quantity{Quantity: q1}.Subtract(Quantity{Quantity: q2})
+	}
+}
+
+func jpQuantityMultiply(arguments []interface{}) (interface{}, error) {
+	q, err := getQuantityArg(quantityMultiply, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	factor, err := validateArg(quantityMultiply, arguments, 1, reflect.Float64)
+	if err != nil {
+		return nil, err
+	}
+	return Quantity{Quantity: q}.Multiply(Scalar{factor.Float()})
+}
+
+func jpQuantitySum(arguments []interface{}) (interface{}, error) {
+	items, err := validateArg(quantitySum, arguments, 0, reflect.Slice)
+	if err != nil {
+		return nil, err
+	}
+	var total resource.Quantity
+	for i := 0; i < items.Len(); i++ {
+		q, err := toQuantity(items.Index(i).Interface())
+		if err != nil {
+			return nil, formatError(genericError, quantitySum, fmt.Sprintf("item %d is not a valid quantity", i))
+		}
+		if i == 0 {
+			total = q
+		} else {
+			total.Add(q)
+		}
+	}
+	return total.String(), nil
+}
+
+func jpQuantityCompare(arguments []interface{}) (interface{}, error) {
+	if q1, err := getQuantityArg(quantityCompare, arguments, 0); err != nil {
+		return nil, err
+	} else if q2, err := getQuantityArg(quantityCompare, arguments, 1); err != nil {
+		return nil, err
+	} else {
+		return float64(q1.Cmp(q2)), nil
+	}
+}
+
+func jpQuantityConvert(arguments []interface{}) (interface{}, error) {
+	q, err := getQuantityArg(quantityConvert, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	unit, err := validateArg(quantityConvert, arguments, 1, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	multiplier, err := resource.ParseQuantity("1" + unit.String())
+	if err != nil {
+		return nil, formatError(genericError, quantityConvert, fmt.Sprintf("invalid unit %q", unit.String()))
+	}
+	return q.AsApproximateFloat64() / multiplier.AsApproximateFloat64(), nil
+}
@@ -0,0 +1,141 @@
+package jmespath
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_QuantityFunctions(t *testing.T) {
+	testCases := []struct {
+		test           string
+		expectedResult interface{}
+	}{
+		{
+			test:           "quantity_parse('512Mi')",
+			expectedResult: 536870912.0,
+		},
+		{
+			test:           "quantity_parse('250m')",
+			expectedResult: 0.25,
+		},
+		{
+			test:           "quantity_parse(`2`)",
+			expectedResult: 2.0,
+		},
+		{
+			test:           "quantity_add('512Mi', '512Mi')",
+			expectedResult: "1Gi",
+		},
+		{
+			test:           "quantity_add('512Mi', '1G')",
+			expectedResult: "1536870912",
+		},
+		{
+			test:           "quantity_add('250m', `1`)",
+			expectedResult: "1250m",
+		},
+		{
+			test:           "quantity_subtract('1Gi', '256Mi')",
+			expectedResult: "768Mi",
+		},
+		{
+			test:           "quantity_multiply('256Mi', `3`)",
+			expectedResult: "768Mi",
+		},
+		{
+			test:           "quantity_sum(['100m', '0.5', '1'])",
+			expectedResult: "1600m",
+		},
+		{
+			test:           "quantity_sum(`[]`)",
+			expectedResult: "0",
+		},
+		{
+			test:           "quantity_compare('1Gi', '1G')",
+			expectedResult: 1.0,
+		},
+		{
+			test:           "quantity_compare('1000M', '1G')",
+			expectedResult: 0.0,
+		},
+		{
+			test:           "quantity_compare('500m', `1`)",
+			expectedResult: -1.0,
+		},
+		{
+			test:           "quantity_compare('1Gi', '1G') > `0`",
+			expectedResult: true,
+		},
+		{
+			test:           "quantity_compare('500m', `1`) > `0`",
+			expectedResult: false,
+		},
+		{
+			test:           "quantity_convert('1Gi', 'Mi')",
+			expectedResult: 1024.0,
+		},
+		{
+			test:           "quantity_convert('1500m', '')",
+			expectedResult: 1.5,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			query, err := New(tc.test)
+			assert.NilError(t, err)
+
+			res, err := query.Search("")
+			assert.NilError(t, err)
+
+			assert.Equal(t, res, tc.expectedResult)
+		})
+	}
+}
+
+func Test_QuantitySumContainers(t *testing.T) {
+	pod := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"resources": map[string]interface{}{"limits": map[string]interface{}{"memory": "512Mi"}}},
+				map[string]interface{}{"resources": map[string]interface{}{"limits": map[string]interface{}{"memory": "1G"}}},
+				map[string]interface{}{"resources": map[string]interface{}{}},
+			},
+		},
+	}
+	query, err := New("quantity_compare(quantity_sum(spec.containers[].resources.limits.memory), '2Gi')")
+	assert.NilError(t, err)
+
+	res, err := query.Search(pod)
+	assert.NilError(t, err)
+	assert.Equal(t, res, -1.0)
+}
+
+func Test_QuantityFunctionsErrors(t *testing.T) {
+	testCases := []struct {
+		test string
+		err  string
+	}{
+		{
+			test: "quantity_add('1Gi', 'foo')",
+			err:  "JMESPath function 'quantity_add': 2 argument is expected of Quantity type",
+		},
+		{
+			test: "quantity_sum(['1Gi', `true`])",
+			err:  "JMESPath function 'quantity_sum': item 1 is not a valid quantity",
+		},
+		{
+			test: "quantity_convert('1Gi', 'foo')",
+			err:  "JMESPath function 'quantity_convert': invalid unit \"foo\"",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			query, err := New(tc.test)
+			assert.NilError(t, err)
+
+			_, err = query.Search("")
+			assert.Error(t, err, tc.err)
+		})
+	}
+}
@@ -42,7 +42,7 @@ func Mutate(rule *kyvernov1.Rule, ctx context.Interface, resource unstructured.U
 	}
 
 	m := updatedRule.Mutation
-	patcher := NewPatcher(updatedRule.Name, m.GetPatchStrategicMerge(), m.PatchesJSON6902, resource, logger)
+	patcher := NewPatcher(updatedRule.Name, m.GetPatchStrategicMerge(), m.GetPatchMerge(), m.PatchesJSON6902, resource, logger)
 	if patcher == nil {
 		return NewResponse(engineapi.RuleStatusError, resource, nil, "empty mutate rule")
 	}
@@ -76,7 +76,7 @@ func ForEach(name string, foreach kyvernov1.ForEachMutation, policyContext engin
 		return NewErrorResponse("variable substitution failed", err)
 	}
 
-	patcher := NewPatcher(name, fe.GetPatchStrategicMerge(), fe.PatchesJSON6902, resource, logger)
+	patcher := NewPatcher(name, fe.GetPatchStrategicMerge(), fe.GetPatchMerge(), fe.PatchesJSON6902, resource, logger)
 	if patcher == nil {
 		return NewResponse(engineapi.RuleStatusError, unstructured.Unstructured{}, nil, "no patches found")
 	}
@@ -121,11 +121,15 @@ func substituteAllInForEach(fe kyvernov1.ForEachMutation, ctx context.Interface,
 	return &updatedForEach, nil
 }
 
-func NewPatcher(name string, strategicMergePatch apiextensions.JSON, jsonPatch string, r unstructured.Unstructured, logger logr.Logger) patch.Patcher {
+func NewPatcher(name string, strategicMergePatch, mergePatch apiextensions.JSON, jsonPatch string, r unstructured.Unstructured, logger logr.Logger) patch.Patcher {
 	if strategicMergePatch != nil {
 		return patch.NewPatchStrategicMerge(name, strategicMergePatch, r, logger)
 	}
 
+	if mergePatch != nil {
+		return patch.NewPatchMerge(name, mergePatch, r, logger)
+	}
+
 	if len(jsonPatch) > 0 {
 		return patch.NewPatchesJSON6902(name, jsonPatch, r, logger)
 	}
@@ -0,0 +1,78 @@
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/go-logr/logr"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ProcessMergePatch applies a JSON merge patch (RFC 7386) to the resource
+func ProcessMergePatch(ruleName string, patch interface{}, resource unstructured.Unstructured, log logr.Logger) (resp engineapi.RuleResponse, patchedResource unstructured.Unstructured) {
+	startTime := time.Now()
+	logger := log.WithName("ProcessMergePatch").WithValues("rule", ruleName)
+	logger.V(4).Info("started applying merge patch", "startTime", startTime)
+	resp.Name = ruleName
+	resp.Type = engineapi.Mutation
+
+	defer func() {
+		resp.Stats.ProcessingTime = time.Since(startTime)
+		resp.Stats.Timestamp = startTime.Unix()
+		logger.V(4).Info("finished applying merge patch", "processingTime", resp.Stats.ProcessingTime.String())
+	}()
+
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		resp.Status = engineapi.RuleStatusFail
+		logger.Error(err, "failed to marshal patch")
+		resp.Message = fmt.Sprintf("failed to process patchMerge: %v", err)
+		return resp, resource
+	}
+
+	base, err := json.Marshal(resource.Object)
+	if err != nil {
+		resp.Status = engineapi.RuleStatusFail
+		logger.Error(err, "failed to marshal resource")
+		resp.Message = fmt.Sprintf("failed to process patchMerge: %v", err)
+		return resp, resource
+	}
+
+	logger.V(3).Info("applying merge patch", "patch", string(patchBytes))
+	patchedBytes, err := jsonpatch.MergePatch(base, patchBytes)
+	if err != nil {
+		logger.Error(err, "failed to apply patchMerge")
+		resp.Status = engineapi.RuleStatusFail
+		resp.Message = fmt.Sprintf("failed to apply patchMerge: %v", err)
+		return resp, resource
+	}
+
+	err = patchedResource.UnmarshalJSON(patchedBytes)
+	if err != nil {
+		logger.Error(err, "failed to unmarshal resource")
+		resp.Status = engineapi.RuleStatusFail
+		resp.Message = fmt.Sprintf("failed to process patchMerge: %v", err)
+		return resp, resource
+	}
+
+	jsonPatches, err := generatePatches(base, patchedBytes)
+	if err != nil {
+		msg := fmt.Sprintf("failed to generated JSON patches from patched resource: %v", err.Error())
+		resp.Status = engineapi.RuleStatusFail
+		logger.V(2).Info(msg)
+		resp.Message = msg
+		return resp, patchedResource
+	}
+
+	for _, p := range jsonPatches {
+		logger.V(5).Info("generated patch", "patch", string(p))
+	}
+
+	resp.Status = engineapi.RuleStatusPass
+	resp.Patches = jsonPatches
+	resp.Message = "applied merge patch"
+	return resp, patchedResource
+}
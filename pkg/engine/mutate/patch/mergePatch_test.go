@@ -0,0 +1,75 @@
+package patch
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"gotest.tools/assert"
+	"sigs.k8s.io/yaml"
+)
+
+func TestProcessMergePatch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		patch    string
+		expected string
+		patches  []string
+	}{{
+		name: "merge maps",
+		patch: `
+metadata:
+  labels:
+    team: platform
+`,
+		expected: `{"apiVersion":"v1","kind":"Pod","metadata":{"labels":{"app":"nginx","env":"prod","team":"platform"},"name":"nginx"},"spec":{"containers":[{"image":"nginx:1.25","name":"nginx"}],"tolerations":[{"key":"a","operator":"Exists"}]}}`,
+		patches:  []string{`{"op":"add","path":"/metadata/labels/team","value":"platform"}`},
+	}, {
+		name: "remove keys",
+		patch: `
+metadata:
+  labels:
+    env: null
+`,
+		expected: `{"apiVersion":"v1","kind":"Pod","metadata":{"labels":{"app":"nginx"},"name":"nginx"},"spec":{"containers":[{"image":"nginx:1.25","name":"nginx"}],"tolerations":[{"key":"a","operator":"Exists"}]}}`,
+		patches:  []string{`{"op":"remove","path":"/metadata/labels/env"}`},
+	}, {
+		name: "replace lists",
+		patch: `
+spec:
+  tolerations:
+  - key: b
+    operator: Exists
+`,
+		expected: `{"apiVersion":"v1","kind":"Pod","metadata":{"labels":{"app":"nginx","env":"prod"},"name":"nginx"},"spec":{"containers":[{"image":"nginx:1.25","name":"nginx"}],"tolerations":[{"key":"b","operator":"Exists"}]}}`,
+		patches:  []string{`{"op":"replace","path":"/spec/tolerations/0/key","value":"b"}`},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resource, err := kubeutils.BytesToUnstructured([]byte(`{
+  "apiVersion": "v1",
+  "kind": "Pod",
+  "metadata": {"name": "nginx", "labels": {"app": "nginx", "env": "prod"}},
+  "spec": {
+    "containers": [{"name": "nginx", "image": "nginx:1.25"}],
+    "tolerations": [{"key": "a", "operator": "Exists"}]
+  }
+}`))
+			assert.NilError(t, err)
+			var patch interface{}
+			assert.NilError(t, yaml.Unmarshal([]byte(tc.patch), &patch))
+			resp, patchedResource := ProcessMergePatch("merge", patch, *resource, logr.Discard())
+			assert.Equal(t, resp.Status, engineapi.RuleStatusPass, resp.Message)
+			actual, err := patchedResource.MarshalJSON()
+			assert.NilError(t, err)
+			assert.Equal(t, strings.TrimSpace(string(actual)), tc.expected)
+			var patches []string
+			for _, p := range resp.Patches {
+				patches = append(patches, string(p))
+			}
+			assert.DeepEqual(t, patches, tc.patches)
+		})
+	}
+}
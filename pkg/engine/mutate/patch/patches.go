@@ -33,6 +33,27 @@ func (h patchStrategicMergeHandler) Patch() (engineapi.RuleResponse, unstructure
 	return ProcessStrategicMergePatch(h.ruleName, h.patch, h.patchedResource, h.logger)
 }
 
+// patchMergeHandler
+type patchMergeHandler struct {
+	ruleName        string
+	patch           apiextensions.JSON
+	patchedResource unstructured.Unstructured
+	logger          logr.Logger
+}
+
+func NewPatchMerge(ruleName string, patch apiextensions.JSON, patchedResource unstructured.Unstructured, logger logr.Logger) Patcher {
+	return patchMergeHandler{
+		ruleName:        ruleName,
+		patch:           patch,
+		patchedResource: patchedResource,
+		logger:          logger,
+	}
+}
+
+func (h patchMergeHandler) Patch() (engineapi.RuleResponse, unstructured.Unstructured) {
+	return ProcessMergePatch(h.ruleName, h.patch, h.patchedResource, h.logger)
+}
+
 // patchesJSON6902Handler
 type patchesJSON6902Handler struct {
 	ruleName        string
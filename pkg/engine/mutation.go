@@ -28,9 +28,11 @@ func (e *engine) mutate(
 	policyContext.JSONContext().Checkpoint()
 	defer policyContext.JSONContext().Restore()
 
+	loadPolicyContext := e.policyContextLoader(policyContext)
 	for _, rule := range autogen.ComputeRules(policy) {
 		startTime := time.Now()
 		logger := internal.LoggerWithRule(logger, rule)
+		ctx, trace := newRuleTrace(ctx, policyContext, rule, rule.HasMutate())
 		handlerFactory := func() (handlers.Handler, error) {
 			if !rule.HasMutate() {
 				return nil, nil
@@ -48,8 +50,12 @@ func (e *engine) mutate(
 			matchedResource,
 			rule,
 			engineapi.Mutation,
+			loadPolicyContext,
 		)
 		matchedResource = resource
+		if trace != nil {
+			resp.Traces = append(resp.Traces, *trace)
+		}
 		for _, ruleResp := range ruleResp {
 			ruleResp := ruleResp
 			internal.AddRuleResponse(&resp, &ruleResp, startTime)
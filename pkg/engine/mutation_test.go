@@ -679,6 +679,67 @@ func Test_foreach(t *testing.T) {
 	}
 }
 
+func Test_foreach_context_on_error(t *testing.T) {
+	policyRaw := []byte(`{
+    "apiVersion": "kyverno.io/v1",
+    "kind": "ClusterPolicy",
+    "metadata": {
+      "name": "replace-image-registry"
+    },
+    "spec": {
+      "rules": [
+        {
+          "name": "replace-image-registry",
+          "match": {
+            "resources": {
+              "kinds": ["Pod"]
+            }
+          },
+          "mutate": {
+            "foreach": [
+              {
+                "list": "request.object.spec.containers",
+                "context": [
+                  {"name": "registry", "globalReference": {"name": "registry"}, "onError": {"action": "UseDefault", "default": "registry.io"}}
+                ],
+                "patchStrategicMerge": {
+                  "spec": {
+                    "containers": [
+                      {
+                        "name": "{{ element.name }}",
+                        "image": "{{ registry }}/{{ element.image }}"
+                      }
+                    ]
+                  }
+                }
+              }
+            ]
+          }
+        }
+      ]
+    }
+  }`)
+	resourceRaw := []byte(`{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "test"}, "spec": {"containers": [{"name": "test1", "image": "foo1/bash1:5.0"}]}}`)
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(policyRaw, &policy))
+	resource, err := kubeutils.BytesToUnstructured(resourceRaw)
+	assert.NilError(t, err)
+	ctx := enginecontext.NewContext()
+	assert.NilError(t, ctx.AddResource(resource.Object))
+	policyContext := NewPolicyContextWithJsonContext(kyverno.Create, ctx).
+		WithPolicy(&policy).
+		WithNewResource(*resource)
+
+	er := testMutate(context.TODO(), nil, registryclient.NewOrDie(), policyContext, nil)
+
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusPass)
+	assert.DeepEqual(t, er.PolicyResponse.Rules[0].ContextFallbacks, []string{"registry"})
+	containers, _, err := unstructured.NestedSlice(er.PatchedResource.Object, "spec", "containers")
+	assert.NilError(t, err)
+	assert.Equal(t, containers[0].(map[string]interface{})["image"], "registry.io/foo1/bash1:5.0")
+}
+
 func Test_foreach_element_mutation(t *testing.T) {
 	policyRaw := []byte(`{
   "apiVersion": "kyverno.io/v1",
@@ -1153,6 +1214,81 @@ func Test_mutate_nested_foreach(t *testing.T) {
 	}
 }
 
+func Test_mutate_foreach_patchMerge(t *testing.T) {
+	policyRaw := []byte(`{
+    "apiVersion": "kyverno.io/v1",
+    "kind": "ClusterPolicy",
+    "metadata": {
+      "name": "label-containers"
+    },
+    "spec": {
+      "rules": [
+        {
+          "name": "label-containers",
+          "match": {
+            "any": [
+              {
+                "resources": {
+                  "kinds": [
+                    "Pod"
+                  ]
+                }
+              }
+            ]
+          },
+          "mutate": {
+            "foreach": [
+              {
+                "list": "request.object.spec.containers",
+                "patchMerge": {
+                  "metadata": {
+                    "labels": {
+                      "container": "{{ element.name }}",
+                      "stale": null
+                    }
+                  }
+                }
+              }
+            ]
+          }
+        }
+      ]
+    }
+  }`)
+
+	resourceRaw := []byte(`{
+    "apiVersion": "v1",
+    "kind": "Pod",
+    "metadata": {
+      "name": "test",
+      "labels": {
+        "app": "test",
+        "stale": "true"
+      }
+    },
+    "spec": {
+      "containers": [
+        {
+          "name": "nginx",
+          "image": "nginx:1.25"
+        },
+        {
+          "name": "busybox",
+          "image": "busybox:1.36"
+        }
+      ]
+    }
+  }`)
+
+	er := testApplyPolicyToResource(t, policyRaw, resourceRaw)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusPass)
+
+	labels, _, err := unstructured.NestedStringMap(er.PatchedResource.Object, "metadata", "labels")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, labels, map[string]string{"app": "test", "container": "busybox"})
+}
+
 func Test_mutate_existing_resources(t *testing.T) {
 	tests := []struct {
 		name       string
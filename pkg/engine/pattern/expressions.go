@@ -0,0 +1,146 @@
+package pattern
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/engine/operator"
+	"k8s.io/utils/lru"
+)
+
+const (
+	// RegexPrefix introduces a regular expression pattern, e.g. re:^v[0-9]+$
+	RegexPrefix = "re:"
+	// CIDRPrefix introduces a CIDR membership pattern, e.g. cidr:10.0.0.0/8
+	CIDRPrefix = "cidr:"
+)
+
+// regexCache caches compiled regular expressions, patterns are evaluated for every resource
+var regexCache = lru.New(1000)
+
+func compileRegex(expr string) (*regexp.Regexp, error) {
+	if cached, ok := regexCache.Get(expr); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	regexCache.Add(expr, re)
+	return re, nil
+}
+
+// isRegexPattern checks if a string pattern is a regular expression, optionally negated.
+// Regular expressions take the whole pattern and are not split on logical operators,
+// alternatives must be expressed in the regular expression itself.
+func isRegexPattern(pattern string) bool {
+	pattern = strings.TrimSpace(pattern)
+	pattern = strings.TrimPrefix(pattern, string(operator.NotEqual))
+	return strings.HasPrefix(strings.TrimSpace(pattern), RegexPrefix)
+}
+
+// ValidateExpressions checks the regular expressions and CIDR ranges used in a string pattern
+func ValidateExpressions(pattern string) error {
+	var conditions []string
+	if isRegexPattern(pattern) {
+		conditions = append(conditions, pattern)
+	} else {
+		for _, or := range strings.Split(pattern, "|") {
+			conditions = append(conditions, strings.Split(or, "&")...)
+		}
+	}
+	for _, condition := range conditions {
+		condition = strings.TrimSpace(condition)
+		op := operator.GetOperatorFromStringPattern(condition)
+		condition = strings.TrimSpace(condition[len(op):])
+		if expr, ok := strings.CutPrefix(condition, RegexPrefix); ok {
+			if _, err := compileRegex(expr); err != nil {
+				return fmt.Errorf("invalid regular expression %q: %w", expr, err)
+			}
+		} else if cidr, ok := strings.CutPrefix(condition, CIDRPrefix); ok {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return fmt.Errorf("invalid CIDR range %q: %w", cidr, err)
+			}
+		} else {
+			continue
+		}
+		if op != operator.Equal && op != operator.NotEqual {
+			return fmt.Errorf("operator %s is not applicable to %s", op, condition)
+		}
+	}
+	return nil
+}
+
+func compareRegex(log logr.Logger, value interface{}, expr string, op operator.Operator) bool {
+	strValue, ok := toString(value)
+	if !ok {
+		log.V(4).Info("unexpected type", "got", value, "expect", expr)
+		return false
+	}
+	re, err := compileRegex(expr)
+	if err != nil {
+		log.Error(err, "failed to compile regular expression", "pattern", expr)
+		return false
+	}
+	switch op {
+	case operator.Equal:
+		return re.MatchString(strValue)
+	case operator.NotEqual:
+		return !re.MatchString(strValue)
+	}
+	log.V(2).Info("Operators >, >=, <, <= are not applicable to regular expressions")
+	return false
+}
+
+func compareCIDR(log logr.Logger, value interface{}, cidr string, op operator.Operator) bool {
+	strValue, ok := value.(string)
+	if !ok {
+		log.V(4).Info("unexpected type", "got", value, "expect", cidr)
+		return false
+	}
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		log.Error(err, "failed to parse CIDR range", "pattern", cidr)
+		return false
+	}
+	contained := false
+	if ip := net.ParseIP(strValue); ip != nil {
+		contained = network.Contains(ip)
+	} else if _, subnet, err := net.ParseCIDR(strValue); err == nil {
+		// a range is contained if its first address is and its prefix is at least as long
+		patternOnes, _ := network.Mask.Size()
+		subnetOnes, _ := subnet.Mask.Size()
+		contained = network.Contains(subnet.IP) && subnetOnes >= patternOnes
+	} else {
+		log.V(4).Info("value is not an IP address or CIDR range", "value", strValue)
+		return false
+	}
+	switch op {
+	case operator.Equal:
+		return contained
+	case operator.NotEqual:
+		return !contained
+	}
+	log.V(2).Info("Operators >, >=, <, <= are not applicable to CIDR ranges")
+	return false
+}
+
+func toString(value interface{}) (string, bool) {
+	switch typed := value.(type) {
+	case string:
+		return typed, true
+	case int:
+		return strconv.Itoa(typed), true
+	case int64:
+		return strconv.FormatInt(typed, 10), true
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(typed), true
+	}
+	return "", false
+}
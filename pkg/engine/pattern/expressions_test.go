@@ -0,0 +1,149 @@
+package pattern
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+func Test_validateRegexPatterns(t *testing.T) {
+	type args struct {
+		value   interface{}
+		pattern string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{{
+		name: "match",
+		args: args{value: "v12", pattern: "re:^v[0-9]+$"},
+		want: true,
+	}, {
+		name: "no match",
+		args: args{value: "latest", pattern: "re:^v[0-9]+$"},
+		want: false,
+	}, {
+		name: "alternation is not split",
+		args: args{value: "prod", pattern: "re:^(dev|prod)$"},
+		want: true,
+	}, {
+		name: "negation",
+		args: args{value: "nginx:latest", pattern: "!re::latest$"},
+		want: false,
+	}, {
+		name: "negation no match",
+		args: args{value: "nginx:1.25", pattern: "!re::latest$"},
+		want: true,
+	}, {
+		name: "int value",
+		args: args{value: 8080, pattern: "re:^80[0-9]{2}$"},
+		want: true,
+	}, {
+		name: "float value",
+		args: args{value: 1.5, pattern: "re:^1\\.5$"},
+		want: true,
+	}, {
+		name: "combined with wildcard",
+		args: args{value: "v1", pattern: "latest | re:^v[0-9]+$"},
+		want: true,
+	}, {
+		name: "invalid regex",
+		args: args{value: "v1", pattern: "re:^v[0-9+$"},
+		want: false,
+	}, {
+		name: "map value",
+		args: args{value: map[string]interface{}{}, pattern: "re:.*"},
+		want: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validateStringPatterns(logr.Discard(), tt.args.value, tt.args.pattern); got != tt.want {
+				t.Errorf("validateStringPatterns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateCIDRPatterns(t *testing.T) {
+	type args struct {
+		value   interface{}
+		pattern string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{{
+		name: "ip in range",
+		args: args{value: "10.1.2.3", pattern: "cidr:10.0.0.0/8"},
+		want: true,
+	}, {
+		name: "ip not in range",
+		args: args{value: "192.168.1.1", pattern: "cidr:10.0.0.0/8"},
+		want: false,
+	}, {
+		name: "ip in one of the ranges",
+		args: args{value: "192.168.1.1", pattern: "cidr:10.0.0.0/8 | cidr:192.168.0.0/16"},
+		want: true,
+	}, {
+		name: "negation",
+		args: args{value: "169.254.169.254", pattern: "!cidr:169.254.0.0/16"},
+		want: false,
+	}, {
+		name: "subnet in range",
+		args: args{value: "10.1.0.0/16", pattern: "cidr:10.0.0.0/8"},
+		want: true,
+	}, {
+		name: "subnet larger than range",
+		args: args{value: "10.0.0.0/7", pattern: "cidr:10.0.0.0/8"},
+		want: false,
+	}, {
+		name: "ipv6",
+		args: args{value: "fd00::1", pattern: "cidr:fd00::/8"},
+		want: true,
+	}, {
+		name: "invalid value",
+		args: args{value: "not-an-ip", pattern: "cidr:10.0.0.0/8"},
+		want: false,
+	}, {
+		name: "invalid value negated",
+		args: args{value: "not-an-ip", pattern: "!cidr:10.0.0.0/8"},
+		want: false,
+	}, {
+		name: "non string value",
+		args: args{value: 10, pattern: "cidr:10.0.0.0/8"},
+		want: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validateStringPatterns(logr.Discard(), tt.args.value, tt.args.pattern); got != tt.want {
+				t.Errorf("validateStringPatterns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateExpressions(t *testing.T) {
+	tests := []struct {
+		pattern string
+		wantErr bool
+	}{
+		{pattern: "re:^v[0-9]+$"},
+		{pattern: "!re:^(dev|prod)$"},
+		{pattern: "cidr:10.0.0.0/8 | cidr:fd00::/8"},
+		{pattern: "?* & !cidr:169.254.0.0/16"},
+		{pattern: ">= 10 | 1-5"},
+		{pattern: "re:^v[0-9+$", wantErr: true},
+		{pattern: "cidr:10.0.0.0/33", wantErr: true},
+		{pattern: "latest | cidr:10.0.0.0", wantErr: true},
+		{pattern: ">cidr:10.0.0.0/8", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			if err := ValidateExpressions(tt.pattern); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateExpressions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -153,6 +153,9 @@ func validateStringPatterns(log logr.Logger, value interface{}, pattern string)
 	if value == pattern {
 		return true
 	}
+	if isRegexPattern(pattern) {
+		return validateStringPattern(log, value, strings.TrimSpace(pattern))
+	}
 	for _, condition := range strings.Split(pattern, "|") {
 		condition = strings.Trim(condition, " ")
 		if checkForAndConditionsAndValidate(log, value, condition) {
@@ -205,6 +208,12 @@ func split(pattern string, r *regexp.Regexp) (string, string, bool) {
 }
 
 func validateString(log logr.Logger, value interface{}, pattern string, op operator.Operator) bool {
+	if expr, ok := strings.CutPrefix(pattern, RegexPrefix); ok {
+		return compareRegex(log, value, expr, op)
+	}
+	if cidr, ok := strings.CutPrefix(pattern, CIDRPrefix); ok {
+		return compareCIDR(log, value, cidr, op)
+	}
 	return compareDuration(log, value, pattern, op) ||
 		compareQuantity(log, value, pattern, op) ||
 		compareString(log, value, pattern, op)
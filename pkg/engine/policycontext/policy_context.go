@@ -8,6 +8,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	enginectx "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -52,6 +53,9 @@ type PolicyContext struct {
 
 	// admissionOperation represents if the caller is from the webhook server
 	admissionOperation bool
+
+	// explain enables recording the rules evaluation traces
+	explain bool
 }
 
 // engineapi.PolicyContext interface
@@ -108,6 +112,10 @@ func (c *PolicyContext) SetElement(element unstructured.Unstructured) {
 	c.element = element
 }
 
+func (c *PolicyContext) Explain() bool {
+	return c.explain
+}
+
 func (c *PolicyContext) JSONContext() enginectx.Interface {
 	return c.jsonContext
 }
@@ -165,6 +173,12 @@ func (c *PolicyContext) WithResources(newResource unstructured.Unstructured, old
 	return c.WithNewResource(newResource).WithOldResource(oldResource)
 }
 
+func (c *PolicyContext) WithExplain(explain bool) *PolicyContext {
+	copy := c.copy()
+	copy.explain = explain
+	return copy
+}
+
 func (c *PolicyContext) withAdmissionOperation(admissionOperation bool) *PolicyContext {
 	copy := c.copy()
 	copy.admissionOperation = admissionOperation
@@ -194,7 +208,7 @@ func NewPolicyContextFromAdmissionRequest(
 	gvk schema.GroupVersionKind,
 	configuration config.Configuration,
 ) (*PolicyContext, error) {
-	ctx, err := newVariablesContext(request, &admissionInfo)
+	ctx, err := newVariablesContext(request, &admissionInfo, configuration)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create policy rule context: %w", err)
 	}
@@ -215,8 +229,8 @@ func NewPolicyContextFromAdmissionRequest(
 	return policyContext, nil
 }
 
-func newVariablesContext(request admissionv1.AdmissionRequest, userRequestInfo *kyvernov1beta1.RequestInfo) (enginectx.Interface, error) {
-	ctx := enginectx.NewContext()
+func newVariablesContext(request admissionv1.AdmissionRequest, userRequestInfo *kyvernov1beta1.RequestInfo, configuration config.Configuration) (enginectx.Interface, error) {
+	ctx := enginectx.NewContext(jmespath.WithConfiguration(configuration))
 	if err := ctx.AddRequest(request); err != nil {
 		return nil, fmt.Errorf("failed to load incoming request in context: %w", err)
 	}
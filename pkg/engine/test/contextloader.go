@@ -48,33 +48,35 @@ func (l *mockContextLoader) Load(
 	rclient registryclient.Client,
 	contextEntries []kyvernov1.ContextEntry,
 	jsonContext enginecontext.Interface,
-) error {
+) ([]string, error) {
 	if l.values != nil {
 		policy := l.values[l.policyName]
 		if policy.Rules != nil {
 			rule := policy.Rules[l.ruleName]
 			for key, value := range rule.Values {
 				if err := jsonContext.AddVariable(key, value); err != nil {
-					return err
+					return nil, err
 				}
 			}
 		}
 	}
 	// Context Variable should be loaded after the values loaded from values file
+	var fallbacks []string
 	for _, entry := range contextEntries {
+		var err error
 		if entry.ImageRegistry != nil && rclient != nil {
-			if err := engineapi.LoadImageData(ctx, rclient, l.logger, entry, jsonContext); err != nil {
-				return err
-			}
+			err = engineapi.LoadImageData(ctx, rclient, l.logger, entry, jsonContext, nil)
 		} else if entry.Variable != nil {
-			if err := engineapi.LoadVariable(l.logger, entry, jsonContext); err != nil {
-				return err
-			}
+			err = engineapi.LoadVariable(l.logger, entry, jsonContext, nil)
 		} else if entry.APICall != nil && l.allowApiCall {
-			if err := engineapi.LoadAPIData(ctx, l.logger, entry, jsonContext, client); err != nil {
-				return err
+			err = engineapi.LoadAPIData(ctx, l.logger, entry, jsonContext, client, nil, nil, nil)
+		}
+		if err != nil {
+			if err := engineapi.HandleContextEntryError(l.logger, entry, jsonContext, err); err != nil {
+				return nil, err
 			}
+			fallbacks = append(fallbacks, entry.Name)
 		}
 	}
-	return nil
+	return fallbacks, nil
 }
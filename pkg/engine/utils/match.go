@@ -294,3 +294,65 @@ func matchesResourceDescriptionExcludeHelper(
 	// len(errs) != 0 if the filter excluded the resource
 	return errs
 }
+
+// ExplainResourceDescription returns the match clause of the rule selecting the resource and the exclude clause
+// excluding it, clauses are empty when the resource is not selected or not excluded
+func ExplainResourceDescription(
+	resource unstructured.Unstructured,
+	rule kyvernov1.Rule,
+	admissionInfo kyvernov1beta1.RequestInfo,
+	namespaceLabels map[string]string,
+	gvk schema.GroupVersionKind,
+	subresource string,
+	operation kyvernov1.AdmissionOperation,
+) (string, string) {
+	if resource.Object == nil {
+		return "", ""
+	}
+	rule = *rule.DeepCopy()
+	resource = *resource.DeepCopy()
+	var matchClause, excludeClause string
+	if len(rule.MatchResources.Any) > 0 {
+		for i, rmr := range rule.MatchResources.Any {
+			if len(matchesResourceDescriptionMatchHelper(rmr, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)) == 0 {
+				matchClause = fmt.Sprintf("match.any[%d]", i)
+				break
+			}
+		}
+	} else if len(rule.MatchResources.All) > 0 {
+		matchClause = "match.all"
+		for _, rmr := range rule.MatchResources.All {
+			if len(matchesResourceDescriptionMatchHelper(rmr, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)) != 0 {
+				matchClause = ""
+				break
+			}
+		}
+	} else {
+		rmr := kyvernov1.ResourceFilter{UserInfo: rule.MatchResources.UserInfo, ResourceDescription: rule.MatchResources.ResourceDescription}
+		if len(matchesResourceDescriptionMatchHelper(rmr, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)) == 0 {
+			matchClause = "match"
+		}
+	}
+	if len(rule.ExcludeResources.Any) > 0 {
+		for i, rer := range rule.ExcludeResources.Any {
+			if len(matchesResourceDescriptionExcludeHelper(rer, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)) != 0 {
+				excludeClause = fmt.Sprintf("exclude.any[%d]", i)
+				break
+			}
+		}
+	} else if len(rule.ExcludeResources.All) > 0 {
+		excludeClause = "exclude.all"
+		for _, rer := range rule.ExcludeResources.All {
+			if len(matchesResourceDescriptionExcludeHelper(rer, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)) == 0 {
+				excludeClause = ""
+				break
+			}
+		}
+	} else {
+		rer := kyvernov1.ResourceFilter{UserInfo: rule.ExcludeResources.UserInfo, ResourceDescription: rule.ExcludeResources.ResourceDescription}
+		if len(matchesResourceDescriptionExcludeHelper(rer, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)) != 0 {
+			excludeClause = "exclude"
+		}
+	}
+	return matchClause, excludeClause
+}
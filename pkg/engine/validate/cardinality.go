@@ -0,0 +1,130 @@
+package validate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/engine/anchor"
+	"github.com/kyverno/kyverno/pkg/engine/operator"
+	"github.com/kyverno/kyverno/pkg/engine/pattern"
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
+)
+
+// cardinality pattern keys
+const (
+	// SizeKey asserts the number of elements of an array or map, e.g. {"$size": "<= 3"}
+	SizeKey = "$size"
+	// CountKey asserts the number of array elements matching the MatchKey sub-pattern, e.g. {"$count": 1, "$match": {...}}
+	CountKey = "$count"
+	// MatchKey is the sub-pattern counted by CountKey, at least one element must match it when CountKey is not set
+	MatchKey = "$match"
+)
+
+// IsCardinalityPattern checks if a pattern map asserts the cardinality of a resource element
+func IsCardinalityPattern(pattern map[string]interface{}) bool {
+	for _, key := range []string{SizeKey, CountKey, MatchKey} {
+		if _, ok := pattern[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateCardinalityPattern checks the keys and conditions of a cardinality pattern
+func ValidateCardinalityPattern(pattern map[string]interface{}) error {
+	for key, value := range pattern {
+		switch key {
+		case SizeKey, CountKey:
+			if err := validateCardinalityCondition(value); err != nil {
+				return fmt.Errorf("invalid %s condition: %w", key, err)
+			}
+		case MatchKey:
+			if _, ok := value.(map[string]interface{}); !ok {
+				return fmt.Errorf("%s must be a map, found %T", MatchKey, value)
+			}
+		default:
+			return fmt.Errorf("%s can't be combined with %s, %s and %s", key, SizeKey, CountKey, MatchKey)
+		}
+	}
+	if _, ok := pattern[CountKey]; ok {
+		if _, ok := pattern[MatchKey]; !ok {
+			return fmt.Errorf("%s requires %s", CountKey, MatchKey)
+		}
+	}
+	return nil
+}
+
+func validateCardinalityCondition(condition interface{}) error {
+	switch typed := condition.(type) {
+	case int, int64:
+		return nil
+	case float64:
+		if typed != float64(int64(typed)) {
+			return fmt.Errorf("expected an integer, found %v", typed)
+		}
+		return nil
+	case string:
+		// conditions containing variables are checked after substitution
+		if regex.IsVariable(typed) {
+			return nil
+		}
+		for _, or := range strings.Split(typed, "|") {
+			for _, and := range strings.Split(or, "&") {
+				and = strings.TrimSpace(and)
+				op := operator.GetOperatorFromStringPattern(and)
+				if op == operator.InRange || op == operator.NotInRange {
+					continue
+				}
+				if _, err := strconv.Atoi(strings.TrimSpace(and[len(op):])); err != nil {
+					return fmt.Errorf("expected a number or a numeric comparison, found %q", and)
+				}
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("expected a number or a string, found %T", condition)
+	}
+}
+
+// validateCardinality validates the size of a resource array or map, and the number of array elements matching a sub-pattern
+func validateCardinality(log logr.Logger, resourceElement interface{}, patternMap map[string]interface{}, originPattern interface{}, path string) (string, error) {
+	var elements []interface{}
+	size := 0
+	switch typed := resourceElement.(type) {
+	case []interface{}:
+		elements = typed
+		size = len(typed)
+	case map[string]interface{}:
+		size = len(typed)
+	case nil:
+	default:
+		return path, fmt.Errorf("cardinality pattern expects an array or a map at path %s, found %T", path, resourceElement)
+	}
+	if condition, ok := patternMap[SizeKey]; ok {
+		if !pattern.Validate(log, int64(size), condition) {
+			return path, fmt.Errorf("size %d does not satisfy '%v' at path %s", size, condition, path)
+		}
+	}
+	if match, ok := patternMap[MatchKey]; ok {
+		if _, ok := resourceElement.(map[string]interface{}); ok {
+			return path, fmt.Errorf("%s expects an array at path %s, found a map", MatchKey, path)
+		}
+		count := 0
+		for i, element := range elements {
+			// elements that don't match are counted out, their anchors must not affect the rest of the pattern
+			if _, err := validateResourceElement(log, element, match, originPattern, path+strconv.Itoa(i)+"/", anchor.NewAnchorMap()); err == nil {
+				count++
+			}
+		}
+		condition, ok := patternMap[CountKey]
+		if !ok {
+			condition = ">0"
+		}
+		if !pattern.Validate(log, int64(count), condition) {
+			return path, fmt.Errorf("%d elements match the pattern at path %s, expected '%v'", count, path, condition)
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,110 @@
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"gotest.tools/assert"
+)
+
+func Test_Cardinality(t *testing.T) {
+	resource := []byte(`{
+		"spec": {
+			"containers": [
+				{"name": "nginx", "image": "nginx:1.25"},
+				{"name": "sidecar", "image": "envoy:latest"},
+				{"name": "logger", "image": "fluentd:latest"}
+			],
+			"tolerations": [
+				{"key": "dedicated", "operator": "Equal", "value": "gpu"}
+			],
+			"nodeSelector": {"zone": "a", "disk": "ssd"}
+		}
+	}`)
+	testCases := []struct {
+		name    string
+		pattern []byte
+		wantErr string
+	}{{
+		name:    "array size pass",
+		pattern: []byte(`{"spec": {"containers": {"$size": "<= 3"}}}`),
+	}, {
+		name:    "array size fail",
+		pattern: []byte(`{"spec": {"containers": {"$size": "<3"}}}`),
+		wantErr: "size 3 does not satisfy '<3' at path /spec/containers/",
+	}, {
+		name:    "array size integer",
+		pattern: []byte(`{"spec": {"tolerations": {"$size": 1}}}`),
+	}, {
+		name:    "map size",
+		pattern: []byte(`{"spec": {"nodeSelector": {"$size": "1-2"}}}`),
+	}, {
+		name:    "missing element has size zero",
+		pattern: []byte(`{"spec": {"volumes": {"$size": 0}}}`),
+	}, {
+		name:    "at least one matching element",
+		pattern: []byte(`{"spec": {"tolerations": {"$match": {"key": "dedicated", "value": "gpu"}}}}`),
+	}, {
+		name:    "no matching element",
+		pattern: []byte(`{"spec": {"tolerations": {"$match": {"key": "dedicated", "value": "tpu"}}}}`),
+		wantErr: "0 elements match the pattern at path /spec/tolerations/, expected '>0'",
+	}, {
+		name:    "exactly one matching element",
+		pattern: []byte(`{"spec": {"containers": {"$count": 1, "$match": {"image": "*:1.25"}}}}`),
+	}, {
+		name:    "more than one matching element",
+		pattern: []byte(`{"spec": {"containers": {"$count": 1, "$match": {"image": "*:latest"}}}}`),
+		wantErr: "2 elements match the pattern at path /spec/containers/, expected '1'",
+	}, {
+		name:    "size and count",
+		pattern: []byte(`{"spec": {"containers": {"$size": ">1", "$count": "<3", "$match": {"image": "*:latest"}}}}`),
+	}, {
+		name:    "match on a map",
+		pattern: []byte(`{"spec": {"nodeSelector": {"$match": {"zone": "a"}}}}`),
+		wantErr: "$match expects an array at path /spec/nodeSelector/, found a map",
+	}, {
+		name:    "scalar element",
+		pattern: []byte(`{"spec": {"containers": [{"name": {"$size": 1}}]}}`),
+		wantErr: "cardinality pattern expects an array or a map",
+	}}
+	var res interface{}
+	assert.NilError(t, json.Unmarshal(resource, &res))
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var pattern interface{}
+			assert.NilError(t, json.Unmarshal(tc.pattern, &pattern))
+			err := MatchPattern(logr.Discard(), res, pattern)
+			if tc.wantErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCardinalityPattern(t *testing.T) {
+	testCases := []struct {
+		name    string
+		pattern map[string]interface{}
+		wantErr bool
+	}{
+		{name: "number", pattern: map[string]interface{}{"$size": float64(3)}},
+		{name: "comparison", pattern: map[string]interface{}{"$size": ">= 1 & <= 5"}},
+		{name: "range", pattern: map[string]interface{}{"$size": "1-3"}},
+		{name: "variable", pattern: map[string]interface{}{"$size": "<= {{ request.object.spec.replicas }}"}},
+		{name: "count and match", pattern: map[string]interface{}{"$count": "1", "$match": map[string]interface{}{"name": "?*"}}},
+		{name: "fraction", pattern: map[string]interface{}{"$size": 1.5}, wantErr: true},
+		{name: "not a number", pattern: map[string]interface{}{"$size": "<= many"}, wantErr: true},
+		{name: "count without match", pattern: map[string]interface{}{"$count": 1}, wantErr: true},
+		{name: "match not a map", pattern: map[string]interface{}{"$match": "?*"}, wantErr: true},
+		{name: "other key", pattern: map[string]interface{}{"$size": 1, "name": "?*"}, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateCardinalityPattern(tc.pattern)
+			assert.Equal(t, err != nil, tc.wantErr, err)
+		})
+	}
+}
@@ -26,6 +26,17 @@ func (e *PatternError) Error() string {
 	return e.Err.Error()
 }
 
+// ValueError is returned when a resource value doesn't match the pattern value
+type ValueError struct {
+	Path     string
+	Expected interface{}
+	Actual   interface{}
+}
+
+func (e *ValueError) Error() string {
+	return fmt.Sprintf("resource value '%v' does not match '%v' at path %s", e.Actual, e.Expected, e.Path)
+}
+
 // MatchPattern is a start of element-by-element pattern validation process.
 // It assumes that validation is started from root, so "/" is passed
 func MatchPattern(logger logr.Logger, resource, pattern interface{}) error {
@@ -72,6 +83,9 @@ func validateResourceElement(log logr.Logger, resourceElement, patternElement, o
 	switch typedPatternElement := patternElement.(type) {
 	// map
 	case map[string]interface{}:
+		if IsCardinalityPattern(typedPatternElement) {
+			return validateCardinality(log, resourceElement, typedPatternElement, originPattern, path)
+		}
 		typedResourceElement, ok := resourceElement.(map[string]interface{})
 		if !ok {
 			log.V(4).Info("Pattern and resource have different structures.", "path", path, "expected", fmt.Sprintf("%T", patternElement), "current", fmt.Sprintf("%T", resourceElement))
@@ -96,13 +110,13 @@ func validateResourceElement(log logr.Logger, resourceElement, patternElement, o
 		case []interface{}:
 			for _, res := range resource {
 				if !pattern.Validate(log, res, patternElement) {
-					return path, fmt.Errorf("resource value '%v' does not match '%v' at path %s", resourceElement, patternElement, path)
+					return path, &ValueError{Path: path, Expected: patternElement, Actual: resourceElement}
 				}
 			}
 			return "", nil
 		default:
 			if !pattern.Validate(log, resourceElement, patternElement) {
-				return path, fmt.Errorf("resource value '%v' does not match '%v' at path %s", resourceElement, patternElement, path)
+				return path, &ValueError{Path: path, Expected: patternElement, Actual: resourceElement}
 			}
 		}
 
@@ -26,9 +26,11 @@ func (e *engine) validate(
 	policyContext.JSONContext().Checkpoint()
 	defer policyContext.JSONContext().Restore()
 
+	loadPolicyContext := e.policyContextLoader(policyContext)
 	for _, rule := range autogen.ComputeRules(policy) {
 		startTime := time.Now()
 		logger := internal.LoggerWithRule(logger, rule)
+		ctx, trace := newRuleTrace(ctx, policyContext, rule, rule.HasValidate() || rule.HasVerifyImageChecks())
 		handlerFactory := func() (handlers.Handler, error) {
 			hasValidate := rule.HasValidate()
 			hasVerifyImageChecks := rule.HasVerifyImageChecks()
@@ -38,6 +40,7 @@ func (e *engine) validate(
 			if hasValidate {
 				hasVerifyManifest := rule.HasVerifyManifests()
 				hasValidatePss := rule.HasValidatePodSecurity()
+				hasValidateCEL := rule.HasValidateCEL()
 				if hasVerifyManifest {
 					return validation.NewValidateManifestHandler(
 						policyContext,
@@ -45,6 +48,8 @@ func (e *engine) validate(
 					)
 				} else if hasValidatePss {
 					return validation.NewValidatePssHandler()
+				} else if hasValidateCEL {
+					return validation.NewValidateCELHandler()
 				} else {
 					return validation.NewValidateResourceHandler()
 				}
@@ -66,8 +71,12 @@ func (e *engine) validate(
 			matchedResource,
 			rule,
 			engineapi.Validation,
+			loadPolicyContext,
 		)
 		matchedResource = resource
+		if trace != nil {
+			resp.Traces = append(resp.Traces, *trace)
+		}
 		for _, ruleResp := range ruleResp {
 			ruleResp := ruleResp
 			internal.AddRuleResponse(&resp, &ruleResp, startTime)
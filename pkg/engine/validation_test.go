@@ -3,6 +3,7 @@ package engine
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
 
@@ -17,6 +18,7 @@ import (
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"gotest.tools/assert"
 	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func testValidate(
@@ -3226,3 +3228,520 @@ func Test_ValidatePattern_anyPattern(t *testing.T) {
 		})
 	}
 }
+
+func Test_ValidateCEL(t *testing.T) {
+	policyRaw := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "check-deployment-replicas"},
+		"spec": {
+		  "rules": [
+			{
+			  "name": "check-replicas",
+			  "match": {"resources": { "kinds": [ "Deployment" ] } },
+			  "context": [
+				{"name": "maxReplicas", "variable": {"value": 3}}
+			  ],
+			  "validate": {
+				"cel": {
+				  "params": {"team": "platform"},
+				  "expressions": [
+					{
+					  "expression": "object.spec.replicas <= maxReplicas",
+					  "message": "deployment {{ request.object.metadata.name }} has too many replicas"
+					},
+					{
+					  "expression": "has(object.metadata.labels) && object.metadata.labels.team == params.team"
+					}
+				  ]
+				}
+			  }
+			}
+		  ]
+		}
+	  }`)
+	testCases := []struct {
+		description string
+		resourceRaw []byte
+		status      engineapi.RuleStatus
+		message     string
+	}{
+		{
+			description: "pass",
+			resourceRaw: []byte(`{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "test", "labels": {"team": "platform"}}, "spec": {"replicas": 2}}`),
+			status:      engineapi.RuleStatusPass,
+			message:     "validation rule 'check-replicas' passed.",
+		},
+		{
+			description: "fail with message",
+			resourceRaw: []byte(`{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "test", "labels": {"team": "platform"}}, "spec": {"replicas": 5}}`),
+			status:      engineapi.RuleStatusFail,
+			message:     "deployment test has too many replicas",
+		},
+		{
+			description: "fail without message",
+			resourceRaw: []byte(`{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "test"}, "spec": {"replicas": 1}}`),
+			status:      engineapi.RuleStatusFail,
+			message:     "validation error: rule check-replicas failed, expression 'has(object.metadata.labels) && object.metadata.labels.team == params.team' evaluated to false",
+		},
+		{
+			description: "error",
+			resourceRaw: []byte(`{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "test"}, "spec": {"replicas": "two"}}`),
+			status:      engineapi.RuleStatusError,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			testForEach(t, policyRaw, tc.resourceRaw, tc.message, tc.status, nil)
+		})
+	}
+}
+
+func Test_PolicyContext(t *testing.T) {
+	policyRaw := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "check-deployment-replicas"},
+		"spec": {
+		  "context": [
+			{"name": "maxReplicas", "variable": {"value": 3}}
+		  ],
+		  "rules": [
+			{
+			  "name": "check-replicas",
+			  "match": {"resources": { "kinds": [ "Deployment" ] } },
+			  "validate": {
+				"deny": {"conditions": {"any": [{"key": "{{ request.object.spec.replicas }}", "operator": "GreaterThan", "value": "{{ maxReplicas }}"}]}}
+			  }
+			},
+			{
+			  "name": "check-replicas-override",
+			  "match": {"resources": { "kinds": [ "Deployment" ] } },
+			  "context": [
+				{"name": "maxReplicas", "variable": {"value": 10}}
+			  ],
+			  "validate": {
+				"deny": {"conditions": {"any": [{"key": "{{ request.object.spec.replicas }}", "operator": "GreaterThan", "value": "{{ maxReplicas }}"}]}}
+			  }
+			},
+			{
+			  "name": "check-replicas-next",
+			  "match": {"resources": { "kinds": [ "Deployment" ] } },
+			  "validate": {
+				"deny": {"conditions": {"any": [{"key": "{{ request.object.spec.replicas }}", "operator": "GreaterThan", "value": "{{ maxReplicas }}"}]}}
+			  }
+			}
+		  ]
+		}
+	  }`)
+	resourceRaw := []byte(`{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "test"}, "spec": {"replicas": 5}}`)
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(policyRaw, &policy))
+	resourceUnstructured, err := kubeutils.BytesToUnstructured(resourceRaw)
+	assert.NilError(t, err)
+	ctx := enginecontext.NewContext()
+	assert.NilError(t, enginecontext.AddResource(ctx, resourceRaw))
+	policyContext := NewPolicyContextWithJsonContext(kyverno.Create, ctx).
+		WithPolicy(&policy).
+		WithNewResource(*resourceUnstructured)
+	policyLoads := 0
+	inner := engineapi.DefaultContextLoaderFactory(nil)
+	contextLoader := func(policy kyverno.PolicyInterface, rule kyverno.Rule) engineapi.ContextLoader {
+		if rule.Name == "" {
+			policyLoads++
+		}
+		return inner(policy, rule)
+	}
+	er := testValidate(context.TODO(), registryclient.NewOrDie(), policyContext, cfg, contextLoader)
+	assert.Equal(t, policyLoads, 1)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 3)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusFail)
+	assert.Equal(t, er.PolicyResponse.Rules[1].Status, engineapi.RuleStatusPass)
+	// rule context entries are kept for the next rules of the policy
+	assert.Equal(t, er.PolicyResponse.Rules[2].Status, engineapi.RuleStatusPass)
+}
+
+func Test_ContextOnError(t *testing.T) {
+	policyRaw := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "check-deployment-replicas"},
+		"spec": {
+		  "rules": [
+			{
+			  "name": "fail",
+			  "match": {"resources": { "kinds": [ "Deployment" ] } },
+			  "context": [
+				{"name": "limits", "globalReference": {"name": "limits"}}
+			  ],
+			  "validate": {
+				"deny": {"conditions": {"any": [{"key": "{{ request.object.spec.replicas }}", "operator": "GreaterThan", "value": "{{ limits.max }}"}]}}
+			  }
+			},
+			{
+			  "name": "ignore",
+			  "match": {"resources": { "kinds": [ "Deployment" ] } },
+			  "context": [
+				{"name": "limits", "globalReference": {"name": "limits"}, "onError": {"action": "Ignore"}}
+			  ],
+			  "validate": {
+				"deny": {"conditions": {"any": [{"key": "{{ request.object.spec.replicas }}", "operator": "GreaterThan", "value": 10}]}}
+			  }
+			},
+			{
+			  "name": "use-default",
+			  "match": {"resources": { "kinds": [ "Deployment" ] } },
+			  "context": [
+				{"name": "limits", "globalReference": {"name": "limits"}, "onError": {"action": "UseDefault", "default": {"max": 3}}}
+			  ],
+			  "validate": {
+				"deny": {"conditions": {"any": [{"key": "{{ request.object.spec.replicas }}", "operator": "GreaterThan", "value": "{{ limits.max }}"}]}}
+			  }
+			}
+		  ]
+		}
+	  }`)
+	resourceRaw := []byte(`{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "test"}, "spec": {"replicas": 5}}`)
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(policyRaw, &policy))
+	resourceUnstructured, err := kubeutils.BytesToUnstructured(resourceRaw)
+	assert.NilError(t, err)
+	ctx := enginecontext.NewContext()
+	assert.NilError(t, enginecontext.AddResource(ctx, resourceRaw))
+	policyContext := NewPolicyContextWithJsonContext(kyverno.Create, ctx).
+		WithPolicy(&policy).
+		WithNewResource(*resourceUnstructured)
+	er := testValidate(context.TODO(), registryclient.NewOrDie(), policyContext, cfg, engineapi.DefaultContextLoaderFactory(nil))
+	assert.Equal(t, len(er.PolicyResponse.Rules), 3)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusError)
+	assert.Equal(t, len(er.PolicyResponse.Rules[0].ContextFallbacks), 0)
+	assert.Equal(t, er.PolicyResponse.Rules[1].Status, engineapi.RuleStatusPass)
+	assert.DeepEqual(t, er.PolicyResponse.Rules[1].ContextFallbacks, []string{"limits"})
+	assert.Equal(t, er.PolicyResponse.Rules[2].Status, engineapi.RuleStatusFail)
+	assert.DeepEqual(t, er.PolicyResponse.Rules[2].ContextFallbacks, []string{"limits"})
+}
+
+func Test_ContextOnErrorForEach(t *testing.T) {
+	policyRaw := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "check-registry"},
+		"spec": {
+		  "rules": [
+			{
+			  "name": "registry",
+			  "match": {"resources": { "kinds": [ "Pod" ] } },
+			  "context": [
+				{"name": "limits", "globalReference": {"name": "limits"}, "onError": {"action": "Ignore"}}
+			  ],
+			  "validate": {
+				"foreach": [
+				  {
+					"list": "request.object.spec.containers",
+					"context": [
+					  {"name": "registry", "globalReference": {"name": "registry"}, "onError": {"action": "UseDefault", "default": "ghcr.io"}}
+					],
+					"deny": {"conditions": {"any": [{"key": "{{ element.image }}", "operator": "NotEquals", "value": "{{ registry }}/*"}]}}
+				  }
+				]
+			  }
+			}
+		  ]
+		}
+	  }`)
+	resourceRaw := []byte(`{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "test"}, "spec": {"containers": [{"name": "nginx", "image": "ghcr.io/nginx"}]}}`)
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(policyRaw, &policy))
+	resourceUnstructured, err := kubeutils.BytesToUnstructured(resourceRaw)
+	assert.NilError(t, err)
+	ctx := enginecontext.NewContext()
+	assert.NilError(t, enginecontext.AddResource(ctx, resourceRaw))
+	policyContext := NewPolicyContextWithJsonContext(kyverno.Create, ctx).
+		WithPolicy(&policy).
+		WithNewResource(*resourceUnstructured)
+	er := testValidate(context.TODO(), registryclient.NewOrDie(), policyContext, cfg, engineapi.DefaultContextLoaderFactory(nil))
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusPass)
+	assert.DeepEqual(t, er.PolicyResponse.Rules[0].ContextFallbacks, []string{"limits", "registry"})
+}
+
+type testSecretResolver map[string]*corev1.Secret
+
+func (r testSecretResolver) Get(_ context.Context, namespace, name string) (*corev1.Secret, error) {
+	if secret, ok := r[namespace+"/"+name]; ok {
+		return secret, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func Test_SecretContextRedaction(t *testing.T) {
+	policyRaw := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "check-password"},
+		"spec": {
+		  "rules": [
+			{
+			  "name": "check-password",
+			  "match": {"resources": { "kinds": [ "ConfigMap" ] } },
+			  "context": [
+				{"name": "password", "secret": {"name": "creds", "namespace": "shared", "key": "password"}}
+			  ],
+			  "validate": {
+				"message": "password {{ password }} must not be used",
+				"deny": {"conditions": {"any": [{"key": "{{ request.object.data.password }}", "operator": "Equals", "value": "{{ password }}"}]}}
+			  }
+			},
+			{
+			  "name": "check-password-pattern",
+			  "match": {"resources": { "kinds": [ "ConfigMap" ] } },
+			  "context": [
+				{"name": "password", "secret": {"name": "creds", "namespace": "shared", "key": "password"}}
+			  ],
+			  "validate": {
+				"pattern": {"data": {"password": "!{{ password }}"}}
+			  }
+			}
+		  ]
+		}
+	  }`)
+	resourceRaw := []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "test", "namespace": "default"}, "data": {"password": "s3cr3t"}}`)
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(policyRaw, &policy))
+	resourceUnstructured, err := kubeutils.BytesToUnstructured(resourceRaw)
+	assert.NilError(t, err)
+	resolver := testSecretResolver{"shared/creds": {Data: map[string][]byte{"password": []byte("s3cr3t")}}}
+	configuration := config.NewDefaultConfiguration()
+	configuration.Load(&corev1.ConfigMap{Data: map[string]string{"secretContextNamespaces": "shared"}})
+	contextLoader := engineapi.DefaultContextLoaderFactory(nil, engineapi.WithSecretResolver(resolver, configuration))
+	ctx := enginecontext.NewContext()
+	assert.NilError(t, enginecontext.AddResource(ctx, resourceRaw))
+	policyContext := NewPolicyContextWithJsonContext(kyverno.Create, ctx).
+		WithPolicy(&policy).
+		WithNewResource(*resourceUnstructured)
+	er := testValidate(context.TODO(), registryclient.NewOrDie(), policyContext, cfg, contextLoader)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 2)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusFail)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Message, "password **REDACTED** must not be used")
+	assert.Equal(t, er.PolicyResponse.Rules[1].Status, engineapi.RuleStatusFail)
+	assert.Assert(t, er.PolicyResponse.Rules[1].Violation != nil)
+	assert.Assert(t, !strings.Contains(er.PolicyResponse.Rules[1].Message, "s3cr3t"), er.PolicyResponse.Rules[1].Message)
+	assert.Assert(t, !strings.Contains(er.PolicyResponse.Rules[1].Violation.Expected, "s3cr3t"), er.PolicyResponse.Rules[1].Violation.Expected)
+	assert.Assert(t, !strings.Contains(er.PolicyResponse.Rules[1].Violation.Actual, "s3cr3t"), er.PolicyResponse.Rules[1].Violation.Actual)
+	// secrets are not loaded from namespaces that are not allowed
+	configuration.Load(&corev1.ConfigMap{Data: map[string]string{}})
+	ctx = enginecontext.NewContext()
+	assert.NilError(t, enginecontext.AddResource(ctx, resourceRaw))
+	policyContext = NewPolicyContextWithJsonContext(kyverno.Create, ctx).
+		WithPolicy(&policy).
+		WithNewResource(*resourceUnstructured)
+	er = testValidate(context.TODO(), registryclient.NewOrDie(), policyContext, cfg, contextLoader)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 2)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusError)
+	assert.Equal(t, er.PolicyResponse.Rules[1].Status, engineapi.RuleStatusError)
+}
+
+func Test_Explain(t *testing.T) {
+	policyRaw := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "explain"},
+		"spec": {
+		  "context": [
+			{"name": "team", "variable": {"value": "platform"}}
+		  ],
+		  "rules": [
+			{
+			  "name": "not-matched",
+			  "match": {"any": [{"resources": {"kinds": ["Deployment"]}}]},
+			  "validate": {"pattern": {"metadata": {"name": "?*"}}}
+			},
+			{
+			  "name": "excluded",
+			  "match": {"any": [{"resources": {"kinds": ["Deployment"]}}, {"resources": {"kinds": ["Pod"]}}]},
+			  "exclude": {"any": [{"resources": {"names": ["test"]}}]},
+			  "validate": {"pattern": {"metadata": {"name": "?*"}}}
+			},
+			{
+			  "name": "preconditions",
+			  "match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			  "context": [
+				{"name": "owner", "variable": {"jmesPath": "request.object.metadata.name"}}
+			  ],
+			  "preconditions": {"all": [{"key": "{{ team }}", "operator": "Equals", "value": "apps"}]},
+			  "validate": {"pattern": {"metadata": {"name": "?*"}}}
+			},
+			{
+			  "name": "pattern",
+			  "match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			  "validate": {"pattern": {"metadata": {"labels": {"team": "?*"}}}}
+			}
+		  ]
+		}
+	  }`)
+	resourceRaw := []byte(`{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "test", "namespace": "default", "labels": {"app": "test"}}}`)
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(policyRaw, &policy))
+	resourceUnstructured, err := kubeutils.BytesToUnstructured(resourceRaw)
+	assert.NilError(t, err)
+	newPolicyContext := func() *PolicyContext {
+		ctx := enginecontext.NewContext()
+		assert.NilError(t, enginecontext.AddResource(ctx, resourceRaw))
+		return NewPolicyContextWithJsonContext(kyverno.Create, ctx).
+			WithPolicy(&policy).
+			WithNewResource(*resourceUnstructured)
+	}
+	// traces are not recorded by default
+	er := testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(), cfg, nil)
+	assert.Equal(t, len(er.PolicyResponse.Traces), 0)
+
+	er = testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext().WithExplain(true), cfg, nil)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 2)
+	assert.Equal(t, len(er.PolicyResponse.Traces), 4)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Name, "preconditions")
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusSkip)
+
+	notMatched := er.PolicyResponse.Trace("not-matched")
+	assert.Assert(t, notMatched != nil)
+	assert.Equal(t, notMatched.Match.Matched, false)
+	assert.Equal(t, notMatched.Match.Clause, "")
+	assert.Assert(t, strings.Contains(notMatched.Match.Reason, "rule not-matched not matched"))
+
+	excluded := er.PolicyResponse.Trace("excluded")
+	assert.Assert(t, excluded != nil)
+	assert.Equal(t, excluded.Match.Matched, false)
+	assert.Equal(t, excluded.Match.Clause, "match.any[1]")
+	assert.Equal(t, excluded.Match.ExcludeClause, "exclude.any[0]")
+
+	preconditions := er.PolicyResponse.Trace("preconditions")
+	assert.Assert(t, preconditions != nil)
+	assert.DeepEqual(t, preconditions.Match, engineapi.MatchTrace{Matched: true, Clause: "match.any[0]"})
+	assert.DeepEqual(t, preconditions.Context, []engineapi.ContextEntryTrace{
+		{Name: "team", Source: "variable", Status: engineapi.ContextEntryLoaded},
+		{Name: "owner", Source: "variable", Status: engineapi.ContextEntryLoaded},
+	})
+	assert.DeepEqual(t, preconditions.Preconditions, []engineapi.ConditionTrace{
+		{Path: "all[0]", Key: "platform", Operator: "Equals", Value: "apps", Result: false},
+	})
+	assert.Equal(t, len(preconditions.Patterns), 0)
+
+	pattern := er.PolicyResponse.Trace("pattern")
+	assert.Assert(t, pattern != nil)
+	assert.Equal(t, pattern.Match.Matched, true)
+	assert.DeepEqual(t, pattern.Context, []engineapi.ContextEntryTrace{
+		{Name: "team", Source: "variable", Status: engineapi.ContextEntryLoaded},
+	})
+	assert.Equal(t, len(pattern.Patterns), 1)
+	assert.Equal(t, pattern.Patterns[0].Pattern, "pattern")
+	assert.Equal(t, pattern.Patterns[0].Path, "/metadata/labels/team/")
+	assert.Equal(t, pattern.Patterns[0].Anchor, "")
+}
+
+func Test_ViolationDetails(t *testing.T) {
+	resourceRaw := []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {"name": "test", "labels": {"app": "test"}},
+		"spec": {
+			"containers": [
+				{"name": "nginx", "image": "nginx:1.25", "imagePullPolicy": "Always"},
+				{"name": "sidecar", "image": "busybox:latest", "imagePullPolicy": "IfNotPresent"}
+			]
+		}}`)
+	policyRaw := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "test"},
+		"spec": {
+		  "rules": [
+			{
+			  "name": "foreach",
+			  "match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			  "validate": {
+				"message": "container {{ element.name }} must not use {{ violation.actual }}",
+				"foreach": [
+				  {
+					"list": "request.object.spec.containers",
+					"pattern": {"image": "!*:latest"}
+				  }
+				]
+			  }
+			},
+			{
+			  "name": "foreach-deny",
+			  "match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			  "validate": {
+				"message": "container {{ violation.elementKey }} must pull images",
+				"foreach": [
+				  {
+					"list": "request.object.spec.containers",
+					"deny": {"conditions": {"any": [{"key": "{{ element.imagePullPolicy }}", "operator": "NotEquals", "value": "Always"}]}}
+				  }
+				]
+			  }
+			},
+			{
+			  "name": "deny",
+			  "match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			  "validate": {
+				"message": "violation {{ to_string(violation) }}",
+				"deny": {"conditions": {"any": [{"key": "{{ request.object.metadata.labels.app }}", "operator": "Equals", "value": "test"}]}}
+			  }
+			},
+			{
+			  "name": "pattern",
+			  "match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			  "validate": {
+				"message": "label app must be {{ violation.expected }}",
+				"pattern": {"metadata": {"labels": {"app": "nginx"}}}
+			  }
+			}
+		  ]
+		}
+	  }`)
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(policyRaw, &policy))
+	resourceUnstructured, err := kubeutils.BytesToUnstructured(resourceRaw)
+	assert.NilError(t, err)
+	ctx := enginecontext.NewContext()
+	assert.NilError(t, enginecontext.AddResource(ctx, resourceRaw))
+	policyContext := NewPolicyContextWithJsonContext(kyverno.Create, ctx).
+		WithPolicy(&policy).
+		WithNewResource(*resourceUnstructured)
+	er := testValidate(context.TODO(), registryclient.NewOrDie(), policyContext, cfg, nil)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 4)
+
+	elementIndex := 1
+	foreach := er.PolicyResponse.Rules[0]
+	assert.Equal(t, foreach.Status, engineapi.RuleStatusFail)
+	assert.Equal(t, foreach.Message, "validation failure: validation error: container sidecar must not use busybox:latest. rule foreach failed at path /image/")
+	assert.DeepEqual(t, foreach.Violation, &engineapi.ViolationDetails{
+		Path:         "/image/",
+		Expected:     "!*:latest",
+		Actual:       "busybox:latest",
+		ElementIndex: &elementIndex,
+		ElementKey:   "sidecar",
+	})
+
+	deny := er.PolicyResponse.Rules[1]
+	assert.Equal(t, deny.Status, engineapi.RuleStatusFail)
+	assert.Equal(t, deny.Message, "validation failure: container sidecar must pull images")
+	assert.DeepEqual(t, deny.Violation, &engineapi.ViolationDetails{
+		ElementIndex: &elementIndex,
+		ElementKey:   "sidecar",
+	})
+
+	deny = er.PolicyResponse.Rules[2]
+	assert.Equal(t, deny.Status, engineapi.RuleStatusFail)
+	assert.Equal(t, deny.Message, "violation {}")
+	assert.DeepEqual(t, deny.Violation, &engineapi.ViolationDetails{})
+
+	pattern := er.PolicyResponse.Rules[3]
+	assert.Equal(t, pattern.Status, engineapi.RuleStatusFail)
+	assert.Equal(t, pattern.Message, "validation error: label app must be nginx. rule pattern failed at path /metadata/labels/app/")
+	assert.DeepEqual(t, pattern.Violation, &engineapi.ViolationDetails{
+		Path:     "/metadata/labels/app/",
+		Expected: "nginx",
+		Actual:   "test",
+	})
+}
@@ -374,6 +374,40 @@ func TestEvaluate(t *testing.T) {
 		{kyverno.Condition{RawKey: kyverno.ToJSON("5"), Operator: kyverno.ConditionOperators["AnyNotIn"], RawValue: kyverno.ToJSON("1-3")}, true},
 		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{1, 5, 11}), Operator: kyverno.ConditionOperators["AnyNotIn"], RawValue: kyverno.ToJSON("0-10")}, true},
 		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{1, 5, 7}), Operator: kyverno.ConditionOperators["AnyNotIn"], RawValue: kyverno.ToJSON("0-10")}, false},
+
+		// Matches
+		{kyverno.Condition{RawKey: kyverno.ToJSON("nginx:1.25"), Operator: kyverno.ConditionOperators["Matches"], RawValue: kyverno.ToJSON(`^nginx:\d+\.\d+$`)}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("nginx:latest"), Operator: kyverno.ConditionOperators["Matches"], RawValue: kyverno.ToJSON(`^nginx:\d+\.\d+$`)}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("ghcr.io/kyverno/kyverno"), Operator: kyverno.ConditionOperators["Matches"], RawValue: kyverno.ToJSON([]interface{}{"^docker.io/", "^ghcr.io/"})}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON(8080), Operator: kyverno.ConditionOperators["Matches"], RawValue: kyverno.ToJSON(`^80\d\d$`)}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{"foo", "bar"}), Operator: kyverno.ConditionOperators["Matches"], RawValue: kyverno.ToJSON("foo")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("foo"), Operator: kyverno.ConditionOperators["Matches"], RawValue: kyverno.ToJSON("(")}, false},
+
+		// Not Matches
+		{kyverno.Condition{RawKey: kyverno.ToJSON("nginx:latest"), Operator: kyverno.ConditionOperators["NotMatches"], RawValue: kyverno.ToJSON(`^nginx:\d+\.\d+$`)}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("nginx:1.25"), Operator: kyverno.ConditionOperators["NotMatches"], RawValue: kyverno.ToJSON(`^nginx:\d+\.\d+$`)}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("quay.io/foo"), Operator: kyverno.ConditionOperators["NotMatches"], RawValue: kyverno.ToJSON([]interface{}{"^docker.io/", "^ghcr.io/"})}, true},
+
+		// Any Matches
+		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{"nginx:latest", "nginx:1.25"}), Operator: kyverno.ConditionOperators["AnyMatches"], RawValue: kyverno.ToJSON(`:latest$`)}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{"nginx:1.24", "nginx:1.25"}), Operator: kyverno.ConditionOperators["AnyMatches"], RawValue: kyverno.ToJSON(`:latest$`)}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{}), Operator: kyverno.ConditionOperators["AnyMatches"], RawValue: kyverno.ToJSON(`:latest$`)}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("nginx:latest"), Operator: kyverno.ConditionOperators["AnyMatches"], RawValue: kyverno.ToJSON(`:latest$`)}, true},
+
+		// All Matches
+		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{"ghcr.io/foo", "docker.io/bar"}), Operator: kyverno.ConditionOperators["AllMatches"], RawValue: kyverno.ToJSON([]interface{}{"^docker.io/", "^ghcr.io/"})}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{"ghcr.io/foo", "quay.io/bar"}), Operator: kyverno.ConditionOperators["AllMatches"], RawValue: kyverno.ToJSON([]interface{}{"^docker.io/", "^ghcr.io/"})}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{}), Operator: kyverno.ConditionOperators["AllMatches"], RawValue: kyverno.ToJSON(`.*`)}, false},
+
+		// Semver Satisfies
+		{kyverno.Condition{RawKey: kyverno.ToJSON("1.4.2"), Operator: kyverno.ConditionOperators["SemverSatisfies"], RawValue: kyverno.ToJSON(">=1.2 <2.0")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("v1.27"), Operator: kyverno.ConditionOperators["SemverSatisfies"], RawValue: kyverno.ToJSON(">=1.25.0")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("2.0.0"), Operator: kyverno.ConditionOperators["SemverSatisfies"], RawValue: kyverno.ToJSON(">=1.2 <2.0")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("3.1.0"), Operator: kyverno.ConditionOperators["SemverSatisfies"], RawValue: kyverno.ToJSON("<2 || >=3")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("1.2.7"), Operator: kyverno.ConditionOperators["SemverSatisfies"], RawValue: kyverno.ToJSON("1.2.x")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("latest"), Operator: kyverno.ConditionOperators["SemverSatisfies"], RawValue: kyverno.ToJSON(">=1.0.0")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("1.0.0"), Operator: kyverno.ConditionOperators["SemverSatisfies"], RawValue: kyverno.ToJSON(">=foo")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON(1), Operator: kyverno.ConditionOperators["SemverSatisfies"], RawValue: kyverno.ToJSON(">=1.0.0")}, false},
 	}
 
 	ctx := context.NewContext()
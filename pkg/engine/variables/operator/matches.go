@@ -0,0 +1,144 @@
+package operator
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+	"k8s.io/utils/lru"
+)
+
+// regexCache caches compiled regular expressions by pattern, conditions are evaluated for every resource
+var regexCache = lru.New(1000)
+
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := regexCache.Get(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regexCache.Add(pattern, re)
+	return re, nil
+}
+
+// NewMatchesHandler returns handler to manage the provided regex operations (Matches, NotMatches, AnyMatches, AllMatches)
+func NewMatchesHandler(log logr.Logger, ctx context.EvalInterface, op kyvernov1.ConditionOperator) OperatorHandler {
+	return MatchesHandler{
+		ctx:       ctx,
+		log:       log,
+		condition: op,
+	}
+}
+
+// MatchesHandler provides implementation to handle regex operations.
+// The value is a regular expression or a list of regular expressions, a key matches if it matches any of them.
+type MatchesHandler struct {
+	ctx       context.EvalInterface
+	log       logr.Logger
+	condition kyvernov1.ConditionOperator
+}
+
+// Evaluate evaluates expression with regex operators
+func (mh MatchesHandler) Evaluate(key, value interface{}) bool {
+	switch typedKey := key.(type) {
+	case string:
+		return mh.validateValueWithStringPattern(typedKey, value)
+	case int, int32, int64, float32, float64, bool:
+		return mh.validateValueWithStringPattern(fmt.Sprint(typedKey), value)
+	case []interface{}:
+		return mh.validateValueWithSlicePattern(typedKey, value)
+	default:
+		mh.log.V(2).Info("Unsupported type", "value", typedKey, "type", fmt.Sprintf("%T", typedKey))
+		return false
+	}
+}
+
+// compilePatterns compiles the regular expressions of a condition value
+func (mh MatchesHandler) compilePatterns(value interface{}) ([]*regexp.Regexp, bool) {
+	var patterns []string
+	switch typedValue := value.(type) {
+	case string:
+		patterns = append(patterns, typedValue)
+	case []interface{}:
+		for _, v := range typedValue {
+			pattern, ok := v.(string)
+			if !ok {
+				mh.log.V(2).Info("Expected type string", "value", v, "type", fmt.Sprintf("%T", v))
+				return nil, false
+			}
+			patterns = append(patterns, pattern)
+		}
+	default:
+		mh.log.V(2).Info("Expected type string or []string", "value", value, "type", fmt.Sprintf("%T", value))
+		return nil, false
+	}
+	regexps := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := compileRegex(pattern)
+		if err != nil {
+			mh.log.Error(err, "failed to compile regular expression", "pattern", pattern)
+			return nil, false
+		}
+		regexps = append(regexps, re)
+	}
+	return regexps, true
+}
+
+func matchesAny(key string, regexps []*regexp.Regexp) bool {
+	for _, re := range regexps {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (mh MatchesHandler) validateValueWithStringPattern(key string, value interface{}) bool {
+	return mh.validateValueWithSlicePattern([]interface{}{key}, value)
+}
+
+func (mh MatchesHandler) validateValueWithSlicePattern(key []interface{}, value interface{}) bool {
+	regexps, ok := mh.compilePatterns(value)
+	if !ok {
+		return false
+	}
+	matches := 0
+	for _, k := range key {
+		if matchesAny(fmt.Sprint(k), regexps) {
+			matches++
+		}
+	}
+	switch mh.condition {
+	case kyvernov1.ConditionOperators["Matches"]:
+		return len(key) == 1 && matches == 1
+	case kyvernov1.ConditionOperators["NotMatches"]:
+		return len(key) == 1 && matches == 0
+	case kyvernov1.ConditionOperators["AnyMatches"]:
+		return matches > 0
+	case kyvernov1.ConditionOperators["AllMatches"]:
+		return len(key) > 0 && matches == len(key)
+	default:
+		mh.log.V(2).Info(fmt.Sprintf("Expected operator, one of [Matches, NotMatches, AnyMatches, AllMatches], found %s", mh.condition))
+		return false
+	}
+}
+
+func (mh MatchesHandler) validateValueWithBoolPattern(_ bool, _ interface{}) bool {
+	return false
+}
+
+func (mh MatchesHandler) validateValueWithIntPattern(_ int64, _ interface{}) bool {
+	return false
+}
+
+func (mh MatchesHandler) validateValueWithFloatPattern(_ float64, _ interface{}) bool {
+	return false
+}
+
+func (mh MatchesHandler) validateValueWithMapPattern(_ map[string]interface{}, _ interface{}) bool {
+	return false
+}
@@ -0,0 +1,20 @@
+package operator
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_compileRegex(t *testing.T) {
+	re, err := compileRegex(`^nginx:\d+$`)
+	assert.NilError(t, err)
+	cached, err := compileRegex(`^nginx:\d+$`)
+	assert.NilError(t, err)
+	assert.Assert(t, re == cached)
+
+	_, err = compileRegex(`^nginx:(`)
+	assert.ErrorContains(t, err, "missing closing )")
+	_, ok := regexCache.Get(`^nginx:(`)
+	assert.Assert(t, !ok)
+}
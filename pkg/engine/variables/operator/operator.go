@@ -69,6 +69,15 @@ func CreateOperatorHandler(log logr.Logger, ctx context.EvalInterface, op kyvern
 		log.V(2).Info("DEPRECATED: The Duration* operators have been replaced with the other existing operators that now also support duration values", "operator", str)
 		return NewDurationOperatorHandler(log, ctx, op)
 
+	case strings.ToLower(string(kyvernov1.ConditionOperators["Matches"])),
+		strings.ToLower(string(kyvernov1.ConditionOperators["NotMatches"])),
+		strings.ToLower(string(kyvernov1.ConditionOperators["AnyMatches"])),
+		strings.ToLower(string(kyvernov1.ConditionOperators["AllMatches"])):
+		return NewMatchesHandler(log, ctx, op)
+
+	case strings.ToLower(string(kyvernov1.ConditionOperators["SemverSatisfies"])):
+		return NewSemverSatisfiesHandler(log, ctx)
+
 	default:
 		log.V(2).Info("operator not supported", "operator", str)
 	}
@@ -0,0 +1,105 @@
+package operator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+)
+
+// NewSemverSatisfiesHandler returns handler to manage SemverSatisfies operations
+func NewSemverSatisfiesHandler(log logr.Logger, ctx context.EvalInterface) OperatorHandler {
+	return SemverSatisfiesHandler{
+		ctx: ctx,
+		log: log,
+	}
+}
+
+// SemverSatisfiesHandler provides implementation to handle SemverSatisfies Operator.
+// The key is a version and the value a constraint range, e.g. ">=1.2 <2.0 || >=3.0".
+type SemverSatisfiesHandler struct {
+	ctx context.EvalInterface
+	log logr.Logger
+}
+
+var semverComparator = regexp.MustCompile(`^([<>=!]*)v?(.+)$`)
+
+// ParseSemverRange parses a semver constraint range, versions in the range may omit the minor
+// and patch components (they default to 0) and may be prefixed with a "v".
+func ParseSemverRange(constraint string) (semver.Range, error) {
+	fields := strings.Fields(constraint)
+	for i, field := range fields {
+		if field == "||" {
+			continue
+		}
+		matches := semverComparator.FindStringSubmatch(field)
+		if matches == nil {
+			continue
+		}
+		version := matches[2]
+		core, suffix := version, ""
+		if idx := strings.IndexAny(version, "-+"); idx != -1 {
+			core, suffix = version[:idx], version[idx:]
+		}
+		if !strings.ContainsAny(core, "xX*") {
+			for n := strings.Count(core, "."); n < 2; n++ {
+				core += ".0"
+			}
+		}
+		fields[i] = matches[1] + core + suffix
+	}
+	return semver.ParseRange(strings.Join(fields, " "))
+}
+
+// Evaluate evaluates expression with SemverSatisfies Operator
+func (sh SemverSatisfiesHandler) Evaluate(key, value interface{}) bool {
+	switch typedKey := key.(type) {
+	case string:
+		return sh.validateValueWithStringPattern(typedKey, value)
+	default:
+		sh.log.V(2).Info("Unsupported type", "value", typedKey, "type", fmt.Sprintf("%T", typedKey))
+		return false
+	}
+}
+
+func (sh SemverSatisfiesHandler) validateValueWithStringPattern(key string, value interface{}) bool {
+	constraint, ok := value.(string)
+	if !ok {
+		sh.log.V(2).Info("Expected type string", "value", value, "type", fmt.Sprintf("%T", value))
+		return false
+	}
+	version, err := semver.ParseTolerant(key)
+	if err != nil {
+		sh.log.V(2).Info("key is not a valid semantic version", "key", key, "error", err.Error())
+		return false
+	}
+	expectedRange, err := ParseSemverRange(constraint)
+	if err != nil {
+		sh.log.Error(err, "failed to parse semver range", "value", constraint)
+		return false
+	}
+	return expectedRange(version)
+}
+
+func (sh SemverSatisfiesHandler) validateValueWithBoolPattern(_ bool, _ interface{}) bool {
+	return false
+}
+
+func (sh SemverSatisfiesHandler) validateValueWithIntPattern(_ int64, _ interface{}) bool {
+	return false
+}
+
+func (sh SemverSatisfiesHandler) validateValueWithFloatPattern(_ float64, _ interface{}) bool {
+	return false
+}
+
+func (sh SemverSatisfiesHandler) validateValueWithMapPattern(_ map[string]interface{}, _ interface{}) bool {
+	return false
+}
+
+func (sh SemverSatisfiesHandler) validateValueWithSlicePattern(_ []interface{}, _ interface{}) bool {
+	return false
+}
@@ -323,6 +323,18 @@ func DefaultVariableResolver(ctx context.EvalInterface, variable string) (interf
 	return ctx.Query(variable)
 }
 
+// redactValue hides the sensitive values registered in the context, e.g. loaded from secrets, before a value is logged
+func redactValue(ctx context.EvalInterface, value interface{}) interface{} {
+	if ctx == nil {
+		return value
+	}
+	str := fmt.Sprint(value)
+	if redacted := ctx.Redact(str); redacted != str {
+		return redacted
+	}
+	return value
+}
+
 func substituteVariablesIfAny(log logr.Logger, ctx context.EvalInterface, vr VariableResolver) jsonUtils.Action {
 	return jsonUtils.OnlyForLeafsAndKeys(func(data *jsonUtils.ActionData) (interface{}, error) {
 		value, ok := data.Element.(string)
@@ -373,7 +385,7 @@ func substituteVariablesIfAny(log logr.Logger, ctx context.EvalInterface, vr Var
 					}
 				}
 
-				log.V(3).Info("variable substituted", "variable", v, "value", substitutedVar, "path", data.Path)
+				log.V(3).Info("variable substituted", "variable", v, "value", redactValue(ctx, substitutedVar), "path", data.Path)
 
 				if originalPattern == v {
 					return substitutedVar, nil
@@ -0,0 +1,86 @@
+package externalapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type entry struct {
+	lock sync.RWMutex
+	data interface{}
+	err  error
+	stop func()
+}
+
+// New creates an entry caching the response of an external API call,
+// the call is executed immediately and then every refresh interval until the entry is stopped.
+func New(ctx context.Context, logger logr.Logger, client dclient.Interface, secrets apicall.SecretLoader, configuration config.Configuration, name string, call kyvernov2alpha1.ExternalAPICall) store.Entry {
+	ctx, cancel := context.WithCancel(ctx)
+	e := &entry{
+		err:  errors.New("data is not loaded yet"),
+		stop: cancel,
+	}
+	contextEntry := kyvernov1.ContextEntry{
+		Name: name,
+		APICall: &kyvernov1.APICall{
+			Service:  call.Service.DeepCopy(),
+			JMESPath: call.JMESPath,
+		},
+	}
+	go wait.UntilWithContext(ctx, func(ctx context.Context) {
+		data, err := fetch(ctx, logger, client, secrets, configuration, contextEntry)
+		if err != nil {
+			logger.Error(err, "failed to refresh global context entry", "name", name)
+		}
+		e.set(data, err)
+	}, call.GetRefreshInterval())
+	return e
+}
+
+func fetch(ctx context.Context, logger logr.Logger, client dclient.Interface, secrets apicall.SecretLoader, configuration config.Configuration, contextEntry kyvernov1.ContextEntry) (interface{}, error) {
+	executor, err := apicall.New(ctx, contextEntry, enginecontext.NewContext(), client, nil, secrets, configuration, logger)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := executor.Execute()
+	if err != nil {
+		return nil, err
+	}
+	var data interface{}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (e *entry) set(data interface{}, err error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	// keep serving the last known data if a refresh fails
+	if err != nil && e.data != nil {
+		return
+	}
+	e.data, e.err = data, err
+}
+
+func (e *entry) Get() (interface{}, error) {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	return e.data, e.err
+}
+
+func (e *entry) Stop() {
+	e.stop()
+}
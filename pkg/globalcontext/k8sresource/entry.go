@@ -0,0 +1,70 @@
+package k8sresource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+const resyncPeriod = 15 * time.Minute
+
+type entry struct {
+	informer cache.SharedIndexInformer
+	lister   cache.GenericLister
+	stop     func()
+}
+
+// New creates an entry caching the resources matching gvr, namespace and selector,
+// the cache is kept up to date by an informer until the entry is stopped.
+func New(ctx context.Context, client dynamic.Interface, gvr schema.GroupVersionResource, namespace string, selector labels.Selector) store.Entry {
+	ctx, cancel := context.WithCancel(ctx)
+	informer := dynamicinformer.NewFilteredDynamicInformer(
+		client,
+		gvr,
+		namespace,
+		resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		func(options *metav1.ListOptions) {
+			options.LabelSelector = selector.String()
+		},
+	)
+	go informer.Informer().Run(ctx.Done())
+	return &entry{
+		informer: informer.Informer(),
+		lister:   informer.Lister(),
+		stop:     cancel,
+	}
+}
+
+func (e *entry) Get() (interface{}, error) {
+	if !e.informer.HasSynced() {
+		return nil, errors.New("resources are not synced yet")
+	}
+	objects, err := e.lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	list := make([]interface{}, 0, len(objects))
+	for _, object := range objects {
+		u, ok := object.(*unstructured.Unstructured)
+		if !ok {
+			return nil, fmt.Errorf("unexpected object type %T", object)
+		}
+		list = append(list, u.UnstructuredContent())
+	}
+	return list, nil
+}
+
+func (e *entry) Stop() {
+	e.stop()
+}
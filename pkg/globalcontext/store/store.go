@@ -0,0 +1,59 @@
+package store
+
+import (
+	"sync"
+)
+
+// Entry is a cached global context entry
+type Entry interface {
+	// Get returns the cached data
+	Get() (interface{}, error)
+	// Stop releases the resources used to keep the data up to date
+	Stop()
+}
+
+// Store holds global context entries by name
+type Store interface {
+	// Set adds or replaces an entry, a replaced entry is stopped
+	Set(name string, entry Entry)
+	// Get returns the entry with the given name
+	Get(name string) (Entry, bool)
+	// Delete stops and removes the entry with the given name
+	Delete(name string)
+}
+
+type store struct {
+	lock    sync.RWMutex
+	entries map[string]Entry
+}
+
+func New() Store {
+	return &store{
+		entries: map[string]Entry{},
+	}
+}
+
+func (s *store) Set(name string, entry Entry) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if old := s.entries[name]; old != nil {
+		old.Stop()
+	}
+	s.entries[name] = entry
+}
+
+func (s *store) Get(name string) (Entry, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	entry, ok := s.entries[name]
+	return entry, ok
+}
+
+func (s *store) Delete(name string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if entry := s.entries[name]; entry != nil {
+		entry.Stop()
+	}
+	delete(s.entries, name)
+}
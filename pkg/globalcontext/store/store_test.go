@@ -0,0 +1,41 @@
+package store
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+type testEntry struct {
+	data    interface{}
+	stopped bool
+}
+
+func (e *testEntry) Get() (interface{}, error) {
+	return e.data, nil
+}
+
+func (e *testEntry) Stop() {
+	e.stopped = true
+}
+
+func TestStore(t *testing.T) {
+	s := New()
+	_, ok := s.Get("foo")
+	assert.Assert(t, !ok)
+	first := &testEntry{data: "first"}
+	s.Set("foo", first)
+	entry, ok := s.Get("foo")
+	assert.Assert(t, ok)
+	data, err := entry.Get()
+	assert.NilError(t, err)
+	assert.Equal(t, data, "first")
+	second := &testEntry{data: "second"}
+	s.Set("foo", second)
+	assert.Assert(t, first.stopped)
+	assert.Assert(t, !second.stopped)
+	s.Delete("foo")
+	assert.Assert(t, second.stopped)
+	_, ok = s.Get("foo")
+	assert.Assert(t, !ok)
+}
@@ -5,6 +5,8 @@ type PolicyValidationMode string
 const (
 	Enforce PolicyValidationMode = "enforce"
 	Audit   PolicyValidationMode = "audit"
+	// WarnMode is the validation mode of policies in Warn mode, Warn is already used by rule results
+	WarnMode PolicyValidationMode = "warn"
 )
 
 type PolicyType string
@@ -12,9 +12,33 @@ func ParsePolicyValidationMode(validationFailureAction kyvernov1.ValidationFailu
 	if validationFailureAction.Enforce() {
 		return Enforce, nil
 	}
+	if validationFailureAction.Warn() {
+		return WarnMode, nil
+	}
 	return Audit, nil
 }
 
+// GetPolicyValidationMode returns the validation mode of a policy, taking rule level validation failure actions into account.
+// The policy is in enforce mode if any of its validate rules is, otherwise it is in warn mode if any of its validate rules is.
+func GetPolicyValidationMode(policy kyvernov1.PolicyInterface) (PolicyValidationMode, error) {
+	spec := policy.GetSpec()
+	warn := false
+	for _, rule := range spec.Rules {
+		if !rule.HasValidate() {
+			continue
+		}
+		action, _ := rule.GetValidationFailureAction(spec)
+		if action.Enforce() {
+			return Enforce, nil
+		}
+		warn = warn || action.Warn()
+	}
+	if warn {
+		return WarnMode, nil
+	}
+	return ParsePolicyValidationMode(spec.ValidationFailureAction)
+}
+
 func ParsePolicyBackgroundMode(policy kyvernov1.PolicyInterface) PolicyBackgroundMode {
 	if policy.BackgroundProcessingEnabled() {
 		return BackgroundTrue
@@ -77,6 +101,6 @@ func GetPolicyInfos(policy kyvernov1.PolicyInterface) (string, string, PolicyTyp
 		policyType = Namespaced
 	}
 	backgroundMode := ParsePolicyBackgroundMode(policy)
-	validationMode, err := ParsePolicyValidationMode(policy.GetSpec().ValidationFailureAction)
+	validationMode, err := GetPolicyValidationMode(policy)
 	return name, namespace, policyType, backgroundMode, validationMode, err
 }
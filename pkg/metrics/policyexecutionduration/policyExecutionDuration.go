@@ -40,9 +40,16 @@ func ProcessEngineResponse(ctx context.Context, m metrics.MetricsConfigManager,
 	resourceSpec := engineResponse.Resource
 	resourceNamespace := resourceSpec.GetNamespace()
 	ruleResponses := engineResponse.PolicyResponse.Rules
+	actions := engineResponse.GetRuleValidationFailureActions()
 	for _, rule := range ruleResponses {
 		ruleName := rule.Name
 		ruleType := metrics.ParseRuleTypeFromEngineRuleResponse(rule)
+		ruleValidationMode := validationMode
+		if ruleType == metrics.Validate {
+			if ruleValidationMode, err = metrics.ParsePolicyValidationMode(actions.Get(ruleName)); err != nil {
+				return err
+			}
+		}
 		var ruleResult metrics.RuleResult
 		switch rule.Status {
 		case engineapi.RuleStatusPass:
@@ -62,7 +69,7 @@ func ProcessEngineResponse(ctx context.Context, m metrics.MetricsConfigManager,
 		registerPolicyExecutionDurationMetric(
 			ctx,
 			m,
-			validationMode,
+			ruleValidationMode,
 			policyType,
 			backgroundMode,
 			namespace, name,
@@ -5,6 +5,9 @@ import (
 	"strconv"
 
 	"github.com/kyverno/kyverno/pkg/engine/anchor"
+	"github.com/kyverno/kyverno/pkg/engine/pattern"
+	"github.com/kyverno/kyverno/pkg/engine/validate"
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
 )
 
 // ValidatePattern validates the pattern
@@ -22,6 +25,43 @@ func ValidatePattern(patternElement interface{}, path string, isSupported func(a
 	}
 }
 
+// ValidatePatternExpressions checks the regular expressions, CIDR ranges and cardinality conditions used in the pattern
+func ValidatePatternExpressions(patternElement interface{}, path string) (string, error) {
+	switch typedPatternElement := patternElement.(type) {
+	case map[string]interface{}:
+		if validate.IsCardinalityPattern(typedPatternElement) {
+			if err := validate.ValidateCardinalityPattern(typedPatternElement); err != nil {
+				return path, fmt.Errorf("error at '%s', %w", path, err)
+			}
+		}
+		for key, value := range typedPatternElement {
+			if errPath, err := ValidatePatternExpressions(value, path+"/"+key); err != nil {
+				return errPath, err
+			}
+		}
+	case []interface{}:
+		for i, value := range typedPatternElement {
+			if errPath, err := ValidatePatternExpressions(value, path+strconv.Itoa(i)+"/"); err != nil {
+				return errPath, err
+			}
+		}
+	case string:
+		if err := validateExpressions(typedPatternElement); err != nil {
+			return path, fmt.Errorf("error at '%s', %w", path, err)
+		}
+	}
+	return "", nil
+}
+
+// validateExpressions checks the expressions of a string pattern, patterns containing variables
+// are checked after substitution when the policy is applied
+func validateExpressions(value string) error {
+	if regex.RegexVariables.MatchString(value) {
+		return nil
+	}
+	return pattern.ValidateExpressions(value)
+}
+
 func validateMap(patternMap map[string]interface{}, path string, isSupported func(anchor.Anchor) bool) (string, error) {
 	// check if anchors are defined
 	for key, value := range patternMap {
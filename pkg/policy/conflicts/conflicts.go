@@ -0,0 +1,125 @@
+package conflicts
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+)
+
+// Conflict describes two mutate rules of different policies writing different values
+// at the same path of resources matched by both rules. Such rules keep reverting each other's changes.
+type Conflict struct {
+	// Policy is the key of the first policy, namespace/name for namespaced policies
+	Policy string `json:"policy"`
+	// Rule is the name of the first rule
+	Rule string `json:"rule"`
+	// OtherPolicy is the key of the second policy
+	OtherPolicy string `json:"otherPolicy"`
+	// OtherRule is the name of the second rule
+	OtherRule string `json:"otherRule"`
+	// Path is the resource path written by both rules, array elements are identified by name or by *
+	Path string `json:"path"`
+	// Value is the value written by the first rule, nil when the rule removes the path
+	Value interface{} `json:"value"`
+	// OtherValue is the value written by the second rule, nil when the rule removes the path
+	OtherValue interface{} `json:"otherValue"`
+}
+
+func (c Conflict) String() string {
+	return fmt.Sprintf("mutate rule %s/%s conflicts with rule %s/%s at path %s: %s != %s",
+		c.Policy, c.Rule, c.OtherPolicy, c.OtherRule, c.Path, formatValue(c.Value), formatValue(c.OtherValue))
+}
+
+// Summary describes the conflict without the rule, path and value of the other policy,
+// it is used when the other policy may not be visible to the author of the first one.
+func (c Conflict) Summary() string {
+	return fmt.Sprintf("mutate rule %s/%s conflicts with a mutate rule of another policy at path %s", c.Policy, c.Rule, c.Path)
+}
+
+func formatValue(value interface{}) string {
+	if value == nil {
+		return "<removed>"
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// Find returns the conflicts between the mutate rules of the given policies,
+// rules of the same policy are applied in order and never conflict.
+func Find(policies []kyvernov1.PolicyInterface) []Conflict {
+	var conflicts []Conflict
+	for i := range policies {
+		for j := i + 1; j < len(policies); j++ {
+			conflicts = append(conflicts, between(policies[i], policies[j])...)
+		}
+	}
+	return conflicts
+}
+
+// FindWith returns the conflicts between the mutate rules of a policy and the mutate rules of other policies,
+// a previous version of the policy in others is ignored.
+func FindWith(policy kyvernov1.PolicyInterface, others []kyvernov1.PolicyInterface) []Conflict {
+	var conflicts []Conflict
+	for _, other := range others {
+		if policyKey(other) == policyKey(policy) {
+			continue
+		}
+		conflicts = append(conflicts, between(policy, other)...)
+	}
+	return conflicts
+}
+
+func between(policy, other kyvernov1.PolicyInterface) []Conflict {
+	var conflicts []Conflict
+	otherRules := autogen.ComputeRules(other)
+	for _, rule := range autogen.ComputeRules(policy) {
+		if !rule.HasMutate() {
+			continue
+		}
+		writes := ruleWrites(rule)
+		for _, otherRule := range otherRules {
+			if !otherRule.HasMutate() {
+				continue
+			}
+			if !scopesOverlap(ruleScope(policy, rule), ruleScope(other, otherRule)) {
+				continue
+			}
+			otherWrites := ruleWrites(otherRule)
+			for _, w := range writes {
+				for _, o := range otherWrites {
+					if !pathsOverlap(w, o) || datautils.DeepEqual(w.value, o.value) {
+						continue
+					}
+					conflicts = append(conflicts, Conflict{
+						Policy:      policyKey(policy),
+						Rule:        rule.Name,
+						OtherPolicy: policyKey(other),
+						OtherRule:   otherRule.Name,
+						Path:        formatPath(w.path),
+						Value:       w.value,
+						OtherValue:  o.value,
+					})
+				}
+			}
+		}
+	}
+	return conflicts
+}
+
+func policyKey(policy kyvernov1.PolicyInterface) string {
+	if policy.IsNamespaced() {
+		return policy.GetNamespace() + "/" + policy.GetName()
+	}
+	return policy.GetName()
+}
+
+func formatPath(path []string) string {
+	return "/" + strings.Join(path, "/")
+}
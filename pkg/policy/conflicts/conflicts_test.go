@@ -0,0 +1,421 @@
+package conflicts
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	"sigs.k8s.io/yaml"
+)
+
+func newClusterPolicy(t *testing.T, name string, rules string) kyvernov1.PolicyInterface {
+	var policy kyvernov1.ClusterPolicy
+	assert.NilError(t, yaml.Unmarshal([]byte(rules), &policy.Spec))
+	policy.Name = name
+	return &policy
+}
+
+func newPolicy(t *testing.T, namespace, name string, rules string) kyvernov1.PolicyInterface {
+	var policy kyvernov1.Policy
+	assert.NilError(t, yaml.Unmarshal([]byte(rules), &policy.Spec))
+	policy.Namespace = namespace
+	policy.Name = name
+	return &policy
+}
+
+const pullPolicyAlways = `
+rules:
+- name: always
+  match:
+    any:
+    - resources:
+        kinds: [Pod]
+  mutate:
+    patchStrategicMerge:
+      spec:
+        containers:
+        - (name): "*"
+          imagePullPolicy: Always
+`
+
+func Test_Find(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies func(t *testing.T) []kyvernov1.PolicyInterface
+		want     []string
+	}{{
+		name: "strategic merge patches writing different values",
+		policies: func(t *testing.T) []kyvernov1.PolicyInterface {
+			return []kyvernov1.PolicyInterface{
+				newClusterPolicy(t, "a", pullPolicyAlways),
+				newClusterPolicy(t, "b", `
+rules:
+- name: if-not-present
+  match:
+    any:
+    - resources:
+        kinds: [Pod]
+  mutate:
+    patchStrategicMerge:
+      spec:
+        containers:
+        - (name): "*"
+          imagePullPolicy: IfNotPresent
+`),
+			}
+		},
+		want: []string{
+			`mutate rule a/always conflicts with rule b/if-not-present at path /spec/containers/*/imagePullPolicy: "Always" != "IfNotPresent"`,
+			`mutate rule a/autogen-always conflicts with rule b/autogen-if-not-present at path /spec/template/spec/containers/*/imagePullPolicy: "Always" != "IfNotPresent"`,
+			`mutate rule a/autogen-cronjob-always conflicts with rule b/autogen-cronjob-if-not-present at path /spec/jobTemplate/spec/template/spec/containers/*/imagePullPolicy: "Always" != "IfNotPresent"`,
+		},
+	}, {
+		name: "same values",
+		policies: func(t *testing.T) []kyvernov1.PolicyInterface {
+			return []kyvernov1.PolicyInterface{
+				newClusterPolicy(t, "a", pullPolicyAlways),
+				newClusterPolicy(t, "b", pullPolicyAlways),
+			}
+		},
+	}, {
+		name: "json patch and foreach",
+		policies: func(t *testing.T) []kyvernov1.PolicyInterface {
+			return []kyvernov1.PolicyInterface{
+				newClusterPolicy(t, "a", `
+rules:
+- name: label
+  match:
+    any:
+    - resources:
+        kinds: [Deployment]
+  mutate:
+    patchesJson6902: |-
+      - op: add
+        path: /metadata/labels/team
+        value: platform
+      - op: add
+        path: /spec/template/spec/containers/-
+        value: {name: sidecar}
+`),
+				newClusterPolicy(t, "b", `
+rules:
+- name: label
+  match:
+    any:
+    - resources:
+        kinds: [apps/v1/Deployment]
+  mutate:
+    foreach:
+    - list: request.object.spec.template.spec.containers
+      patchStrategicMerge:
+        metadata:
+          labels:
+            team: apps
+`),
+			}
+		},
+		want: []string{`mutate rule a/label conflicts with rule b/label at path /metadata/labels/team: "platform" != "apps"`},
+	}, {
+		name: "removal",
+		policies: func(t *testing.T) []kyvernov1.PolicyInterface {
+			return []kyvernov1.PolicyInterface{
+				newClusterPolicy(t, "a", `
+rules:
+- name: remove-labels
+  match:
+    resources:
+      kinds: [Pod]
+  mutate:
+    patchesJson6902: |-
+      - op: remove
+        path: /metadata/labels
+`),
+				newClusterPolicy(t, "b", `
+rules:
+- name: label
+  match:
+    resources:
+      kinds: [Pod]
+  mutate:
+    patchStrategicMerge:
+      metadata:
+        labels:
+          +(team): apps
+          env: prod
+`),
+			}
+		},
+		want: []string{`mutate rule a/remove-labels conflicts with rule b/label at path /metadata/labels: <removed> != "prod"`},
+	}, {
+		name: "merge patch",
+		policies: func(t *testing.T) []kyvernov1.PolicyInterface {
+			return []kyvernov1.PolicyInterface{
+				newClusterPolicy(t, "a", `
+rules:
+- name: unlabel
+  match:
+    any:
+    - resources:
+        kinds: [Pod]
+  mutate:
+    patchMerge:
+      metadata:
+        labels:
+          team: null
+      spec:
+        tolerations: []
+`),
+				newClusterPolicy(t, "b", `
+rules:
+- name: label
+  match:
+    any:
+    - resources:
+        kinds: [Pod]
+  mutate:
+    patchStrategicMerge:
+      metadata:
+        labels:
+          team: apps
+`),
+			}
+		},
+		want: []string{
+			`mutate rule a/unlabel conflicts with rule b/label at path /metadata/labels/team: <removed> != "apps"`,
+			`mutate rule a/autogen-unlabel conflicts with rule b/autogen-label at path /spec/template/metadata/labels/team: <removed> != "apps"`,
+			`mutate rule a/autogen-cronjob-unlabel conflicts with rule b/autogen-cronjob-label at path /spec/jobTemplate/spec/template/metadata/labels/team: <removed> != "apps"`,
+		},
+	}, {
+		name: "different kinds",
+		policies: func(t *testing.T) []kyvernov1.PolicyInterface {
+			return []kyvernov1.PolicyInterface{
+				newClusterPolicy(t, "a", pullPolicyAlways),
+				newClusterPolicy(t, "b", `
+rules:
+- name: if-not-present
+  match:
+    any:
+    - resources:
+        kinds: [Job]
+  mutate:
+    patchStrategicMerge:
+      spec:
+        containers:
+        - (name): "*"
+          imagePullPolicy: IfNotPresent
+`),
+			}
+		},
+	}, {
+		name: "different containers",
+		policies: func(t *testing.T) []kyvernov1.PolicyInterface {
+			return []kyvernov1.PolicyInterface{
+				newClusterPolicy(t, "a", `
+rules:
+- name: nginx
+  match:
+    any:
+    - resources:
+        kinds: [Pod]
+  mutate:
+    patchStrategicMerge:
+      spec:
+        containers:
+        - name: nginx
+          image: nginx:1.25
+`),
+				newClusterPolicy(t, "b", `
+rules:
+- name: busybox
+  match:
+    any:
+    - resources:
+        kinds: [Pod]
+  mutate:
+    patchStrategicMerge:
+      spec:
+        containers:
+        - name: busybox
+          image: busybox:1.36
+`),
+			}
+		},
+	}, {
+		name: "namespaced policies in different namespaces",
+		policies: func(t *testing.T) []kyvernov1.PolicyInterface {
+			return []kyvernov1.PolicyInterface{
+				newPolicy(t, "ns-1", "a", pullPolicyAlways),
+				newPolicy(t, "ns-2", "b", `
+rules:
+- name: if-not-present
+  match:
+    any:
+    - resources:
+        kinds: [Pod]
+  mutate:
+    patchStrategicMerge:
+      spec:
+        containers:
+        - (name): "*"
+          imagePullPolicy: IfNotPresent
+`),
+			}
+		},
+	}, {
+		name: "namespaced policy and cluster policy",
+		policies: func(t *testing.T) []kyvernov1.PolicyInterface {
+			return []kyvernov1.PolicyInterface{
+				newPolicy(t, "ns-1", "a", pullPolicyAlways),
+				newClusterPolicy(t, "b", `
+rules:
+- name: if-not-present
+  match:
+    any:
+    - resources:
+        kinds: [Pod]
+        namespaces: [ns-*]
+  mutate:
+    patchStrategicMerge:
+      spec:
+        containers:
+        - (name): "*"
+          imagePullPolicy: IfNotPresent
+`),
+			}
+		},
+		want: []string{
+			`mutate rule ns-1/a/always conflicts with rule b/if-not-present at path /spec/containers/*/imagePullPolicy: "Always" != "IfNotPresent"`,
+			`mutate rule ns-1/a/autogen-always conflicts with rule b/autogen-if-not-present at path /spec/template/spec/containers/*/imagePullPolicy: "Always" != "IfNotPresent"`,
+			`mutate rule ns-1/a/autogen-cronjob-always conflicts with rule b/autogen-cronjob-if-not-present at path /spec/jobTemplate/spec/template/spec/containers/*/imagePullPolicy: "Always" != "IfNotPresent"`,
+		},
+	}, {
+		name: "autogen rule and deployment rule",
+		policies: func(t *testing.T) []kyvernov1.PolicyInterface {
+			return []kyvernov1.PolicyInterface{
+				newClusterPolicy(t, "a", pullPolicyAlways),
+				newClusterPolicy(t, "b", `
+rules:
+- name: if-not-present
+  match:
+    any:
+    - resources:
+        kinds: [Deployment]
+  mutate:
+    patchStrategicMerge:
+      spec:
+        template:
+          spec:
+            containers:
+            - (name): "*"
+              imagePullPolicy: IfNotPresent
+`),
+			}
+		},
+		want: []string{`mutate rule a/autogen-always conflicts with rule b/if-not-present at path /spec/template/spec/containers/*/imagePullPolicy: "Always" != "IfNotPresent"`},
+	}, {
+		name: "mutate existing targets",
+		policies: func(t *testing.T) []kyvernov1.PolicyInterface {
+			return []kyvernov1.PolicyInterface{
+				newClusterPolicy(t, "a", `
+rules:
+- name: annotate
+  match:
+    any:
+    - resources:
+        kinds: [ConfigMap]
+  mutate:
+    targets:
+    - apiVersion: v1
+      kind: Secret
+      namespace: "{{ request.object.metadata.namespace }}"
+    patchStrategicMerge:
+      metadata:
+        annotations:
+          synced: "true"
+`),
+				newClusterPolicy(t, "b", `
+rules:
+- name: annotate
+  match:
+    any:
+    - resources:
+        kinds: [Secret]
+  mutate:
+    patchStrategicMerge:
+      metadata:
+        annotations:
+          synced: "false"
+`),
+			}
+		},
+		want: []string{`mutate rule a/annotate conflicts with rule b/annotate at path /metadata/annotations/synced: "true" != "false"`},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, conflict := range Find(tt.policies(t)) {
+				got = append(got, conflict.String())
+			}
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
+
+func Test_FindWith(t *testing.T) {
+	policy := newClusterPolicy(t, "a", pullPolicyAlways)
+	previous := newClusterPolicy(t, "a", `
+rules:
+- name: always
+  match:
+    any:
+    - resources:
+        kinds: [Pod]
+  mutate:
+    patchStrategicMerge:
+      spec:
+        containers:
+        - (name): "*"
+          imagePullPolicy: Never
+`)
+	assert.Equal(t, len(FindWith(policy, []kyvernov1.PolicyInterface{previous})), 0)
+	assert.Equal(t, len(FindWith(policy, []kyvernov1.PolicyInterface{newPolicy(t, "default", "a", "")})), 0)
+	other := newPolicy(t, "default", "b", `
+rules:
+- name: never
+  match:
+    any:
+    - resources:
+        kinds: [Pod]
+  mutate:
+    patchStrategicMerge:
+      spec:
+        containers:
+        - (name): "*"
+          imagePullPolicy: Never
+`)
+	found := FindWith(policy, []kyvernov1.PolicyInterface{other})
+	assert.Assert(t, len(found) > 0)
+	assert.Equal(t, found[0].Summary(), "mutate rule a/always conflicts with a mutate rule of another policy at path /spec/containers/*/imagePullPolicy")
+}
+
+func Test_pathsOverlap(t *testing.T) {
+	tests := []struct {
+		name string
+		a    write
+		b    write
+		want bool
+	}{
+		{name: "same path", a: write{path: []string{"metadata", "name"}}, b: write{path: []string{"metadata", "name"}}, want: true},
+		{name: "different path", a: write{path: []string{"metadata", "name"}, value: "a"}, b: write{path: []string{"metadata", "namespace"}, value: "b"}},
+		{name: "any element", a: write{path: []string{"spec", "containers", "*", "image"}}, b: write{path: []string{"spec", "containers", "[name=nginx]", "image"}}, want: true},
+		{name: "index and named element", a: write{path: []string{"spec", "containers", "0", "image"}}, b: write{path: []string{"spec", "containers", "[name=nginx]", "image"}}, want: true},
+		{name: "different indexes", a: write{path: []string{"spec", "containers", "0", "image"}}, b: write{path: []string{"spec", "containers", "1", "image"}}},
+		{name: "parent removal", a: write{path: []string{"metadata", "labels"}}, b: write{path: []string{"metadata", "labels", "app"}, value: "nginx"}, want: true},
+		{name: "parent write", a: write{path: []string{"metadata", "labels"}, value: []interface{}{}}, b: write{path: []string{"metadata", "labels", "app"}, value: "nginx"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, pathsOverlap(tt.a, tt.b), tt.want)
+		})
+	}
+}
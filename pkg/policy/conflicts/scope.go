@@ -0,0 +1,163 @@
+package conflicts
+
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// clause is a set of resource descriptions a resource must all match
+type clause []kyvernov1.ResourceDescription
+
+// ruleScope returns the resources a mutate rule may modify, a resource must match one of the clauses.
+// Exclusions and preconditions are not considered, the scope is an over-approximation.
+func ruleScope(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) []clause {
+	var clauses []clause
+	if rule.IsMutateExisting() {
+		for _, target := range rule.Mutation.Targets {
+			description := kyvernov1.ResourceDescription{
+				Kinds:             []string{target.Kind},
+				Selector:          target.Selector,
+				NamespaceSelector: target.NamespaceSelector,
+			}
+			if target.Name != "" {
+				description.Names = []string{target.Name}
+			}
+			if target.Namespace != "" {
+				description.Namespaces = []string{target.Namespace}
+			}
+			clauses = append(clauses, clause{description})
+		}
+	} else if len(rule.MatchResources.Any) > 0 {
+		for _, filter := range rule.MatchResources.Any {
+			clauses = append(clauses, clause{filter.ResourceDescription})
+		}
+	} else if len(rule.MatchResources.All) > 0 {
+		var all clause
+		for _, filter := range rule.MatchResources.All {
+			all = append(all, filter.ResourceDescription)
+		}
+		clauses = append(clauses, all)
+	} else {
+		clauses = append(clauses, clause{rule.MatchResources.ResourceDescription})
+	}
+	// namespaced policies only apply to resources in their namespace
+	if policy.IsNamespaced() {
+		for i := range clauses {
+			clauses[i] = append(clauses[i], kyvernov1.ResourceDescription{Namespaces: []string{policy.GetNamespace()}})
+		}
+	}
+	return clauses
+}
+
+func scopesOverlap(a, b []clause) bool {
+	for _, ca := range a {
+		for _, cb := range b {
+			if clausesOverlap(ca, cb) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func clausesOverlap(a, b clause) bool {
+	all := append(append(clause{}, a...), b...)
+	for i := range all {
+		for j := i + 1; j < len(all); j++ {
+			if !descriptionsOverlap(all[i], all[j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func descriptionsOverlap(a, b kyvernov1.ResourceDescription) bool {
+	return kindsOverlap(a.Kinds, b.Kinds) &&
+		patternsOverlap(names(a), names(b)) &&
+		patternsOverlap(a.Namespaces, b.Namespaces) &&
+		labelsOverlap(a.Annotations, b.Annotations) &&
+		selectorsOverlap(a.Selector, b.Selector) &&
+		selectorsOverlap(a.NamespaceSelector, b.NamespaceSelector) &&
+		operationsOverlap(a.Operations, b.Operations)
+}
+
+func names(description kyvernov1.ResourceDescription) []string {
+	if description.Name != "" {
+		return append([]string{description.Name}, description.Names...)
+	}
+	return description.Names
+}
+
+func kindsOverlap(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	for _, ka := range a {
+		ga, va, ra, sa := kubeutils.ParseKindSelector(ka)
+		for _, kb := range b {
+			gb, vb, rb, sb := kubeutils.ParseKindSelector(kb)
+			if patternOverlap(ga, gb) && patternOverlap(va, vb) && patternOverlap(ra, rb) && patternOverlap(sa, sb) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func patternsOverlap(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	for _, pa := range a {
+		for _, pb := range b {
+			if patternOverlap(pa, pb) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// patternOverlap checks if two wildcard patterns may match the same value, values containing variables match anything
+func patternOverlap(a, b string) bool {
+	if regex.IsVariable(a) || regex.IsVariable(b) {
+		return true
+	}
+	return wildcard.Match(a, b) || wildcard.Match(b, a)
+}
+
+// labelsOverlap checks if two sets of required labels can be satisfied by the same resource
+func labelsOverlap(a, b map[string]string) bool {
+	for key, va := range a {
+		if vb, ok := b[key]; ok && !patternOverlap(va, vb) {
+			return false
+		}
+	}
+	return true
+}
+
+// selectorsOverlap only considers the selectors match labels, expressions are assumed to overlap
+func selectorsOverlap(a, b *metav1.LabelSelector) bool {
+	if a == nil || b == nil {
+		return true
+	}
+	return labelsOverlap(a.MatchLabels, b.MatchLabels)
+}
+
+func operationsOverlap(a, b []kyvernov1.AdmissionOperation) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	for _, oa := range a {
+		for _, ob := range b {
+			if oa == ob {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,230 @@
+package conflicts
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/anchor"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
+	"github.com/kyverno/kyverno/pkg/utils/api"
+	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+)
+
+// anyElement is the path segment of array elements that can't be identified statically
+const anyElement = "*"
+
+// write is a value set or removed by a mutate rule at a resource path
+type write struct {
+	path []string
+	// value is nil when the path is removed
+	value interface{}
+}
+
+// ruleWrites returns the writes of the patches declared in a mutate rule, including foreach patches.
+// Conditional and add if not present anchors don't override values and are not considered writes.
+func ruleWrites(rule kyvernov1.Rule) []write {
+	var writes []write
+	writes = append(writes, patchWrites(rule.Mutation.GetPatchStrategicMerge(), rule.Mutation.GetPatchMerge(), rule.Mutation.PatchesJSON6902)...)
+	writes = append(writes, foreachWrites(rule.Mutation.ForEachMutation)...)
+	sort.SliceStable(writes, func(i, j int) bool {
+		return formatPath(writes[i].path) < formatPath(writes[j].path)
+	})
+	return writes
+}
+
+func foreachWrites(foreach []kyvernov1.ForEachMutation) []write {
+	var writes []write
+	for _, fe := range foreach {
+		if fe.ForEachMutation != nil {
+			// invalid nested foreach declarations are reported by the policy validation
+			nested, err := api.DeserializeJSONArray[kyvernov1.ForEachMutation](fe.ForEachMutation)
+			if err == nil {
+				writes = append(writes, foreachWrites(nested)...)
+			}
+			continue
+		}
+		writes = append(writes, patchWrites(fe.GetPatchStrategicMerge(), fe.GetPatchMerge(), fe.PatchesJSON6902)...)
+	}
+	return writes
+}
+
+func patchWrites(patchStrategicMerge, patchMerge interface{}, patchesJSON6902 string) []write {
+	var writes []write
+	if patchStrategicMerge != nil {
+		writes = append(writes, strategicMergeWrites(nil, patchStrategicMerge)...)
+	}
+	if patchMerge != nil {
+		writes = append(writes, mergePatchWrites(nil, patchMerge)...)
+	}
+	if patchesJSON6902 != "" {
+		writes = append(writes, jsonPatchWrites(patchesJSON6902)...)
+	}
+	return writes
+}
+
+func strategicMergeWrites(path []string, value interface{}) []write {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		var writes []write
+		for key, child := range typed {
+			// patch directives, e.g. $patch or $setElementOrder
+			if strings.HasPrefix(key, "$") {
+				continue
+			}
+			if anchor.Parse(key) != nil {
+				continue
+			}
+			writes = append(writes, strategicMergeWrites(appendSegment(path, key), child)...)
+		}
+		return writes
+	case []interface{}:
+		if len(typed) == 0 {
+			return []write{{path: path, value: typed}}
+		}
+		var writes []write
+		for _, element := range typed {
+			if _, ok := element.(map[string]interface{}); !ok {
+				// lists of scalars are replaced as a whole
+				return []write{{path: path, value: typed}}
+			}
+			writes = append(writes, strategicMergeWrites(append(copyPath(path), elementSegment(element)), element)...)
+		}
+		return writes
+	default:
+		return []write{{path: path, value: value}}
+	}
+}
+
+// mergePatchWrites follows RFC 7386, maps are merged, null values remove keys and other values, including lists,
+// are replaced as a whole
+func mergePatchWrites(path []string, value interface{}) []write {
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return []write{{path: path, value: value}}
+	}
+	var writes []write
+	for key, child := range fields {
+		writes = append(writes, mergePatchWrites(appendSegment(path, key), child)...)
+	}
+	return writes
+}
+
+// elementSegment identifies an array element by its name, as the name is the merge key of most lists
+func elementSegment(element interface{}) string {
+	fields, _ := element.(map[string]interface{})
+	for _, key := range []string{"name", anchor.String(anchor.Condition, "name"), anchor.String(anchor.Equality, "name")} {
+		if name, ok := fields[key].(string); ok && !regex.IsVariable(name) && !wildcard.ContainsWildcard(name) {
+			return fmt.Sprintf("[name=%s]", name)
+		}
+	}
+	return anyElement
+}
+
+func jsonPatchWrites(patches string) []write {
+	data, err := patch.ConvertPatchesToJSON(patches)
+	if err != nil {
+		return nil
+	}
+	var operations []struct {
+		Op    string      `json:"op"`
+		Path  string      `json:"path"`
+		Value interface{} `json:"value"`
+	}
+	// invalid patches are reported by the policy validation
+	if err := json.Unmarshal(data, &operations); err != nil {
+		return nil
+	}
+	var writes []write
+	for _, operation := range operations {
+		path := jsonPointerSegments(operation.Path)
+		if len(path) == 0 {
+			continue
+		}
+		last := path[len(path)-1]
+		switch operation.Op {
+		case "add":
+			// appending to or inserting in an array doesn't override other values
+			if _, err := strconv.Atoi(last); err == nil || last == "-" {
+				continue
+			}
+			writes = append(writes, strategicMergeWrites(path, operation.Value)...)
+		case "replace":
+			writes = append(writes, strategicMergeWrites(path, operation.Value)...)
+		case "remove":
+			writes = append(writes, write{path: path})
+		}
+	}
+	return writes
+}
+
+func jsonPointerSegments(pointer string) []string {
+	pointer = strings.TrimPrefix(pointer, "/")
+	if pointer == "" {
+		return nil
+	}
+	segments := strings.Split(pointer, "/")
+	for i, segment := range segments {
+		if regex.IsVariable(segment) {
+			segments[i] = anyElement
+			continue
+		}
+		segment = strings.ReplaceAll(segment, "~1", "/")
+		segments[i] = strings.ReplaceAll(segment, "~0", "~")
+	}
+	return segments
+}
+
+func appendSegment(path []string, segment string) []string {
+	if regex.IsVariable(segment) {
+		segment = anyElement
+	}
+	return append(copyPath(path), segment)
+}
+
+func copyPath(path []string) []string {
+	return append(make([]string, 0, len(path)+1), path...)
+}
+
+// pathsOverlap checks if two writes may target the same resource element,
+// a removal also overlaps with the writes below the removed path
+func pathsOverlap(a, b write) bool {
+	if len(a.path) != len(b.path) {
+		shorter, longer := a, b
+		if len(b.path) < len(a.path) {
+			shorter, longer = b, a
+		}
+		if shorter.value != nil {
+			return false
+		}
+		return segmentsOverlap(shorter.path, longer.path[:len(shorter.path)])
+	}
+	return segmentsOverlap(a.path, b.path)
+}
+
+func segmentsOverlap(a, b []string) bool {
+	for i := range a {
+		if !segmentOverlap(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func segmentOverlap(a, b string) bool {
+	if a == b || a == anyElement || b == anyElement {
+		return true
+	}
+	// an array index may point to any named element
+	_, errA := strconv.Atoi(a)
+	_, errB := strconv.Atoi(b)
+	return (errA == nil && isElementSelector(b)) || (errB == nil && isElementSelector(a))
+}
+
+func isElementSelector(segment string) bool {
+	return strings.HasPrefix(segment, "[name=")
+}
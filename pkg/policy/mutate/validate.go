@@ -11,6 +11,7 @@ import (
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"go.uber.org/multierr"
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // Mutate provides implementation to validate 'mutate' rule
@@ -30,18 +31,21 @@ func NewMutateFactory(m kyvernov1.Mutation, client dclient.Interface) *Mutate {
 // Validate validates the 'mutate' rule
 func (m *Mutate) Validate(ctx context.Context) (string, error) {
 	if m.hasForEach() {
-		if m.hasPatchStrategicMerge() || m.hasPatchesJSON6902() {
-			return "foreach", fmt.Errorf("only one of `foreach`, `patchStrategicMerge`, or `patchesJson6902` is allowed")
+		if m.hasPatchStrategicMerge() || m.hasPatchMerge() || m.hasPatchesJSON6902() {
+			return "foreach", fmt.Errorf("only one of `foreach`, `patchStrategicMerge`, `patchMerge`, or `patchesJson6902` is allowed")
 		}
 
 		return m.validateForEach("", m.mutation.ForEachMutation)
 	}
 
-	if m.hasPatchesJSON6902() && m.hasPatchStrategicMerge() {
-		return "foreach", fmt.Errorf("only one of `patchStrategicMerge` or `patchesJson6902` is allowed")
+	if countPatches(m.hasPatchStrategicMerge(), m.hasPatchMerge(), m.hasPatchesJSON6902()) > 1 {
+		return "foreach", fmt.Errorf("only one of `patchStrategicMerge`, `patchMerge`, or `patchesJson6902` is allowed")
 	}
 
 	if m.mutation.Targets != nil {
+		if path, err := validateTargetSelectors(m.mutation.Targets); err != nil {
+			return path, err
+		}
 		if err := m.validateAuth(ctx, m.mutation.Targets); err != nil {
 			return "targets", fmt.Errorf("auth check fails, require additional privileges, update the ClusterRole 'kyverno:background-controller:additional':%v", err)
 		}
@@ -49,20 +53,42 @@ func (m *Mutate) Validate(ctx context.Context) (string, error) {
 	return "", nil
 }
 
+// validateTargetSelectors checks the targets label selectors, selectors containing variables are checked after substitution
+func validateTargetSelectors(targets []kyvernov1.TargetResourceSpec) (string, error) {
+	for i, target := range targets {
+		if err := validateSelector(target.Selector); err != nil {
+			return fmt.Sprintf("targets[%d].selector", i), err
+		}
+		if err := validateSelector(target.NamespaceSelector); err != nil {
+			return fmt.Sprintf("targets[%d].namespaceSelector", i), err
+		}
+	}
+	return "", nil
+}
+
+func validateSelector(selector *metav1.LabelSelector) error {
+	if selector == nil || regex.IsVariable(selector.String()) {
+		return nil
+	}
+	if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+		return fmt.Errorf("invalid label selector: %v", err)
+	}
+	return nil
+}
+
 func (m *Mutate) validateForEach(tag string, foreach []kyvernov1.ForEachMutation) (string, error) {
 	for i, fe := range foreach {
 		tag = tag + fmt.Sprintf("foreach[%d]", i)
 		if fe.ForEachMutation != nil {
-			if fe.Context != nil || fe.AnyAllConditions != nil || fe.PatchesJSON6902 != "" || fe.RawPatchStrategicMerge != nil {
+			if fe.Context != nil || fe.AnyAllConditions != nil || fe.PatchesJSON6902 != "" || fe.RawPatchStrategicMerge != nil || fe.RawPatchMerge != nil {
 				return tag, fmt.Errorf("a nested foreach cannot contain other declarations")
 			}
 
 			return m.validateNestedForEach(tag, fe.ForEachMutation)
 		}
 
-		psm := fe.GetPatchStrategicMerge()
-		if (fe.PatchesJSON6902 == "" && psm == nil) || (fe.PatchesJSON6902 != "" && psm != nil) {
-			return tag, fmt.Errorf("only one of `patchStrategicMerge` or `patchesJson6902` is allowed")
+		if countPatches(fe.GetPatchStrategicMerge() != nil, fe.GetPatchMerge() != nil, fe.PatchesJSON6902 != "") != 1 {
+			return tag, fmt.Errorf("only one of `patchStrategicMerge`, `patchMerge`, or `patchesJson6902` is allowed")
 		}
 	}
 
@@ -86,10 +112,25 @@ func (m *Mutate) hasPatchStrategicMerge() bool {
 	return m.mutation.GetPatchStrategicMerge() != nil
 }
 
+func (m *Mutate) hasPatchMerge() bool {
+	return m.mutation.GetPatchMerge() != nil
+}
+
 func (m *Mutate) hasPatchesJSON6902() bool {
 	return m.mutation.PatchesJSON6902 != ""
 }
 
+// countPatches returns the number of patch declarations present
+func countPatches(present ...bool) int {
+	count := 0
+	for _, p := range present {
+		if p {
+			count++
+		}
+	}
+	return count
+}
+
 func (m *Mutate) validateAuth(ctx context.Context, targets []kyvernov1.TargetResourceSpec) error {
 	var errs []error
 	for _, target := range targets {
@@ -21,8 +21,11 @@ import (
 	"github.com/kyverno/kyverno/pkg/autogen"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	openapicontroller "github.com/kyverno/kyverno/pkg/controllers/openapi"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/cel"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/engine/variables/operator"
 	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/openapi"
@@ -33,6 +36,7 @@ import (
 	"golang.org/x/exp/slices"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apimachinery/pkg/util/yaml"
@@ -40,10 +44,10 @@ import (
 )
 
 var (
-	allowedVariables                   = regexp.MustCompile(`request\.|serviceAccountName|serviceAccountNamespace|element|elementIndex|@|images\.|image\.|([a-z_0-9]+\()[^{}]`)
-	allowedVariablesBackground         = regexp.MustCompile(`request\.|element|elementIndex|@|images\.|image\.|([a-z_0-9]+\()[^{}]`)
-	allowedVariablesInTarget           = regexp.MustCompile(`request\.|serviceAccountName|serviceAccountNamespace|element|elementIndex|@|images\.|image\.|target\.|([a-z_0-9]+\()[^{}]`)
-	allowedVariablesBackgroundInTarget = regexp.MustCompile(`request\.|element|elementIndex|@|images\.|image\.|target\.|([a-z_0-9]+\()[^{}]`)
+	allowedVariables                   = regexp.MustCompile(`request\.|serviceAccountName|serviceAccountNamespace|element|elementIndex|@|violation\.|images\.|image\.|([a-z_0-9]+\()[^{}]`)
+	allowedVariablesBackground         = regexp.MustCompile(`request\.|element|elementIndex|@|violation\.|images\.|image\.|([a-z_0-9]+\()[^{}]`)
+	allowedVariablesInTarget           = regexp.MustCompile(`request\.|serviceAccountName|serviceAccountNamespace|element|elementIndex|@|violation\.|images\.|image\.|target\.|([a-z_0-9]+\()[^{}]`)
+	allowedVariablesBackgroundInTarget = regexp.MustCompile(`request\.|element|elementIndex|@|violation\.|images\.|image\.|target\.|([a-z_0-9]+\()[^{}]`)
 	// wildCardAllowedVariables represents regex for the allowed fields in wildcards
 	wildCardAllowedVariables = regexp.MustCompile(`\{\{\s*(request\.|serviceAccountName|serviceAccountNamespace)[^{}]*\}\}`)
 	errOperationForbidden    = errors.New("variables are forbidden in the path of a JSONPatch")
@@ -125,6 +129,16 @@ func checkValidationFailureAction(spec *kyvernov1.Spec) []string {
 			return []string{msg}
 		}
 	}
+	for _, rule := range spec.Rules {
+		if action := rule.Validation.ValidationFailureAction; action != nil && (*action == "enforce" || *action == "audit") {
+			return []string{msg}
+		}
+		for _, override := range rule.Validation.ValidationFailureActionOverrides {
+			if override.Action == "enforce" || override.Action == "audit" {
+				return []string{msg}
+			}
+		}
+	}
 	return nil
 }
 
@@ -183,11 +197,27 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 		return warnings, errs.ToAggregate()
 	}
 
+	if err := validateContextEntries(spec.Context); err != nil {
+		return warnings, fmt.Errorf("path: spec.context: %v", err)
+	}
+
+	if !mock {
+		if err := validateResourceLookupKinds(spec.Context, client); err != nil {
+			return warnings, fmt.Errorf("path: spec.context: %v", err)
+		}
+	}
+
 	if !namespaced {
 		err := validateNamespaces(spec, specPath.Child("validationFailureActionOverrides"))
 		if err != nil {
 			return warnings, err
 		}
+		for i, rule := range spec.Rules {
+			err := validateOverridesNamespaces(rule.Validation.ValidationFailureActionOverrides, specPath.Child("rules").Index(i).Child("validate", "validationFailureActionOverrides"))
+			if err != nil {
+				return warnings, err
+			}
+		}
 	}
 
 	rules := autogen.ComputeRules(policy)
@@ -297,10 +327,22 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 			return warnings, fmt.Errorf("path: spec.rules[%d]: %v", i, err)
 		}
 
+		if !mock {
+			if err := validateRuleResourceLookupKinds(rule, client); err != nil {
+				return warnings, fmt.Errorf("path: spec.rules[%d].%v", i, err)
+			}
+		}
+
 		if err := validateRuleImageExtractorsJMESPath(rule); err != nil {
 			return warnings, fmt.Errorf("path: spec.rules[%d]: %v", i, err)
 		}
 
+		if rule.HasValidateCEL() {
+			if path, err := cel.Validate(rule.Validation.CEL, spec.Context, rule.Context); err != nil {
+				return warnings, fmt.Errorf("path: spec.rules[%d].validate.cel.%s: %v", i, path, err)
+			}
+		}
+
 		// If a rule's match block does not match any kind,
 		// we should only allow it to have metadata in its overlay
 		if len(rule.MatchResources.Any) > 0 {
@@ -338,10 +380,8 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 		}
 
 		if rule.HasVerifyImages() {
-			isAuditFailureAction := false
-			if spec.ValidationFailureAction == kyvernov1.Audit {
-				isAuditFailureAction = true
-			}
+			action, _ := rule.GetValidationFailureAction(spec)
+			isAuditFailureAction := action == kyvernov1.Audit
 
 			verifyImagePath := rulePath.Child("verifyImages")
 			for index, i := range rule.VerifyImages {
@@ -447,14 +487,14 @@ func hasInvalidVariables(policy kyvernov1.PolicyInterface, background bool) erro
 		for i := range withoutTargets.Mutation.Targets {
 			withoutTargets.Mutation.Targets[i].RawAnyAllConditions = nil
 		}
-		ctx := buildContext(withoutTargets, background, false, nil)
+		ctx := buildContext(policy.GetSpec().Context, withoutTargets, background, false, nil)
 		if _, err := variables.SubstituteAllInRule(logging.GlobalLogger(), ctx, *withoutTargets); !variables.CheckNotFoundErr(err) {
 			return fmt.Errorf("variable substitution failed for rule %s: %s", withoutTargets.Name, err.Error())
 		}
 
 		// perform variable checks with mutate.targets
 		for _, target := range r.Mutation.Targets {
-			ctx := buildContext(ruleCopy, background, true, target.Context)
+			ctx := buildContext(policy.GetSpec().Context, ruleCopy, background, true, target.Context)
 			if _, err := variables.SubstituteAllInRule(logging.GlobalLogger(), ctx, *ruleCopy); !variables.CheckNotFoundErr(err) {
 				return fmt.Errorf("variable substitution failed for rule target %s: %s", ruleCopy.Name, err.Error())
 			}
@@ -568,9 +608,10 @@ func imageRefHasVariables(verifyImages []kyvernov1.ImageVerification) error {
 	return nil
 }
 
-func buildContext(rule *kyvernov1.Rule, background bool, target bool, targetContext []kyvernov1.ContextEntry) *enginecontext.MockContext {
+func buildContext(policyContext []kyvernov1.ContextEntry, rule *kyvernov1.Rule, background bool, target bool, targetContext []kyvernov1.ContextEntry) *enginecontext.MockContext {
 	re := getAllowedVariables(background, target)
 	ctx := enginecontext.NewMockContext(re)
+	addContextVariables(policyContext, ctx)
 	addContextVariables(rule.Context, ctx)
 	for _, fe := range rule.Validation.ForEachValidation {
 		addContextVariables(fe.Context, ctx)
@@ -644,9 +685,9 @@ func isLabelAndAnnotationsString(rule kyvernov1.Rule) bool {
 			if mk == "labels" {
 				labelKey, ok := metaKey[mk].(map[string]interface{})
 				if ok {
-					// range over labels
+					// range over labels, null values remove labels in merge patches
 					for _, val := range labelKey {
-						if reflect.TypeOf(val).String() != "string" {
+						if val != nil && reflect.TypeOf(val).String() != "string" {
 							return false
 						}
 					}
@@ -654,9 +695,9 @@ func isLabelAndAnnotationsString(rule kyvernov1.Rule) bool {
 			} else if mk == "annotations" {
 				annotationKey, ok := metaKey[mk].(map[string]interface{})
 				if ok {
-					// range over annotations
+					// range over annotations, null values remove annotations in merge patches
 					for _, val := range annotationKey {
-						if reflect.TypeOf(val).String() != "string" {
+						if val != nil && reflect.TypeOf(val).String() != "string" {
 							return false
 						}
 					}
@@ -720,14 +761,22 @@ func isLabelAndAnnotationsString(rule kyvernov1.Rule) bool {
 		if rule.Mutation.ForEachMutation != nil {
 			for _, foreach := range rule.Mutation.ForEachMutation {
 				forEachStrategicMergeMap, ok := foreach.GetPatchStrategicMerge().(map[string]interface{})
-				if ok {
-					return checkMetadata(forEachStrategicMergeMap)
+				if ok && !checkMetadata(forEachStrategicMergeMap) {
+					return false
+				}
+				forEachMergeMap, ok := foreach.GetPatchMerge().(map[string]interface{})
+				if ok && !checkMetadata(forEachMergeMap) {
+					return false
 				}
 			}
 		} else {
 			strategicMergeMap, ok := rule.Mutation.GetPatchStrategicMerge().(map[string]interface{})
-			if ok {
-				return checkMetadata(strategicMergeMap)
+			if ok && !checkMetadata(strategicMergeMap) {
+				return false
+			}
+			mergeMap, ok := rule.Mutation.GetPatchMerge().(map[string]interface{})
+			if ok && !checkMetadata(mergeMap) {
+				return false
 			}
 		}
 	}
@@ -743,6 +792,13 @@ func ruleOnlyDealsWithResourceMetaData(rule kyvernov1.Rule) bool {
 		}
 	}
 
+	mergePatch, _ := rule.Mutation.GetPatchMerge().(map[string]interface{})
+	for k := range mergePatch {
+		if k != "metadata" {
+			return false
+		}
+	}
+
 	if rule.Mutation.PatchesJSON6902 != "" {
 		bytes := []byte(rule.Mutation.PatchesJSON6902)
 		jp, _ := jsonpatch.DecodePatch(bytes)
@@ -890,6 +946,9 @@ func validateConditionValues(c kyvernov1.Condition) (string, error) {
 	if k == nil || v == nil || c.Operator == "" {
 		return "", fmt.Errorf("entered value of `key`, `value` or `operator` is missing or misspelled")
 	}
+	if err := validateConditionPatterns(c); err != nil {
+		return "value", err
+	}
 	switch reflect.TypeOf(k).Kind() {
 	case reflect.String:
 		value, err := validateValuesKeyRequest(c)
@@ -899,6 +958,46 @@ func validateConditionValues(c kyvernov1.Condition) (string, error) {
 	}
 }
 
+// validateConditionPatterns validates the regular expressions and semver ranges used as values
+// by the regex and semver operators, values containing variables are checked at evaluation time
+func validateConditionPatterns(c kyvernov1.Condition) error {
+	value := c.GetValue()
+	if regex.ObjectHasVariables(value) != nil {
+		return nil
+	}
+	switch c.Operator {
+	case kyvernov1.ConditionOperators["Matches"],
+		kyvernov1.ConditionOperators["NotMatches"],
+		kyvernov1.ConditionOperators["AnyMatches"],
+		kyvernov1.ConditionOperators["AllMatches"]:
+		var patterns []interface{}
+		switch typedValue := value.(type) {
+		case []interface{}:
+			patterns = typedValue
+		default:
+			patterns = append(patterns, typedValue)
+		}
+		for _, pattern := range patterns {
+			str, ok := pattern.(string)
+			if !ok {
+				return fmt.Errorf("operator %s expects a regular expression or a list of regular expressions", c.Operator)
+			}
+			if _, err := regexp.Compile(str); err != nil {
+				return fmt.Errorf("invalid regular expression %s: %v", str, err)
+			}
+		}
+	case kyvernov1.ConditionOperators["SemverSatisfies"]:
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("operator %s expects a semver range", c.Operator)
+		}
+		if _, err := operator.ParseSemverRange(str); err != nil {
+			return fmt.Errorf("invalid semver range %s: %v", str, err)
+		}
+	}
+	return nil
+}
+
 func validateValuesKeyRequest(c kyvernov1.Condition) (string, error) {
 	k := c.GetKey()
 	switch strings.ReplaceAll(k.(string), " ", "") {
@@ -945,36 +1044,48 @@ func validateConditionValuesKeyRequestOperation(c kyvernov1.Condition) (string,
 }
 
 func validateRuleContext(rule kyvernov1.Rule) error {
-	if rule.Context == nil || len(rule.Context) == 0 {
-		return nil
-	}
+	return validateContextEntries(rule.Context)
+}
 
-	for _, entry := range rule.Context {
+func validateContextEntries(entries []kyvernov1.ContextEntry) error {
+	for _, entry := range entries {
 		if entry.Name == "" {
 			return fmt.Errorf("a name is required for context entries")
 		}
-		for _, v := range []string{"images", "request", "serviceAccountName", "serviceAccountNamespace", "element", "elementIndex"} {
+		for _, v := range []string{"images", "request", "serviceAccountName", "serviceAccountNamespace", "element", "elementIndex", "violation"} {
 			if entry.Name == v || strings.HasPrefix(entry.Name, v+".") {
 				return fmt.Errorf("entry name %s is invalid as it conflicts with a pre-defined variable %s", entry.Name, v)
 			}
 		}
 
+		if countContextEntrySources(entry) != 1 {
+			return fmt.Errorf("exactly one of configMap or secret or resource or apiCall or imageRegistry or variable or globalReference is required for context entries")
+		}
+
 		var err error
-		if entry.ConfigMap != nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable == nil {
+		if entry.ConfigMap != nil {
 			err = validateConfigMap(entry)
-		} else if entry.ConfigMap == nil && entry.APICall != nil && entry.ImageRegistry == nil && entry.Variable == nil {
+		} else if entry.Secret != nil {
+			err = validateSecret(entry)
+		} else if entry.Resource != nil {
+			err = validateResourceLookup(entry)
+		} else if entry.APICall != nil {
 			err = validateAPICall(entry)
-		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry != nil && entry.Variable == nil {
+		} else if entry.ImageRegistry != nil {
 			err = validateImageRegistry(entry)
-		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable != nil {
+		} else if entry.Variable != nil {
 			err = validateVariable(entry)
-		} else {
-			return fmt.Errorf("exactly one of configMap or apiCall or imageRegistry or variable is required for context entries")
+		} else if entry.GlobalReference != nil {
+			err = validateGlobalReference(entry)
 		}
 
 		if err != nil {
 			return err
 		}
+
+		if err := validateOnError(entry); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -1017,6 +1128,67 @@ func validateRuleImageExtractorsJMESPath(rule kyvernov1.Rule) error {
 	return nil
 }
 
+func countContextEntrySources(entry kyvernov1.ContextEntry) int {
+	count := 0
+	if entry.ConfigMap != nil {
+		count++
+	}
+	if entry.Secret != nil {
+		count++
+	}
+	if entry.Resource != nil {
+		count++
+	}
+	if entry.APICall != nil {
+		count++
+	}
+	if entry.ImageRegistry != nil {
+		count++
+	}
+	if entry.Variable != nil {
+		count++
+	}
+	if entry.GlobalReference != nil {
+		count++
+	}
+	return count
+}
+
+func validateOnError(entry kyvernov1.ContextEntry) error {
+	if entry.OnError == nil {
+		return nil
+	}
+	switch entry.OnError.GetAction() {
+	case kyvernov1.OnErrorFail, kyvernov1.OnErrorIgnore:
+		if entry.OnError.Default != nil {
+			return fmt.Errorf("a default value is only allowed with the UseDefault onError action for context entry %s", entry.Name)
+		}
+	case kyvernov1.OnErrorUseDefault:
+		if entry.OnError.Default == nil {
+			return fmt.Errorf("a default value is required by the UseDefault onError action for context entry %s", entry.Name)
+		}
+	default:
+		return fmt.Errorf("invalid onError action %s for context entry %s, must be one of Fail, Ignore or UseDefault", entry.OnError.Action, entry.Name)
+	}
+	return nil
+}
+
+func validateGlobalReference(entry kyvernov1.ContextEntry) error {
+	if entry.GlobalReference.Name == "" {
+		return fmt.Errorf("a name is required for globalReference context entry")
+	}
+	// If JMESPath contains variables, the validation will fail because it's not possible to infer which value
+	// will be inserted by the variable
+	// Skip validation if a variable is detected
+	jmesPath := variables.ReplaceAllVars(entry.GlobalReference.JMESPath, func(s string) string { return "kyvernojmespathvariable" })
+	if !strings.Contains(jmesPath, "kyvernojmespathvariable") && entry.GlobalReference.JMESPath != "" {
+		if _, err := jmespath.NewParser().Parse(entry.GlobalReference.JMESPath); err != nil {
+			return fmt.Errorf("failed to parse JMESPath %s: %v", entry.GlobalReference.JMESPath, err)
+		}
+	}
+	return nil
+}
+
 func validateVariable(entry kyvernov1.ContextEntry) error {
 	// If JMESPath contains variables, the validation will fail because it's not possible to infer which value
 	// will be inserted by the variable
@@ -1048,6 +1220,114 @@ func validateConfigMap(entry kyvernov1.ContextEntry) error {
 	return nil
 }
 
+func validateSecret(entry kyvernov1.ContextEntry) error {
+	if entry.Secret.Name == "" {
+		return fmt.Errorf("a name is required for secret context entry")
+	}
+
+	if entry.Secret.Key == "" {
+		return fmt.Errorf("a key is required for secret context entry")
+	}
+
+	return nil
+}
+
+func validateResourceLookup(entry kyvernov1.ContextEntry) error {
+	if entry.Resource.APIVersion == "" {
+		return fmt.Errorf("an apiVersion is required for resource context entry")
+	}
+
+	if entry.Resource.Kind == "" {
+		return fmt.Errorf("a kind is required for resource context entry")
+	}
+
+	if engineapi.IsSecretKind(entry.Resource.APIVersion, entry.Resource.Kind) {
+		return fmt.Errorf("secrets can not be looked up with a resource context entry, use a secret context entry instead")
+	}
+
+	if entry.Resource.Name != "" && entry.Resource.Selector != nil {
+		return fmt.Errorf("name and selector are mutually exclusive for resource context entry")
+	}
+
+	if entry.Resource.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(entry.Resource.Selector); err != nil {
+			return fmt.Errorf("invalid selector for resource context entry: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// validateResourceLookupKinds checks the kinds of resource context entries are served by the cluster
+func validateResourceLookupKinds(entries []kyvernov1.ContextEntry, client dclient.Interface) error {
+	for _, entry := range entries {
+		if entry.Resource == nil {
+			continue
+		}
+		gvk := schema.FromAPIVersionAndKind(entry.Resource.APIVersion, entry.Resource.Kind)
+		if _, err := client.Discovery().GetGVRFromGVK(gvk); err != nil {
+			return fmt.Errorf("unable to find resource %s %s for context entry %s: %v", entry.Resource.APIVersion, entry.Resource.Kind, entry.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateRuleResourceLookupKinds checks the kinds of resource context entries declared in the rule,
+// its foreach declarations (including nested ones) and its mutate targets
+func validateRuleResourceLookupKinds(rule kyvernov1.Rule, client dclient.Interface) error {
+	if err := validateResourceLookupKinds(rule.Context, client); err != nil {
+		return fmt.Errorf("context: %v", err)
+	}
+	if err := validateForEachValidationResourceLookupKinds(rule.Validation.ForEachValidation, "validate.foreach", client); err != nil {
+		return err
+	}
+	if err := validateForEachMutationResourceLookupKinds(rule.Mutation.ForEachMutation, "mutate.foreach", client); err != nil {
+		return err
+	}
+	for i, target := range rule.Mutation.Targets {
+		if err := validateResourceLookupKinds(target.Context, client); err != nil {
+			return fmt.Errorf("mutate.targets[%d].context: %v", i, err)
+		}
+	}
+	return nil
+}
+
+func validateForEachValidationResourceLookupKinds(foreach []kyvernov1.ForEachValidation, path string, client dclient.Interface) error {
+	for i, fe := range foreach {
+		if err := validateResourceLookupKinds(fe.Context, client); err != nil {
+			return fmt.Errorf("%s[%d].context: %v", path, i, err)
+		}
+		if fe.ForEachValidation != nil {
+			nested, err := apiutils.DeserializeJSONArray[kyvernov1.ForEachValidation](fe.ForEachValidation)
+			if err != nil {
+				return fmt.Errorf("%s[%d].foreach: %v", path, i, err)
+			}
+			if err := validateForEachValidationResourceLookupKinds(nested, fmt.Sprintf("%s[%d].foreach", path, i), client); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateForEachMutationResourceLookupKinds(foreach []kyvernov1.ForEachMutation, path string, client dclient.Interface) error {
+	for i, fe := range foreach {
+		if err := validateResourceLookupKinds(fe.Context, client); err != nil {
+			return fmt.Errorf("%s[%d].context: %v", path, i, err)
+		}
+		if fe.ForEachMutation != nil {
+			nested, err := apiutils.DeserializeJSONArray[kyvernov1.ForEachMutation](fe.ForEachMutation)
+			if err != nil {
+				return fmt.Errorf("%s[%d].foreach: %v", path, i, err)
+			}
+			if err := validateForEachMutationResourceLookupKinds(nested, fmt.Sprintf("%s[%d].foreach", path, i), client); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func validateAPICall(entry kyvernov1.ContextEntry) error {
 	// If JMESPath contains variables, the validation will fail because it's not possible to infer which value
 	// will be inserted by the variable
@@ -1061,6 +1341,12 @@ func validateAPICall(entry kyvernov1.ContextEntry) error {
 		}
 	}
 
+	if entry.APICall.Service != nil {
+		if errs := entry.APICall.Service.Validate(field.NewPath("service")); len(errs) != 0 {
+			return errs.ToAggregate()
+		}
+	}
+
 	return nil
 }
 
@@ -1263,58 +1549,67 @@ func validateKinds(kinds []string, mock, backgroundScanningEnabled, isValidation
 	return nil
 }
 
-func validateWildcardsWithNamespaces(enforce, audit, enforceW, auditW []string) error {
-	pat, ns, notOk := wildcard.MatchPatterns(auditW, enforce...)
-	if notOk {
-		return fmt.Errorf("wildcard pattern '%s' matches with namespace '%s'", pat, ns)
-	}
-	pat, ns, notOk = wildcard.MatchPatterns(enforceW, audit...)
-	if notOk {
-		return fmt.Errorf("wildcard pattern '%s' matches with namespace '%s'", pat, ns)
-	}
-	pat1, pat2, notOk := wildcard.MatchPatterns(auditW, enforceW...)
-	if notOk {
-		return fmt.Errorf("wildcard pattern '%s' conflicts with the pattern '%s'", pat1, pat2)
+// validationFailureActions lists the validation failure actions that can be used in overrides,
+// deprecated lower case actions are merged with their replacement
+var validationFailureActions = []string{"audit", "enforce", "warn"}
+
+// validateWildcardsWithNamespaces checks that the wildcard patterns of an action
+// don't match the namespaces or the wildcard patterns of the other actions
+func validateWildcardsWithNamespaces(namespaces, patterns map[string]sets.Set[string]) error {
+	for _, a := range validationFailureActions {
+		for _, b := range validationFailureActions {
+			if a == b {
+				continue
+			}
+			pat, ns, notOk := wildcard.MatchPatterns(sets.List(patterns[a]), sets.List(namespaces[b])...)
+			if notOk {
+				return fmt.Errorf("wildcard pattern '%s' matches with namespace '%s'", pat, ns)
+			}
+		}
 	}
-	pat1, pat2, notOk = wildcard.MatchPatterns(enforceW, auditW...)
-	if notOk {
-		return fmt.Errorf("wildcard pattern '%s' conflicts with the pattern '%s'", pat1, pat2)
+	for _, a := range validationFailureActions {
+		for _, b := range validationFailureActions {
+			if a == b {
+				continue
+			}
+			pat1, pat2, notOk := wildcard.MatchPatterns(sets.List(patterns[a]), sets.List(patterns[b])...)
+			if notOk {
+				return fmt.Errorf("wildcard pattern '%s' conflicts with the pattern '%s'", pat1, pat2)
+			}
+		}
 	}
 	return nil
 }
 
 func validateNamespaces(s *kyvernov1.Spec, path *field.Path) error {
-	action := map[string]sets.Set[string]{
-		"enforce":  sets.New[string](),
-		"audit":    sets.New[string](),
-		"enforceW": sets.New[string](),
-		"auditW":   sets.New[string](),
+	return validateOverridesNamespaces(s.ValidationFailureActionOverrides, path)
+}
+
+func validateOverridesNamespaces(overrides []kyvernov1.ValidationFailureActionOverride, path *field.Path) error {
+	namespaces := map[string]sets.Set[string]{}
+	patterns := map[string]sets.Set[string]{}
+	for _, a := range validationFailureActions {
+		namespaces[a] = sets.New[string]()
+		patterns[a] = sets.New[string]()
 	}
 
-	for i, vfa := range s.ValidationFailureActionOverrides {
+	for i, vfa := range overrides {
 		patternList, nsList := wildcard.SeperateWildcards(vfa.Namespaces)
+		current := strings.ToLower(string(vfa.Action))
+		if _, ok := namespaces[current]; !ok {
+			continue
+		}
 
-		if vfa.Action.Audit() {
-			if action["enforce"].HasAny(nsList...) {
-				return fmt.Errorf("conflicting namespaces found in path: %s: %s", path.Index(i).Child("namespaces").String(),
-					strings.Join(sets.List(action["enforce"].Intersection(sets.New(nsList...))), ", "))
-			}
-			action["auditW"].Insert(patternList...)
-		} else if vfa.Action.Enforce() {
-			if action["audit"].HasAny(nsList...) {
+		for _, other := range validationFailureActions {
+			if other != current && namespaces[other].HasAny(nsList...) {
 				return fmt.Errorf("conflicting namespaces found in path: %s: %s", path.Index(i).Child("namespaces").String(),
-					strings.Join(sets.List(action["audit"].Intersection(sets.New(nsList...))), ", "))
+					strings.Join(sets.List(namespaces[other].Intersection(sets.New(nsList...))), ", "))
 			}
-			action["enforceW"].Insert(patternList...)
 		}
-		action[strings.ToLower(string(vfa.Action))].Insert(nsList...)
+		patterns[current].Insert(patternList...)
+		namespaces[current].Insert(nsList...)
 
-		err := validateWildcardsWithNamespaces(
-			sets.List(action["enforce"]),
-			sets.List(action["audit"]),
-			sets.List(action["enforceW"]),
-			sets.List(action["auditW"]),
-		)
+		err := validateWildcardsWithNamespaces(namespaces, patterns)
 		if err != nil {
 			return fmt.Errorf("path: %s: %s", path.Index(i).Child("namespaces").String(), err.Error())
 		}
@@ -40,6 +40,9 @@ func (v *Validate) Validate(ctx context.Context) (string, error) {
 		}); err != nil {
 			return fmt.Sprintf("pattern.%s", path), err
 		}
+		if path, err := common.ValidatePatternExpressions(target, "/"); err != nil {
+			return fmt.Sprintf("pattern.%s", path), err
+		}
 	}
 
 	if target := v.rule.GetAnyPattern(); target != nil {
@@ -57,6 +60,9 @@ func (v *Validate) Validate(ctx context.Context) (string, error) {
 			}); err != nil {
 				return fmt.Sprintf("anyPattern[%d].%s", i, path), err
 			}
+			if path, err := common.ValidatePatternExpressions(pattern, "/"); err != nil {
+				return fmt.Sprintf("anyPattern[%d].%s", i, path), err
+			}
 		}
 	}
 
@@ -74,11 +80,11 @@ func (v *Validate) Validate(ctx context.Context) (string, error) {
 func (v *Validate) validateElements() error {
 	count := validationElemCount(v.rule)
 	if count == 0 {
-		return fmt.Errorf("one of pattern, anyPattern, deny, foreach must be specified")
+		return fmt.Errorf("one of pattern, anyPattern, deny, foreach, cel must be specified")
 	}
 
 	if count > 1 {
-		return fmt.Errorf("only one of pattern, anyPattern, deny, foreach can be specified")
+		return fmt.Errorf("only one of pattern, anyPattern, deny, foreach, cel can be specified")
 	}
 
 	return nil
@@ -110,6 +116,10 @@ func validationElemCount(v *kyvernov1.Validation) int {
 		count++
 	}
 
+	if v.CEL != nil {
+		count++
+	}
+
 	if v.Manifests != nil && len(v.Manifests.Attestors) != 0 {
 		count++
 	}
@@ -131,6 +141,18 @@ func (v *Validate) validateForEach(foreach kyvernov1.ForEachValidation) error {
 		return fmt.Errorf("only one of pattern, anyPattern, deny, or a nested foreach can be specified")
 	}
 
+	if target := foreach.GetPattern(); target != nil {
+		if _, err := common.ValidatePatternExpressions(target, "/"); err != nil {
+			return fmt.Errorf("foreach.pattern: %w", err)
+		}
+	}
+
+	if target := foreach.GetAnyPattern(); target != nil {
+		if _, err := common.ValidatePatternExpressions(target, "/"); err != nil {
+			return fmt.Errorf("foreach.anyPattern: %w", err)
+		}
+	}
+
 	return nil
 }
 
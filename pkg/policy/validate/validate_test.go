@@ -380,3 +380,64 @@ func Test_Validate_Validate_Unsupported(t *testing.T) {
 	}
 
 }
+
+func Test_Validate_Pattern_Expressions(t *testing.T) {
+	testcases := []struct {
+		description   string
+		rawValidation []byte
+		expectedPath  string
+		wantErr       bool
+	}{{
+		description:   "valid expressions",
+		rawValidation: []byte(`{"pattern": {"spec": {"containers": [{"image": "re:^registry\\.io/.+:v[0-9]+$"}], "hostIP": "!cidr:169.254.0.0/16"}}}`),
+	}, {
+		description:   "variables are not checked",
+		rawValidation: []byte(`{"pattern": {"spec": {"hostIP": "cidr:{{ request.object.metadata.annotations.range }}"}}}`),
+	}, {
+		description:   "invalid regex",
+		rawValidation: []byte(`{"pattern": {"spec": {"containers": [{"image": "re:^v[0-9+$"}]}}}`),
+		expectedPath:  "pattern.//spec/containers0//image",
+		wantErr:       true,
+	}, {
+		description:   "invalid cidr in anyPattern",
+		rawValidation: []byte(`{"anyPattern": [{"spec": {"hostIP": "cidr:10.0.0.0/8"}}, {"spec": {"hostIP": "cidr:10.0.0.0"}}]}`),
+		expectedPath:  "anyPattern[1].//spec/hostIP",
+		wantErr:       true,
+	}, {
+		description:   "invalid regex in foreach",
+		rawValidation: []byte(`{"foreach": [{"list": "request.object.spec.containers", "pattern": {"image": "re:("}}]}`),
+		wantErr:       true,
+	}, {
+		description:   "valid cardinality",
+		rawValidation: []byte(`{"pattern": {"spec": {"containers": {"$size": "<= 3", "$count": 1, "$match": {"image": "re:^registry\\.io/"}}}}}`),
+	}, {
+		description:   "invalid size",
+		rawValidation: []byte(`{"pattern": {"spec": {"containers": {"$size": "<= many"}}}}`),
+		expectedPath:  "pattern.//spec/containers",
+		wantErr:       true,
+	}, {
+		description:   "count without match",
+		rawValidation: []byte(`{"pattern": {"spec": {"tolerations": {"$count": 1}}}}`),
+		expectedPath:  "pattern.//spec/tolerations",
+		wantErr:       true,
+	}, {
+		description:   "invalid regex in match",
+		rawValidation: []byte(`{"pattern": {"spec": {"containers": {"$match": {"image": "re:("}}}}}`),
+		expectedPath:  "pattern.//spec/containers/$match/image",
+		wantErr:       true,
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			var validation kyverno.Validation
+			assert.NilError(t, json.Unmarshal(tc.rawValidation, &validation))
+			checker := NewValidateFactory(&validation)
+			path, err := checker.Validate(context.TODO())
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+			}
+			assert.Equal(t, path, tc.expectedPath)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-logr/logr"
 	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/openapi"
 	"gotest.tools/assert"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
@@ -198,6 +199,61 @@ func Test_Validate_PreconditionsValuesString_KeyRequestOperation_UnknownValue(t
 	assert.Assert(t, err != nil)
 }
 
+func Test_Validate_Conditions_Patterns(t *testing.T) {
+	testcases := []struct {
+		name        string
+		conditions  string
+		expectedErr string
+	}{
+		{
+			name:       "valid-regex",
+			conditions: `[{"key": "{{ request.object.metadata.name }}", "operator": "Matches", "value": "^app-[a-z]+$"}]`,
+		},
+		{
+			name:       "valid-regex-list",
+			conditions: `[{"key": "{{ images.containers.*.registry }}", "operator": "AllMatches", "value": ["^docker.io$", "^ghcr.io$"]}]`,
+		},
+		{
+			name:       "regex-with-variable",
+			conditions: `[{"key": "{{ request.object.metadata.name }}", "operator": "NotMatches", "value": "{{ request.namespace }}"}]`,
+		},
+		{
+			name:        "invalid-regex",
+			conditions:  `[{"key": "{{ request.object.metadata.name }}", "operator": "Matches", "value": "app-("}]`,
+			expectedErr: "invalid regular expression app-(",
+		},
+		{
+			name:        "invalid-regex-type",
+			conditions:  `[{"key": "{{ request.object.metadata.name }}", "operator": "AnyMatches", "value": [1]}]`,
+			expectedErr: "operator AnyMatches expects a regular expression or a list of regular expressions",
+		},
+		{
+			name:       "valid-semver-range",
+			conditions: `[{"key": "{{ request.object.metadata.labels.version }}", "operator": "SemverSatisfies", "value": ">=1.2 <2.0 || >=3"}]`,
+		},
+		{
+			name:        "invalid-semver-range",
+			conditions:  `[{"key": "{{ request.object.metadata.labels.version }}", "operator": "SemverSatisfies", "value": ">=foo"}]`,
+			expectedErr: "invalid semver range >=foo",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var conditions []kyverno.Condition
+			err := json.Unmarshal([]byte(tc.conditions), &conditions)
+			assert.NilError(t, err)
+
+			path, err := validateConditions(conditions, "preconditions")
+			if tc.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				assert.Equal(t, path, "preconditions[0].value")
+			}
+		})
+	}
+}
+
 func Test_Validate_DenyConditionsValuesList_KeyRequestOperation_ExpectedItem(t *testing.T) {
 	denyConditions := []byte(`
 	[
@@ -2138,6 +2194,68 @@ func Test_ValidateNamespace(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "tc14",
+			spec: &kyverno.Spec{
+				ValidationFailureAction: "Enforce",
+				ValidationFailureActionOverrides: []kyverno.ValidationFailureActionOverride{
+					{
+						Action: "Warn",
+						Namespaces: []string{
+							"default",
+						},
+					},
+					{
+						Action: "Audit",
+						Namespaces: []string{
+							"default",
+						},
+					},
+				},
+				Rules: []kyverno.Rule{
+					{
+						Name:           "require-labels",
+						MatchResources: kyverno.MatchResources{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Pod"}}},
+						Validation: kyverno.Validation{
+							Message:    "label 'app.kubernetes.io/name' is required",
+							RawPattern: &apiextv1.JSON{Raw: []byte(`"metadata": {"lables": {"app.kubernetes.io/name": "?*"}}`)},
+						},
+					},
+				},
+			},
+			expectedError: errors.New("conflicting namespaces found in path: spec.validationFailureActionOverrides[1].namespaces: default"),
+		},
+		{
+			description: "tc15",
+			spec: &kyverno.Spec{
+				ValidationFailureAction: "Warn",
+				ValidationFailureActionOverrides: []kyverno.ValidationFailureActionOverride{
+					{
+						Action: "Enforce",
+						Namespaces: []string{
+							"default",
+						},
+					},
+					{
+						Action: "Warn",
+						Namespaces: []string{
+							"default*",
+						},
+					},
+				},
+				Rules: []kyverno.Rule{
+					{
+						Name:           "require-labels",
+						MatchResources: kyverno.MatchResources{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Pod"}}},
+						Validation: kyverno.Validation{
+							Message:    "label 'app.kubernetes.io/name' is required",
+							RawPattern: &apiextv1.JSON{Raw: []byte(`"metadata": {"lables": {"app.kubernetes.io/name": "?*"}}`)},
+						},
+					},
+				},
+			},
+			expectedError: errors.New("path: spec.validationFailureActionOverrides[1].namespaces: wildcard pattern 'default*' matches with namespace 'default'"),
+		},
 	}
 
 	for _, tc := range testcases {
@@ -2300,6 +2418,69 @@ func Test_Validate_RuleImageExtractorsJMESPath(t *testing.T) {
 	assert.Equal(t, expectedErr.Error(), actualErr.Error())
 }
 
+func Test_Validate_CEL(t *testing.T) {
+	testCases := []struct {
+		name        string
+		expressions string
+		expectedErr string
+	}{
+		{
+			name:        "valid",
+			expressions: `[{"expression": "object.spec.replicas <= maxReplicas && params.enabled"}]`,
+		},
+		{
+			name:        "syntax-error",
+			expressions: `[{"expression": "object.spec.replicas <="}]`,
+			expectedErr: "path: spec.rules[0].validate.cel.expressions[0].expression:",
+		},
+		{
+			name:        "undeclared-variable",
+			expressions: `[{"expression": "object.spec.replicas <= minReplicas"}]`,
+			expectedErr: "path: spec.rules[0].validate.cel.expressions[0].expression:",
+		},
+		{
+			name:        "not-a-bool",
+			expressions: `[{"expression": "true"}, {"expression": "'replicas'"}]`,
+			expectedErr: "path: spec.rules[0].validate.cel.expressions[1].expression: expression must evaluate to a bool, found string",
+		},
+		{
+			name:        "empty",
+			expressions: `[]`,
+			expectedErr: "path: spec.rules[0].validate.cel.expressions: at least one expression is required",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rawPolicy := []byte(`{
+				"apiVersion": "kyverno.io/v1",
+				"kind": "ClusterPolicy",
+				"metadata": {"name": "check-replicas"},
+				"spec": {
+					"rules": [
+						{
+							"name": "check-replicas",
+							"match": {"any": [{"resources": {"kinds": ["Deployment"]}}]},
+							"context": [{"name": "maxReplicas", "variable": {"value": 3}}],
+							"validate": {"cel": {"expressions": ` + tc.expressions + `}}
+						}
+					]
+				}
+			}`)
+			var policy *kyverno.ClusterPolicy
+			err := json.Unmarshal(rawPolicy, &policy)
+			assert.NilError(t, err)
+
+			openApiManager, _ := openapi.NewManager(logr.Discard())
+			_, err = Validate(policy, nil, nil, true, openApiManager)
+			if tc.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
 func Test_ImmutableGenerateFields(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -3366,3 +3547,283 @@ func Test_ImmutableGenerateFields(t *testing.T) {
 		assert.Assert(t, (err != nil) == test.expectedErr, test.name, err)
 	}
 }
+
+func Test_validateContextEntries(t *testing.T) {
+	testCases := []struct {
+		name        string
+		entries     string
+		expectedErr string
+	}{
+		{
+			name:    "global-reference",
+			entries: `[{"name": "deployments", "globalReference": {"name": "deployments", "jmesPath": "[].metadata.name"}}]`,
+		},
+		{
+			name:        "global-reference-without-name",
+			entries:     `[{"name": "deployments", "globalReference": {}}]`,
+			expectedErr: "a name is required for globalReference context entry",
+		},
+		{
+			name:        "global-reference-invalid-jmespath",
+			entries:     `[{"name": "deployments", "globalReference": {"name": "deployments", "jmesPath": "[]."}}]`,
+			expectedErr: "failed to parse JMESPath [].",
+		},
+		{
+			name:        "multiple-sources",
+			entries:     `[{"name": "deployments", "globalReference": {"name": "deployments"}, "variable": {"value": 3}}]`,
+			expectedErr: "exactly one of configMap or secret or resource or apiCall or imageRegistry or variable or globalReference is required for context entries",
+		},
+		{
+			name:        "no-source",
+			entries:     `[{"name": "deployments"}]`,
+			expectedErr: "exactly one of configMap or secret or resource or apiCall or imageRegistry or variable or globalReference is required for context entries",
+		},
+		{
+			name:    "service-call",
+			entries: `[{"name": "data", "apiCall": {"service": {"urlPath": "https://svc.ns/data", "requestType": "PUT", "headers": {"X-Request-Id": "{{ request.uid }}"}, "credentials": {"type": "BasicAuth", "secretRef": {"name": "creds", "namespace": "kyverno"}}, "timeout": "5s", "retry": {"attempts": 3, "backoff": "100ms"}}}}]`,
+		},
+		{
+			name:        "service-call-invalid-header",
+			entries:     `[{"name": "data", "apiCall": {"service": {"urlPath": "https://svc.ns/data", "requestType": "GET", "headers": {"X Request": "foo"}}}}]`,
+			expectedErr: "service.headers: Invalid value: \"X Request\": invalid header name",
+		},
+		{
+			name:        "service-call-invalid-credentials",
+			entries:     `[{"name": "data", "apiCall": {"service": {"urlPath": "https://svc.ns/data", "requestType": "GET", "credentials": {"type": "Token", "secretRef": {"name": "creds"}}}}}]`,
+			expectedErr: "[service.credentials.type: Unsupported value: \"Token\": supported values: \"BearerToken\", \"BasicAuth\", \"ClientCertificate\", service.credentials.secretRef.namespace: Required value: a secret namespace is required]",
+		},
+		{
+			name:        "service-call-invalid-timeout",
+			entries:     `[{"name": "data", "apiCall": {"service": {"urlPath": "https://svc.ns/data", "requestType": "GET", "timeout": "0s"}}}]`,
+			expectedErr: "service.timeout: Invalid value: \"0s\": the timeout must be greater than zero",
+		},
+		{
+			name:        "service-call-invalid-retry",
+			entries:     `[{"name": "data", "apiCall": {"service": {"urlPath": "https://svc.ns/data", "requestType": "GET", "retry": {"attempts": -1}}}}]`,
+			expectedErr: "service.retry.attempts: Invalid value: -1: the number of attempts must not be negative",
+		},
+		{
+			name:        "service-call-too-many-retries",
+			entries:     `[{"name": "data", "apiCall": {"service": {"urlPath": "https://svc.ns/data", "requestType": "GET", "retry": {"attempts": 6}}}}]`,
+			expectedErr: "service.retry.attempts: Invalid value: 6: the number of attempts must not be greater than 5",
+		},
+		{
+			name:    "secret",
+			entries: `[{"name": "password", "secret": {"name": "creds", "key": "password"}}]`,
+		},
+		{
+			name:        "secret-without-key",
+			entries:     `[{"name": "password", "secret": {"name": "creds"}}]`,
+			expectedErr: "a key is required for secret context entry",
+		},
+		{
+			name:        "secret-and-configmap",
+			entries:     `[{"name": "password", "secret": {"name": "creds", "key": "password"}, "configMap": {"name": "creds", "namespace": "default"}}]`,
+			expectedErr: "exactly one of configMap or secret or resource or apiCall or imageRegistry or variable or globalReference is required for context entries",
+		},
+		{
+			name:    "resource-by-name",
+			entries: `[{"name": "deployment", "resource": {"apiVersion": "apps/v1", "kind": "Deployment", "namespace": "{{ request.namespace }}", "name": "foo"}}]`,
+		},
+		{
+			name:    "resource-by-selector",
+			entries: `[{"name": "deployments", "resource": {"apiVersion": "apps/v1", "kind": "Deployment", "selector": {"matchLabels": {"app": "foo"}}}}]`,
+		},
+		{
+			name:        "resource-without-kind",
+			entries:     `[{"name": "deployments", "resource": {"apiVersion": "apps/v1"}}]`,
+			expectedErr: "a kind is required for resource context entry",
+		},
+		{
+			name:        "resource-secret",
+			entries:     `[{"name": "secret", "resource": {"apiVersion": "v1", "kind": "Secret", "name": "foo"}}]`,
+			expectedErr: "secrets can not be looked up with a resource context entry, use a secret context entry instead",
+		},
+		{
+			name:        "resource-name-and-selector",
+			entries:     `[{"name": "deployments", "resource": {"apiVersion": "apps/v1", "kind": "Deployment", "name": "foo", "selector": {"matchLabels": {"app": "foo"}}}}]`,
+			expectedErr: "name and selector are mutually exclusive for resource context entry",
+		},
+		{
+			name:        "resource-invalid-selector",
+			entries:     `[{"name": "deployments", "resource": {"apiVersion": "apps/v1", "kind": "Deployment", "selector": {"matchExpressions": [{"key": "app", "operator": "Foo"}]}}}]`,
+			expectedErr: "invalid selector for resource context entry: \"Foo\" is not a valid label selector operator",
+		},
+		{
+			name:    "on-error-use-default",
+			entries: `[{"name": "deployments", "globalReference": {"name": "deployments"}, "onError": {"action": "UseDefault", "default": []}}]`,
+		},
+		{
+			name:    "on-error-ignore",
+			entries: `[{"name": "deployments", "globalReference": {"name": "deployments"}, "onError": {"action": "Ignore"}}]`,
+		},
+		{
+			name:        "on-error-invalid-action",
+			entries:     `[{"name": "deployments", "globalReference": {"name": "deployments"}, "onError": {"action": "Retry"}}]`,
+			expectedErr: "invalid onError action Retry for context entry deployments, must be one of Fail, Ignore or UseDefault",
+		},
+		{
+			name:        "on-error-missing-default",
+			entries:     `[{"name": "deployments", "globalReference": {"name": "deployments"}, "onError": {"action": "UseDefault"}}]`,
+			expectedErr: "a default value is required by the UseDefault onError action for context entry deployments",
+		},
+		{
+			name:        "on-error-unexpected-default",
+			entries:     `[{"name": "deployments", "globalReference": {"name": "deployments"}, "onError": {"action": "Ignore", "default": []}}]`,
+			expectedErr: "a default value is only allowed with the UseDefault onError action for context entry deployments",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var entries []kyverno.ContextEntry
+			assert.NilError(t, json.Unmarshal([]byte(tc.entries), &entries))
+			err := validateContextEntries(entries)
+			if tc.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func Test_Validate_Violation_Variables(t *testing.T) {
+	rawPolicy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "disallow-latest-tag"},
+		"spec": {
+		  "rules": [
+			{
+			  "name": "validate-image-tag",
+			  "match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			  "validate": {
+				"message": "container {{ violation.elementKey }} uses {{ violation.actual }} at {{ violation.path }}",
+				"foreach": [
+				  {
+					"list": "request.object.spec.containers",
+					"pattern": {"image": "!*:latest"}
+				  }
+				]
+			  }
+			}
+		  ]
+		}
+	  }`)
+	var policy *kyverno.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+
+	openApiManager, _ := openapi.NewManager(logr.Discard())
+	_, err = Validate(policy, nil, nil, true, openApiManager)
+	assert.NilError(t, err)
+}
+
+func Test_Validate_Mutate_Target_Selectors(t *testing.T) {
+	testcases := []struct {
+		description string
+		rule        []byte
+		errorPath   string
+	}{
+		{
+			description: "invalid selector",
+			rule:        []byte(`{"name":"test","mutate":{"targets":[{"apiVersion":"apps/v1","kind":"Deployment","selector":{"matchLabels":{"team":"not a label value"}}}],"patchStrategicMerge":{"metadata":{"labels":{"foo":"bar"}}}}}`),
+			errorPath:   "targets[0].selector",
+		},
+		{
+			description: "invalid namespace selector",
+			rule:        []byte(`{"name":"test","mutate":{"targets":[{"apiVersion":"v1","kind":"ConfigMap"},{"apiVersion":"apps/v1","kind":"Deployment","namespaceSelector":{"matchExpressions":[{"key":"env","operator":"Equals","values":["prod"]}]}}],"patchStrategicMerge":{"metadata":{"labels":{"foo":"bar"}}}}}`),
+			errorPath:   "targets[1].namespaceSelector",
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			var rule kyverno.Rule
+			assert.NilError(t, json.Unmarshal(testcase.rule, &rule))
+			err := validateActions(0, &rule, nil, true)
+			assert.ErrorContains(t, err, testcase.errorPath)
+		})
+	}
+}
+
+func Test_isLabelAndAnnotationsString(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		want bool
+	}{{
+		name: "strategic merge patch with string labels",
+		rule: `{"name": "test", "mutate": {"patchStrategicMerge": {"metadata": {"labels": {"team": "apps"}}}}}`,
+		want: true,
+	}, {
+		name: "merge patch with a number label",
+		rule: `{"name": "test", "mutate": {"patchMerge": {"metadata": {"labels": {"team": 1}}}}}`,
+		want: false,
+	}, {
+		name: "merge patch removing a label",
+		rule: `{"name": "test", "mutate": {"patchMerge": {"metadata": {"labels": {"team": null}}}}}`,
+		want: true,
+	}, {
+		name: "foreach merge patch with a boolean annotation",
+		rule: `{"name": "test", "mutate": {"foreach": [{"list": "request.object.spec.containers", "patchMerge": {"metadata": {"annotations": {"checked": true}}}}]}}`,
+		want: false,
+	}, {
+		name: "second foreach with a number label",
+		rule: `{"name": "test", "mutate": {"foreach": [
+			{"list": "request.object.spec.containers", "patchStrategicMerge": {"metadata": {"labels": {"team": "apps"}}}},
+			{"list": "request.object.spec.containers", "patchStrategicMerge": {"metadata": {"labels": {"team": 1}}}}
+		]}}`,
+		want: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rule kyverno.Rule
+			assert.NilError(t, json.Unmarshal([]byte(tt.rule), &rule))
+			assert.Equal(t, isLabelAndAnnotationsString(rule), tt.want)
+		})
+	}
+}
+
+func Test_validateRuleResourceLookupKinds(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		wantErr string
+	}{{
+		name: "known kinds",
+		rule: `{"name": "test", "context": [{"name": "cm", "resource": {"apiVersion": "v1", "kind": "ConfigMap", "name": "cm"}}],
+			"validate": {"foreach": [{"list": "request.object.spec.containers", "context": [{"name": "ns", "resource": {"apiVersion": "v1", "kind": "Namespace", "name": "ns"}}]}]}}`,
+	}, {
+		name:    "unknown kind in rule context",
+		rule:    `{"name": "test", "context": [{"name": "widget", "resource": {"apiVersion": "v1", "kind": "Widget", "name": "widget"}}]}`,
+		wantErr: "context: unable to find resource v1 Widget for context entry widget",
+	}, {
+		name: "unknown kind in nested validate foreach context",
+		rule: `{"name": "test", "validate": {"foreach": [{"list": "request.object.spec.containers", "foreach": [
+			{"list": "element.ports", "context": [{"name": "widget", "resource": {"apiVersion": "v1", "kind": "Widget", "name": "widget"}}]}
+		]}]}}`,
+		wantErr: "validate.foreach[0].foreach[0].context: unable to find resource v1 Widget for context entry widget",
+	}, {
+		name: "unknown kind in mutate foreach context",
+		rule: `{"name": "test", "mutate": {"foreach": [{"list": "request.object.spec.containers",
+			"context": [{"name": "widget", "resource": {"apiVersion": "v1", "kind": "Widget", "name": "widget"}}]}]}}`,
+		wantErr: "mutate.foreach[0].context: unable to find resource v1 Widget for context entry widget",
+	}, {
+		name: "unknown kind in mutate target context",
+		rule: `{"name": "test", "mutate": {"targets": [{"apiVersion": "v1", "kind": "ConfigMap", "name": "cm",
+			"context": [{"name": "widget", "resource": {"apiVersion": "v1", "kind": "Widget", "name": "widget"}}]}]}}`,
+		wantErr: "mutate.targets[0].context: unable to find resource v1 Widget for context entry widget",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rule kyverno.Rule
+			assert.NilError(t, json.Unmarshal([]byte(tt.rule), &rule))
+			err := validateRuleResourceLookupKinds(rule, dclient.NewEmptyFakeClient())
+			if tt.wantErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
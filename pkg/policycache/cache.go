@@ -2,6 +2,7 @@ package policycache
 
 import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/utils/wildcard"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -77,16 +78,40 @@ func filterPolicies(pkey PolicyType, result []kyvernov1.PolicyInterface, nspace
 	return policies
 }
 
+// checkValidationFailureActionOverrides checks the validate rules of a policy against the expected action,
+// a policy with rules in both modes is kept only when looking for enforce policies.
 func checkValidationFailureActionOverrides(enforce bool, ns string, policy kyvernov1.PolicyInterface) bool {
-	validationFailureAction := policy.GetSpec().ValidationFailureAction
-	validationFailureActionOverrides := policy.GetSpec().ValidationFailureActionOverrides
-	if validationFailureAction.Enforce() != enforce && (ns == "" || len(validationFailureActionOverrides) == 0) {
+	spec := policy.GetSpec()
+	for _, rule := range autogen.ComputeRules(policy) {
+		if !rule.HasValidate() {
+			continue
+		}
+		validationFailureAction, validationFailureActionOverrides := rule.GetValidationFailureAction(spec)
+		keep := checkRuleValidationFailureActionOverrides(enforce, ns, validationFailureAction, validationFailureActionOverrides)
+		if enforce && keep {
+			return true
+		}
+		if !enforce && !keep {
+			return false
+		}
+	}
+	return !enforce
+}
+
+func checkRuleValidationFailureActionOverrides(enforce bool, ns string, validationFailureAction kyvernov1.ValidationFailureAction, validationFailureActionOverrides []kyvernov1.ValidationFailureActionOverride) bool {
+	if isSynchronous(validationFailureAction) != enforce && (ns == "" || len(validationFailureActionOverrides) == 0) {
 		return false
 	}
 	for _, action := range validationFailureActionOverrides {
-		if action.Action.Enforce() != enforce && wildcard.CheckPatterns(action.Namespaces, ns) {
+		if isSynchronous(action.Action) != enforce && wildcard.CheckPatterns(action.Namespaces, ns) {
 			return false
 		}
 	}
 	return true
 }
+
+// isSynchronous checks if a validation failure action requires the rule to be evaluated in the admission request,
+// Enforce rules block the request and Warn rules return their violations as admission warnings.
+func isSynchronous(action kyvernov1.ValidationFailureAction) bool {
+	return action.Enforce() || action.Warn()
+}
@@ -887,6 +887,119 @@ func newValidateEnforcePolicy(t *testing.T) *kyvernov1.ClusterPolicy {
 	return policy
 }
 
+func newValidateWarnPolicy(t *testing.T) *kyvernov1.ClusterPolicy {
+	rawPolicy := []byte(`{
+		"metadata": {
+		  "name": "check-label-app-warn"
+		},
+		"spec": {
+		  "background": false,
+		  "rules": [
+			{
+				"match": {
+                    "resources": {
+                        "kinds": [
+                            "Pod"
+                        ]
+                    }
+                },
+                "name": "check-label-app",
+                "validate": {
+                    "message": "The label 'app' is required.",
+                    "pattern": {
+                        "metadata": {
+                            "labels": {
+                                "app": "?*"
+                            }
+                        }
+                    }
+                }
+			}
+		  ],
+		  "validationFailureAction": "Warn",
+		  "validationFailureActionOverrides": [
+				{
+					"action": "Audit",
+					"namespaces": [
+						"test"
+					]
+				}
+			]
+		}
+	  }`)
+	var policy *kyvernov1.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+	return policy
+}
+
+func newValidateMixedPolicy(t *testing.T) *kyvernov1.ClusterPolicy {
+	rawPolicy := []byte(`{
+		"metadata": {
+		  "name": "check-label-app-mixed"
+		},
+		"spec": {
+		  "background": false,
+		  "rules": [
+			{
+				"match": {
+                    "resources": {
+                        "kinds": [
+                            "Pod"
+                        ]
+                    }
+                },
+                "name": "check-label-app",
+                "validate": {
+                    "validationFailureAction": "Enforce",
+                    "validationFailureActionOverrides": [
+                        {
+                            "action": "Audit",
+                            "namespaces": [
+                                "test"
+                            ]
+                        }
+                    ],
+                    "message": "The label 'app' is required.",
+                    "pattern": {
+                        "metadata": {
+                            "labels": {
+                                "app": "?*"
+                            }
+                        }
+                    }
+                }
+			},
+			{
+				"match": {
+                    "resources": {
+                        "kinds": [
+                            "Pod"
+                        ]
+                    }
+                },
+                "name": "check-label-team",
+                "validate": {
+                    "message": "The label 'team' is required.",
+                    "pattern": {
+                        "metadata": {
+                            "labels": {
+                                "team": "?*"
+                            }
+                        }
+                    }
+                }
+			}
+		  ],
+		  "validationFailureAction": "Audit"
+		}
+	  }`)
+	var policy *kyvernov1.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.NilError(t, err)
+	return policy
+}
+
 func Test_Ns_All(t *testing.T) {
 	pCache := newPolicyCache()
 	policy := newNsPolicy(t)
@@ -1260,3 +1373,51 @@ func Test_Get_Policies_Validate_Failure_Action_Overrides(t *testing.T) {
 		t.Errorf("expected 2 validate enforce policy, found %v", len(validateEnforce))
 	}
 }
+
+func Test_Get_Policies_Rule_Validation_Failure_Action(t *testing.T) {
+	cache := NewCache()
+	policy := newValidateMixedPolicy(t)
+	finder := TestResourceFinder{}
+	key, _ := kubecache.MetaNamespaceKeyFunc(policy)
+	cache.Set(key, policy, finder)
+	validateAudit := cache.GetPolicies(ValidateAudit, podsGVRS.GroupVersionResource(), "", "")
+	if len(validateAudit) != 0 {
+		t.Errorf("expected 0 validate audit policy, found %v", len(validateAudit))
+	}
+	validateEnforce := cache.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "")
+	if len(validateEnforce) != 1 {
+		t.Errorf("expected 1 validate enforce policy, found %v", len(validateEnforce))
+	}
+	validateAudit = cache.GetPolicies(ValidateAudit, podsGVRS.GroupVersionResource(), "", "test")
+	if len(validateAudit) != 1 {
+		t.Errorf("expected 1 validate audit policy, found %v", len(validateAudit))
+	}
+	validateEnforce = cache.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "test")
+	if len(validateEnforce) != 0 {
+		t.Errorf("expected 0 validate enforce policy, found %v", len(validateEnforce))
+	}
+}
+
+func Test_Get_Policies_Validate_Warn(t *testing.T) {
+	cache := NewCache()
+	policy := newValidateWarnPolicy(t)
+	finder := TestResourceFinder{}
+	key, _ := kubecache.MetaNamespaceKeyFunc(policy)
+	cache.Set(key, policy, finder)
+	validateAudit := cache.GetPolicies(ValidateAudit, podsGVRS.GroupVersionResource(), "", "")
+	if len(validateAudit) != 0 {
+		t.Errorf("expected 0 validate audit policy, found %v", len(validateAudit))
+	}
+	validateEnforce := cache.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "")
+	if len(validateEnforce) != 1 {
+		t.Errorf("expected 1 validate enforce policy, found %v", len(validateEnforce))
+	}
+	validateAudit = cache.GetPolicies(ValidateAudit, podsGVRS.GroupVersionResource(), "", "test")
+	if len(validateAudit) != 1 {
+		t.Errorf("expected 1 validate audit policy, found %v", len(validateAudit))
+	}
+	validateEnforce = cache.GetPolicies(ValidateEnforce, podsGVRS.GroupVersionResource(), "", "test")
+	if len(validateEnforce) != 0 {
+		t.Errorf("expected 0 validate enforce policy, found %v", len(validateEnforce))
+	}
+}
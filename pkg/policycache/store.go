@@ -79,14 +79,21 @@ func newPolicyMap() *policyMap {
 	}
 }
 
+// computeEnforcePolicy checks if any validate rule of the policy can block requests or return warnings.
 func computeEnforcePolicy(spec *kyvernov1.Spec) bool {
-	if spec.ValidationFailureAction.Enforce() {
-		return true
-	}
-	for _, k := range spec.ValidationFailureActionOverrides {
-		if k.Action.Enforce() {
+	for _, rule := range spec.Rules {
+		if !rule.HasValidate() {
+			continue
+		}
+		action, overrides := rule.GetValidationFailureAction(spec)
+		if isSynchronous(action) {
 			return true
 		}
+		for _, k := range overrides {
+			if isSynchronous(k.Action) {
+				return true
+			}
+		}
 	}
 	return false
 }
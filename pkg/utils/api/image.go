@@ -94,7 +94,7 @@ func extract(obj interface{}, path []string, keyPath, valuePath string, fields [
 			return nil
 		}
 		if jmesPath != "" {
-			jp, err := jmespath.New(jmesPath)
+			jp, err := jmespath.New(jmesPath, jmespath.WithConfiguration(cfg))
 			if err != nil {
 				return fmt.Errorf("invalid jmespath %s: %v", jmesPath, err)
 			}
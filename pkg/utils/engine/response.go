@@ -16,11 +16,14 @@ func IsResponseSuccessful(engineReponses []engineapi.EngineResponse) bool {
 }
 
 // BlockRequest returns true when:
-// 1. a policy fails (i.e. creates a violation) and validationFailureAction is set to 'enforce'
+// 1. a rule fails (i.e. creates a violation) and its validationFailureAction is set to 'enforce'
 // 2. a policy has a processing error and failurePolicy is set to 'Fail`
 func BlockRequest(er engineapi.EngineResponse, failurePolicy kyvernov1.FailurePolicyType) bool {
-	if er.IsFailed() && er.GetValidationFailureAction().Enforce() {
-		return true
+	actions := er.GetRuleValidationFailureActions()
+	for _, rule := range er.PolicyResponse.Rules {
+		if rule.Status == engineapi.RuleStatusFail && actions.Get(rule.Name).Enforce() {
+			return true
+		}
 	}
 	if er.IsError() && failurePolicy == kyvernov1.Fail {
 		return true
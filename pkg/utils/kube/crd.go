@@ -18,6 +18,7 @@ func CRDsInstalled(apiserverClient apiserver.Interface) bool {
 		"clustercleanuppolicies.kyverno.io",
 		"clusterpolicies.kyverno.io",
 		"clusterpolicyreports.wgpolicyk8s.io",
+		"globalcontextentries.kyverno.io",
 		"policies.kyverno.io",
 		"policyexceptions.kyverno.io",
 		"policyreports.wgpolicyk8s.io",
@@ -0,0 +1,26 @@
+package policy
+
+import (
+	"sort"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+)
+
+// AppliedBefore checks if the mutations of policy a are applied before the mutations of policy b,
+// policies are ordered by priority, then by namespace and name.
+func AppliedBefore(a, b kyvernov1.PolicyInterface) bool {
+	if pa, pb := a.GetSpec().Priority, b.GetSpec().Priority; pa != pb {
+		return pa < pb
+	}
+	if a.GetNamespace() != b.GetNamespace() {
+		return a.GetNamespace() < b.GetNamespace()
+	}
+	return a.GetName() < b.GetName()
+}
+
+// SortByPriority sorts policies in the order their mutations are applied.
+func SortByPriority(policies []kyvernov1.PolicyInterface) {
+	sort.SliceStable(policies, func(i, j int) bool {
+		return AppliedBefore(policies[i], policies[j])
+	})
+}
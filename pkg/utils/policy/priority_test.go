@@ -0,0 +1,45 @@
+package policy
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSortByPriority(t *testing.T) {
+	newClusterPolicy := func(name string, priority int32) kyvernov1.PolicyInterface {
+		return &kyvernov1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       kyvernov1.Spec{Priority: priority},
+		}
+	}
+	newPolicy := func(namespace, name string, priority int32) kyvernov1.PolicyInterface {
+		return &kyvernov1.Policy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       kyvernov1.Spec{Priority: priority},
+		}
+	}
+	policies := []kyvernov1.PolicyInterface{
+		newPolicy("tenant", "labels", 0),
+		newClusterPolicy("platform-overrides", 100),
+		newClusterPolicy("defaults", 0),
+		newClusterPolicy("early", -10),
+		newPolicy("another-tenant", "labels", 0),
+		newClusterPolicy("annotations", 0),
+	}
+	SortByPriority(policies)
+	var got []string
+	for _, policy := range policies {
+		got = append(got, policy.GetNamespace()+"/"+policy.GetName())
+	}
+	assert.DeepEqual(t, got, []string{
+		"/early",
+		"/annotations",
+		"/defaults",
+		"another-tenant/labels",
+		"tenant/labels",
+		"/platform-overrides",
+	})
+}
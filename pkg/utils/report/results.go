@@ -1,7 +1,9 @@
 package report
 
 import (
+	"encoding/json"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -84,6 +86,7 @@ func severityFromString(severity string) policyreportv1alpha2.PolicySeverity {
 func EngineResponseToReportResults(response engineapi.EngineResponse) []policyreportv1alpha2.PolicyReportResult {
 	key, _ := cache.MetaNamespaceKeyFunc(response.Policy)
 	var results []policyreportv1alpha2.PolicyReportResult
+	actions := response.GetRuleValidationFailureActions()
 	for _, ruleResult := range response.PolicyResponse.Rules {
 		annotations := response.Policy.GetAnnotations()
 		result := policyreportv1alpha2.PolicyReportResult{
@@ -115,9 +118,40 @@ func EngineResponseToReportResults(response engineapi.EngineResponse) []policyre
 				}
 			}
 		}
+		if violation := ruleResult.Violation; violation != nil {
+			properties := map[string]string{
+				"path":       violation.Path,
+				"expected":   violation.Expected,
+				"actual":     violation.Actual,
+				"elementKey": violation.ElementKey,
+			}
+			if violation.ElementIndex != nil {
+				properties["elementIndex"] = strconv.Itoa(*violation.ElementIndex)
+			}
+			for key, value := range properties {
+				if value == "" {
+					continue
+				}
+				if result.Properties == nil {
+					result.Properties = map[string]string{}
+				}
+				result.Properties[key] = value
+			}
+		}
+		if trace := response.PolicyResponse.Trace(ruleResult.Name); trace != nil {
+			if data, err := json.Marshal(trace); err == nil {
+				if result.Properties == nil {
+					result.Properties = map[string]string{}
+				}
+				result.Properties["explain"] = string(data)
+			}
+		}
 		if result.Result == "fail" && !result.Scored {
 			result.Result = "warn"
 		}
+		if result.Result == "fail" && ruleResult.Type == engineapi.Validation && actions.Get(ruleResult.Name).Warn() {
+			result.Result = "warn"
+		}
 		results = append(results, result)
 	}
 	return results
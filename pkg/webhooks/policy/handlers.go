@@ -5,23 +5,37 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/openapi"
 	policyvalidate "github.com/kyverno/kyverno/pkg/policy"
+	"github.com/kyverno/kyverno/pkg/policy/conflicts"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	"github.com/kyverno/kyverno/pkg/webhooks"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 type policyHandlers struct {
 	client         dclient.Interface
 	openApiManager openapi.Manager
+	cpolLister     kyvernov1listers.ClusterPolicyLister
+	polLister      kyvernov1listers.PolicyLister
 }
 
-func NewHandlers(client dclient.Interface, openApiManager openapi.Manager) webhooks.PolicyHandlers {
+func NewHandlers(
+	client dclient.Interface,
+	openApiManager openapi.Manager,
+	cpolLister kyvernov1listers.ClusterPolicyLister,
+	polLister kyvernov1listers.PolicyLister,
+) webhooks.PolicyHandlers {
 	return &policyHandlers{
 		client:         client,
 		openApiManager: openApiManager,
+		cpolLister:     cpolLister,
+		polLister:      polLister,
 	}
 }
 
@@ -34,10 +48,57 @@ func (h *policyHandlers) Validate(ctx context.Context, logger logr.Logger, reque
 	warnings, err := policyvalidate.Validate(policy, oldPolicy, h.client, false, h.openApiManager)
 	if err != nil {
 		logger.Error(err, "policy validation errors")
+	} else {
+		warnings = append(warnings, h.conflictWarnings(logger, policy)...)
 	}
 	return admissionutils.Response(request.UID, err, warnings...)
 }
 
+// conflictWarnings returns a warning for each mutate rule of the policy conflicting with the mutate rules of existing policies,
+// conflicts with policies in other namespaces, which the requester may not be allowed to see, are reported without their details
+func (h *policyHandlers) conflictWarnings(logger logr.Logger, policy kyvernov1.PolicyInterface) []string {
+	if !policy.GetSpec().HasMutate() {
+		return nil
+	}
+	var others []kyvernov1.PolicyInterface
+	cpols, err := h.cpolLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list cluster policies")
+		return nil
+	}
+	for _, cpol := range cpols {
+		others = append(others, cpol)
+	}
+	pols, err := h.polLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list policies")
+		return nil
+	}
+	for _, pol := range pols {
+		others = append(others, pol)
+	}
+	var visible, hidden []kyvernov1.PolicyInterface
+	for _, other := range others {
+		if other.GetNamespace() == policy.GetNamespace() {
+			visible = append(visible, other)
+		} else {
+			hidden = append(hidden, other)
+		}
+	}
+	var warnings []string
+	for _, conflict := range conflicts.FindWith(policy, visible) {
+		warnings = append(warnings, conflict.String())
+	}
+	summaries := sets.New[string]()
+	for _, conflict := range conflicts.FindWith(policy, hidden) {
+		if summary := conflict.Summary(); !summaries.Has(summary) {
+			summaries.Insert(summary)
+			warnings = append(warnings, summary)
+		}
+	}
+	return warnings
+}
+
 func (h *policyHandlers) Mutate(_ context.Context, _ logr.Logger, request handlers.AdmissionRequest, _ time.Time) handlers.AdmissionResponse {
 	return admissionutils.ResponseSuccess(request.UID)
 }
@@ -134,7 +134,7 @@ func (v *validationHandler) HandleValidation(
 
 	if blocked {
 		logger.V(4).Info("admission request blocked")
-		return false, webhookutils.GetBlockedMessages(engineResponses), nil
+		return false, webhookutils.GetBlockedMessages(engineResponses), webhookutils.GetWarnActionMessages(engineResponses)
 	}
 
 	go v.handleAudit(ctx, policyContext.NewResource(), request, policyContext.NamespaceLabels(), engineResponses...)
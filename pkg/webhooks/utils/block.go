@@ -34,7 +34,8 @@ func BlockRequest(engineResponses []engineapi.EngineResponse, failurePolicy kyve
 	return false
 }
 
-// GetBlockedMessages gets the error messages for rules with error or fail status
+// GetBlockedMessages gets the error messages for rules with error or fail status,
+// failures of rules in audit or warn mode are not reported
 func GetBlockedMessages(engineResponses []engineapi.EngineResponse) string {
 	if len(engineResponses) == 0 {
 		return ""
@@ -43,7 +44,11 @@ func GetBlockedMessages(engineResponses []engineapi.EngineResponse) string {
 	hasViolations := false
 	for _, er := range engineResponses {
 		ruleToReason := make(map[string]string)
+		actions := er.GetRuleValidationFailureActions()
 		for _, rule := range er.PolicyResponse.Rules {
+			if rule.Status == engineapi.RuleStatusFail && !actions.Get(rule.Name).Enforce() {
+				continue
+			}
 			if rule.Status != engineapi.RuleStatusPass {
 				ruleToReason[rule.Name] = rule.Message
 				if rule.Status == engineapi.RuleStatusFail {
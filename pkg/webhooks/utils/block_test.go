@@ -63,6 +63,31 @@ func TestBlockRequest(t *testing.T) {
 			ValidationFailureAction: kyvernov1.Enforce,
 		},
 	}
+	warnPolicy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: kyvernov1.Spec{
+			ValidationFailureAction: kyvernov1.Warn,
+		},
+	}
+	enforce := kyvernov1.Enforce
+	audit := kyvernov1.Audit
+	mixedPolicy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: kyvernov1.Spec{
+			ValidationFailureAction: kyvernov1.Audit,
+			Rules: []kyvernov1.Rule{{
+				Name:       "rule-enforce",
+				Validation: kyvernov1.Validation{ValidationFailureAction: &enforce},
+			}, {
+				Name:       "rule-audit",
+				Validation: kyvernov1.Validation{ValidationFailureAction: &audit},
+			}},
+		},
+	}
 	resource := unstructured.Unstructured{
 		Object: map[string]interface{}{
 			"kind": "foo",
@@ -117,6 +142,65 @@ func TestBlockRequest(t *testing.T) {
 			log:           logr.Discard(),
 		},
 		want: false,
+	}, {
+		name: "failure - warn",
+		args: args{
+			engineResponses: []engineapi.EngineResponse{
+				engineapi.NewEngineResponse(resource, warnPolicy, nil, &engineapi.PolicyResponse{
+					Rules: []engineapi.RuleResponse{
+						{
+							Name:    "rule-fail",
+							Status:  engineapi.RuleStatusFail,
+							Message: "message fail",
+						},
+					},
+				}, time.Now()),
+			},
+			failurePolicy: kyvernov1.Fail,
+			log:           logr.Discard(),
+		},
+		want: false,
+	}, {
+		name: "failure - rule enforce",
+		args: args{
+			engineResponses: []engineapi.EngineResponse{
+				engineapi.NewEngineResponse(resource, mixedPolicy, nil, &engineapi.PolicyResponse{
+					Rules: []engineapi.RuleResponse{
+						{
+							Name:    "rule-enforce",
+							Status:  engineapi.RuleStatusFail,
+							Message: "message fail",
+						},
+					},
+				}, time.Now()),
+			},
+			failurePolicy: kyvernov1.Fail,
+			log:           logr.Discard(),
+		},
+		want: true,
+	}, {
+		name: "failure - rule audit",
+		args: args{
+			engineResponses: []engineapi.EngineResponse{
+				engineapi.NewEngineResponse(resource, mixedPolicy, nil, &engineapi.PolicyResponse{
+					Rules: []engineapi.RuleResponse{
+						{
+							Name:    "rule-enforce",
+							Status:  engineapi.RuleStatusPass,
+							Message: "message pass",
+						},
+						{
+							Name:    "rule-audit",
+							Status:  engineapi.RuleStatusFail,
+							Message: "message fail",
+						},
+					},
+				}, time.Now()),
+			},
+			failurePolicy: kyvernov1.Fail,
+			log:           logr.Discard(),
+		},
+		want: false,
 	}, {
 		name: "error - fail",
 		args: args{
@@ -207,6 +291,26 @@ func TestGetBlockedMessages(t *testing.T) {
 			ValidationFailureAction: kyvernov1.Enforce,
 		},
 	}
+	enforce := kyvernov1.Enforce
+	audit := kyvernov1.Audit
+	warn := kyvernov1.Warn
+	mixedPolicy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{
+				Name:       "rule-enforce",
+				Validation: kyvernov1.Validation{ValidationFailureAction: &enforce},
+			}, {
+				Name:       "rule-audit",
+				Validation: kyvernov1.Validation{ValidationFailureAction: &audit},
+			}, {
+				Name:       "rule-warn",
+				Validation: kyvernov1.Validation{ValidationFailureAction: &warn},
+			}},
+		},
+	}
 	resource := unstructured.Unstructured{
 		Object: map[string]interface{}{
 			"kind": "foo",
@@ -276,6 +380,48 @@ func TestGetBlockedMessages(t *testing.T) {
 			},
 		},
 		want: "\n\npolicy foo/bar/baz for resource violation: \n\ntest:\n  rule-error: message error\n  rule-fail: message fail\n",
+	}, {
+		name: "failure - rule enforce and rule audit",
+		args: args{
+			engineResponses: []engineapi.EngineResponse{
+				engineapi.NewEngineResponse(resource, mixedPolicy, nil, &engineapi.PolicyResponse{
+					Rules: []engineapi.RuleResponse{
+						{
+							Name:    "rule-enforce",
+							Status:  engineapi.RuleStatusFail,
+							Message: "message enforce",
+						},
+						{
+							Name:    "rule-audit",
+							Status:  engineapi.RuleStatusFail,
+							Message: "message audit",
+						},
+					},
+				}, time.Now()),
+			},
+		},
+		want: "\n\npolicy foo/bar/baz for resource violation: \n\ntest:\n  rule-enforce: message enforce\n",
+	}, {
+		name: "failure - rule enforce and rule warn",
+		args: args{
+			engineResponses: []engineapi.EngineResponse{
+				engineapi.NewEngineResponse(resource, mixedPolicy, nil, &engineapi.PolicyResponse{
+					Rules: []engineapi.RuleResponse{
+						{
+							Name:    "rule-enforce",
+							Status:  engineapi.RuleStatusFail,
+							Message: "message enforce",
+						},
+						{
+							Name:    "rule-warn",
+							Status:  engineapi.RuleStatusFail,
+							Message: "message warn",
+						},
+					},
+				}, time.Now()),
+			},
+		},
+		want: "\n\npolicy foo/bar/baz for resource violation: \n\ntest:\n  rule-enforce: message enforce\n",
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
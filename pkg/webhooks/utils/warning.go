@@ -18,3 +18,19 @@ func GetWarningMessages(engineResponses []engineapi.EngineResponse) []string {
 	}
 	return warnings
 }
+
+// GetWarnActionMessages gets the warning messages for failed rules in warn mode,
+// they are returned with the admission response even when the request is blocked by other rules
+func GetWarnActionMessages(engineResponses []engineapi.EngineResponse) []string {
+	var warnings []string
+	for _, er := range engineResponses {
+		actions := er.GetRuleValidationFailureActions()
+		for _, rule := range er.PolicyResponse.Rules {
+			if rule.Status == engineapi.RuleStatusFail && actions.Get(rule.Name).Warn() {
+				msg := fmt.Sprintf("policy %s.%s: %s", er.Policy.GetName(), rule.Name, rule.Message)
+				warnings = append(warnings, msg)
+			}
+		}
+	}
+	return warnings
+}
@@ -101,3 +101,72 @@ func TestGetWarningMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestGetWarnActionMessages(t *testing.T) {
+	enforce := v1.Enforce
+	warn := v1.Warn
+	policy := &v1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: v1.Spec{
+			Rules: []v1.Rule{{
+				Name:       "rule-enforce",
+				Validation: v1.Validation{ValidationFailureAction: &enforce},
+			}, {
+				Name:       "rule-warn",
+				Validation: v1.Validation{ValidationFailureAction: &warn},
+			}, {
+				Name:       "rule-warn-pass",
+				Validation: v1.Validation{ValidationFailureAction: &warn},
+			}},
+		},
+	}
+	type args struct {
+		engineResponses []engineapi.EngineResponse
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{{
+		name: "nil response",
+		args: args{nil},
+		want: nil,
+	}, {
+		name: "enforce and warn rules",
+		args: args{[]engineapi.EngineResponse{
+			{
+				Policy: policy,
+				PolicyResponse: engineapi.PolicyResponse{
+					Rules: []engineapi.RuleResponse{
+						{
+							Name:    "rule-enforce",
+							Status:  engineapi.RuleStatusFail,
+							Message: "message enforce",
+						},
+						{
+							Name:    "rule-warn",
+							Status:  engineapi.RuleStatusFail,
+							Message: "message warn",
+						},
+						{
+							Name:    "rule-warn-pass",
+							Status:  engineapi.RuleStatusPass,
+							Message: "message pass",
+						},
+					},
+				},
+			},
+		}},
+		want: []string{
+			"policy test.rule-warn: message warn",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetWarnActionMessages(tt.args.engineResponses)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}